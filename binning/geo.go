@@ -39,3 +39,15 @@ func LonLatToFractionalTile(lonLat *LonLat, level uint32) *FractionalTileCoord {
 		Z: level,
 	}
 }
+
+// FractionalTileToLonLat converts a floating point tile coordinate into a
+// geographic coordinate.
+func FractionalTileToLonLat(tile *FractionalTileCoord) *LonLat {
+	pow2 := math.Pow(2, float64(tile.Z))
+	lon := tile.X/pow2*(maxLon*2) - maxLon
+	lat := math.Atan(math.Sinh(math.Pi*(2*tile.Y/pow2-1))) * radiansToDegrees
+	return &LonLat{
+		Lon: lon,
+		Lat: lat,
+	}
+}
@@ -0,0 +1,109 @@
+package binning
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// FlatHex is the orientation of hexagons with a flat top edge.
+	FlatHex = "flat"
+	// PointyHex is the orientation of hexagons with a pointed top vertex.
+	PointyHex = "pointy"
+)
+
+var (
+	sqrt3 = math.Sqrt(3)
+)
+
+// HexCoord represents the axial coordinate of a hexagonal cell.
+type HexCoord struct {
+	Q int64 `json:"q"`
+	R int64 `json:"r"`
+}
+
+// HexGrid represents a grid of hexagonal cells laid over the global pixel
+// space of a zoom level, with its origin at the bottom-left. Since the grid
+// does not depend on the tile, cells straddling a tile seam share the same
+// axial coordinate in each tile.
+type HexGrid struct {
+	// Size is the distance in pixels from the center of a cell to its
+	// vertices.
+	Size        float64
+	Orientation string
+}
+
+// NewHexGrid instantiates and returns a hexagonal grid.
+func NewHexGrid(size float64, orientation string) (*HexGrid, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("hex size must be greater than 0")
+	}
+	if orientation != FlatHex && orientation != PointyHex {
+		return nil, fmt.Errorf("unrecognized hex orientation `%s`", orientation)
+	}
+	return &HexGrid{
+		Size:        size,
+		Orientation: orientation,
+	}, nil
+}
+
+// Basis returns the matrix [a b; c d] which converts a pixel coordinate into
+// a fractional axial coordinate, such that q = a*x + b*y and r = c*x + d*y.
+func (g *HexGrid) Basis() (float64, float64, float64, float64) {
+	if g.Orientation == FlatHex {
+		return 2.0 / 3.0 / g.Size,
+			0,
+			-1.0 / 3.0 / g.Size,
+			sqrt3 / 3.0 / g.Size
+	}
+	return sqrt3 / 3.0 / g.Size,
+		-1.0 / 3.0 / g.Size,
+		0,
+		2.0 / 3.0 / g.Size
+}
+
+// PixelToFractionalHex converts a global pixel coordinate into a fractional
+// axial coordinate.
+func (g *HexGrid) PixelToFractionalHex(x, y float64) (float64, float64) {
+	a, b, c, d := g.Basis()
+	return a*x + b*y, c*x + d*y
+}
+
+// PixelToHex returns the cell containing the global pixel coordinate.
+func (g *HexGrid) PixelToHex(x, y float64) *HexCoord {
+	return RoundHex(g.PixelToFractionalHex(x, y))
+}
+
+// HexToPixel returns the global pixel coordinate of the center of the cell.
+func (g *HexGrid) HexToPixel(hex *HexCoord) (float64, float64) {
+	q := float64(hex.Q)
+	r := float64(hex.R)
+	if g.Orientation == FlatHex {
+		return g.Size * (1.5 * q),
+			g.Size * (sqrt3/2*q + sqrt3*r)
+	}
+	return g.Size * (sqrt3*q + sqrt3/2*r),
+		g.Size * (1.5 * r)
+}
+
+// RoundHex rounds a fractional axial coordinate to the nearest cell.
+func RoundHex(q, r float64) *HexCoord {
+	s := -q - r
+	rq := math.Floor(q + 0.5)
+	rr := math.Floor(r + 0.5)
+	rs := math.Floor(s + 0.5)
+	dq := math.Abs(rq - q)
+	dr := math.Abs(rr - r)
+	ds := math.Abs(rs - s)
+	// the cube coordinates must sum to 0, so reset the component with the
+	// largest rounding error
+	if dq > dr && dq > ds {
+		rq = -rr - rs
+	} else if dr > ds {
+		rr = -rq - rs
+	}
+	return &HexCoord{
+		Q: int64(rq),
+		R: int64(rr),
+	}
+}
@@ -0,0 +1,69 @@
+package binning_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/unchartedsoftware/veldt/binning"
+)
+
+var _ = Describe("hex", func() {
+
+	const (
+		epsilon = 0.000001
+	)
+
+	Describe("NewHexGrid", func() {
+		It("should return an error if the size is not positive", func() {
+			_, err := binning.NewHexGrid(0, binning.PointyHex)
+			Expect(err).NotTo(BeNil())
+		})
+		It("should return an error if the orientation is not recognized", func() {
+			_, err := binning.NewHexGrid(16, "round")
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("RoundHex", func() {
+		It("should round a fractional axial coordinate to the nearest cell", func() {
+			Expect(*binning.RoundHex(0.1, -0.1)).To(Equal(binning.HexCoord{Q: 0, R: 0}))
+			Expect(*binning.RoundHex(1.2, 0.1)).To(Equal(binning.HexCoord{Q: 1, R: 0}))
+			Expect(*binning.RoundHex(-2.1, 1.9)).To(Equal(binning.HexCoord{Q: -2, R: 2}))
+			Expect(*binning.RoundHex(1.4, 0.4)).To(Equal(binning.HexCoord{Q: 1, R: 1}))
+		})
+	})
+
+	Describe("HexGrid", func() {
+		It("should return the cell containing the center of a cell", func() {
+			for _, orientation := range []string{binning.FlatHex, binning.PointyHex} {
+				grid, err := binning.NewHexGrid(10, orientation)
+				Expect(err).To(BeNil())
+				for q := int64(-3); q <= 3; q++ {
+					for r := int64(-3); r <= 3; r++ {
+						hex := &binning.HexCoord{Q: q, R: r}
+						x, y := grid.HexToPixel(hex)
+						Expect(grid.PixelToHex(x, y)).To(Equal(hex))
+						// points within the inner radius belong to the cell
+						Expect(grid.PixelToHex(x+8, y)).To(Equal(hex))
+						Expect(grid.PixelToHex(x, y-8)).To(Equal(hex))
+					}
+				}
+			}
+		})
+		It("should lay out pointy cells in offset rows", func() {
+			grid, err := binning.NewHexGrid(10, binning.PointyHex)
+			Expect(err).To(BeNil())
+			x, y := grid.HexToPixel(&binning.HexCoord{Q: 0, R: 1})
+			Expect(x).To(BeNumerically("~", 8.660254, epsilon))
+			Expect(y).To(BeNumerically("~", 15.0, epsilon))
+		})
+		It("should lay out flat cells in offset columns", func() {
+			grid, err := binning.NewHexGrid(10, binning.FlatHex)
+			Expect(err).To(BeNil())
+			x, y := grid.HexToPixel(&binning.HexCoord{Q: 1, R: 0})
+			Expect(x).To(BeNumerically("~", 15.0, epsilon))
+			Expect(y).To(BeNumerically("~", 8.660254, epsilon))
+		})
+	})
+
+})
@@ -0,0 +1,232 @@
+package binning
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/unchartedsoftware/veldt/geometry"
+)
+
+const (
+	// LinearProjection is the ID of the linear cartesian projection.
+	LinearProjection = "linear"
+	// MercatorProjection is the ID of the web mercator projection.
+	MercatorProjection = "mercator"
+	// PlateCarreeProjection is the ID of the equirectangular projection.
+	PlateCarreeProjection = "platecarree"
+)
+
+var (
+	mutex       = sync.RWMutex{}
+	projections = map[string]ProjectionCtor{
+		LinearProjection:      NewLinear,
+		MercatorProjection:    NewMercator,
+		"EPSG:3857":           NewMercator,
+		PlateCarreeProjection: NewPlateCarree,
+		"EPSG:4326":           NewPlateCarree,
+	}
+)
+
+// Projection represents a map projection between data coordinates and a tile
+// grid whose origin is at the bottom-left.
+type Projection interface {
+	// Project converts a data coordinate into a fractional tile coordinate at
+	// the provided zoom level.
+	Project(coord *geometry.Coord, level uint32) *FractionalTileCoord
+	// Unproject converts a fractional tile coordinate into a data coordinate.
+	Unproject(tile *FractionalTileCoord) *geometry.Coord
+	// TileBounds returns the data coordinate bounds of the tile coordinate.
+	TileBounds(tile *TileCoord) *geometry.Bounds
+	// GridSize returns the number of tiles across the x and y axes at zoom
+	// level 0.
+	GridSize() (uint32, uint32)
+}
+
+// Projected represents a tile whose coordinates lie on the grid of a
+// projection.
+type Projected interface {
+	GetProjection() Projection
+}
+
+// ProjectionCtor represents a function that instantiates a projection for the
+// provided data bounds. Projections with a fixed extent ignore the bounds,
+// which may be nil.
+type ProjectionCtor func(bounds *geometry.Bounds) (Projection, error)
+
+// RegisterProjection registers a projection under the provided ID string.
+func RegisterProjection(id string, ctor ProjectionCtor) {
+	mutex.Lock()
+	projections[id] = ctor
+	mutex.Unlock()
+	runtime.Gosched()
+}
+
+// GetProjection instantiates the projection registered under the provided ID
+// string for the provided data bounds.
+func GetProjection(id string, bounds *geometry.Bounds) (Projection, error) {
+	mutex.RLock()
+	ctor, ok := projections[id]
+	mutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unrecognized projection `%s`", id)
+	}
+	return ctor(bounds)
+}
+
+// Projections returns the IDs of all registered projections in sorted order.
+func Projections() []string {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	ids := make([]string, 0, len(projections))
+	for id := range projections {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Linear represents a linear projection of a cartesian extent onto a single
+// tile at zoom level 0.
+type Linear struct {
+	Bounds *geometry.Bounds
+}
+
+// NewLinear instantiates and returns a linear projection of the provided
+// bounds.
+func NewLinear(bounds *geometry.Bounds) (Projection, error) {
+	if bounds == nil {
+		return nil, fmt.Errorf("`%s` projection requires bounds", LinearProjection)
+	}
+	return &Linear{
+		Bounds: bounds,
+	}, nil
+}
+
+// Project converts a data coordinate into a fractional tile coordinate.
+func (p *Linear) Project(coord *geometry.Coord, level uint32) *FractionalTileCoord {
+	return CoordToFractionalTile(coord, level, p.Bounds)
+}
+
+// Unproject converts a fractional tile coordinate into a data coordinate.
+func (p *Linear) Unproject(tile *FractionalTileCoord) *geometry.Coord {
+	pow2 := math.Pow(2, float64(tile.Z))
+	return geometry.NewCoord(
+		p.Bounds.Left+(tile.X/pow2)*(p.Bounds.Right-p.Bounds.Left),
+		p.Bounds.Bottom+(tile.Y/pow2)*(p.Bounds.Top-p.Bounds.Bottom))
+}
+
+// TileBounds returns the data coordinate bounds of the tile coordinate.
+func (p *Linear) TileBounds(tile *TileCoord) *geometry.Bounds {
+	return GetTileBounds(tile, p.Bounds)
+}
+
+// GridSize returns a single tile at zoom level 0.
+func (p *Linear) GridSize() (uint32, uint32) {
+	return 1, 1
+}
+
+// Mercator represents the web mercator projection (EPSG:3857) of lon / lat
+// coordinates, with latitudes clamped to the square extent of the map.
+type Mercator struct{}
+
+// NewMercator instantiates and returns a web mercator projection.
+func NewMercator(bounds *geometry.Bounds) (Projection, error) {
+	return &Mercator{}, nil
+}
+
+// Project converts a lon / lat coordinate into a fractional tile coordinate.
+func (p *Mercator) Project(coord *geometry.Coord, level uint32) *FractionalTileCoord {
+	return LonLatToFractionalTile(NewLonLat(coord.X, coord.Y), level)
+}
+
+// Unproject converts a fractional tile coordinate into a lon / lat coordinate.
+func (p *Mercator) Unproject(tile *FractionalTileCoord) *geometry.Coord {
+	lonLat := FractionalTileToLonLat(tile)
+	return geometry.NewCoord(lonLat.Lon, lonLat.Lat)
+}
+
+// TileBounds returns the lon / lat bounds of the tile coordinate.
+func (p *Mercator) TileBounds(tile *TileCoord) *geometry.Bounds {
+	return projectedTileBounds(p, tile)
+}
+
+// GridSize returns a single tile at zoom level 0.
+func (p *Mercator) GridSize() (uint32, uint32) {
+	return 1, 1
+}
+
+// PlateCarree represents the equirectangular projection (EPSG:4326) of
+// lon / lat coordinates, with two tiles at zoom level 0.
+type PlateCarree struct{}
+
+// NewPlateCarree instantiates and returns an equirectangular projection.
+func NewPlateCarree(bounds *geometry.Bounds) (Projection, error) {
+	return &PlateCarree{}, nil
+}
+
+// Project converts a lon / lat coordinate into a fractional tile coordinate.
+func (p *PlateCarree) Project(coord *geometry.Coord, level uint32) *FractionalTileCoord {
+	pow2 := math.Pow(2, float64(level))
+	lon := math.Min(maxLon, math.Max(minLon, coord.X))
+	lat := math.Min(90, math.Max(-90, coord.Y))
+	return &FractionalTileCoord{
+		X: (lon - minLon) / (maxLon - minLon) * 2 * pow2,
+		Y: (lat + 90) / 180 * pow2,
+		Z: level,
+	}
+}
+
+// Unproject converts a fractional tile coordinate into a lon / lat coordinate.
+func (p *PlateCarree) Unproject(tile *FractionalTileCoord) *geometry.Coord {
+	pow2 := math.Pow(2, float64(tile.Z))
+	return geometry.NewCoord(
+		minLon+tile.X/(2*pow2)*(maxLon-minLon),
+		-90+tile.Y/pow2*180)
+}
+
+// TileBounds returns the lon / lat bounds of the tile coordinate.
+func (p *PlateCarree) TileBounds(tile *TileCoord) *geometry.Bounds {
+	return projectedTileBounds(p, tile)
+}
+
+// GridSize returns two tiles across the x axis at zoom level 0.
+func (p *PlateCarree) GridSize() (uint32, uint32) {
+	return 2, 1
+}
+
+// ValidateTileCoord returns an error if the tile coordinate is outside of the
+// grid of the projection at its zoom level.
+func ValidateTileCoord(p Projection, coord *TileCoord) error {
+	if coord.Z >= 32 {
+		// every uint32 coordinate is within the grid
+		return nil
+	}
+	x, y := p.GridSize()
+	width := uint64(x) << coord.Z
+	height := uint64(y) << coord.Z
+	if uint64(coord.X) >= width || uint64(coord.Y) >= height {
+		return fmt.Errorf("`coord` is outside of the %d by %d tile grid of zoom level %d", width, height, coord.Z)
+	}
+	return nil
+}
+
+func projectedTileBounds(p Projection, tile *TileCoord) *geometry.Bounds {
+	bottomLeft := p.Unproject(&FractionalTileCoord{
+		X: float64(tile.X),
+		Y: float64(tile.Y),
+		Z: tile.Z,
+	})
+	topRight := p.Unproject(&FractionalTileCoord{
+		X: float64(tile.X + 1),
+		Y: float64(tile.Y + 1),
+		Z: tile.Z,
+	})
+	return geometry.NewBounds(
+		bottomLeft.X,
+		topRight.X,
+		bottomLeft.Y,
+		topRight.Y)
+}
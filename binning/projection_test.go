@@ -0,0 +1,112 @@
+package binning_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/geometry"
+)
+
+var _ = Describe("projection", func() {
+
+	const (
+		epsilon = 0.000001
+	)
+
+	Describe("GetProjection", func() {
+		It("should return an error for an unrecognized projection", func() {
+			_, err := binning.GetProjection("unknown", nil)
+			Expect(err).NotTo(BeNil())
+		})
+		It("should return an error for a linear projection without bounds", func() {
+			_, err := binning.GetProjection(binning.LinearProjection, nil)
+			Expect(err).NotTo(BeNil())
+		})
+		It("should recognize EPSG codes", func() {
+			p, err := binning.GetProjection("EPSG:3857", nil)
+			Expect(err).To(BeNil())
+			Expect(p).To(BeAssignableToTypeOf(&binning.Mercator{}))
+			p, err = binning.GetProjection("EPSG:4326", nil)
+			Expect(err).To(BeNil())
+			Expect(p).To(BeAssignableToTypeOf(&binning.PlateCarree{}))
+		})
+	})
+
+	Describe("Linear", func() {
+		It("should unproject a projected coordinate", func() {
+			p, err := binning.GetProjection(binning.LinearProjection, geometry.NewBounds(-1, 1, -1, 1))
+			Expect(err).To(BeNil())
+			tile := p.Project(geometry.NewCoord(0.25, -0.5), 4)
+			coord := p.Unproject(tile)
+			Expect(coord.X).To(BeNumerically("~", 0.25, epsilon))
+			Expect(coord.Y).To(BeNumerically("~", -0.5, epsilon))
+		})
+	})
+
+	Describe("Mercator", func() {
+		It("should unproject a projected coordinate", func() {
+			p := &binning.Mercator{}
+			tile := p.Project(geometry.NewCoord(-73.5, 45.5), 8)
+			coord := p.Unproject(tile)
+			Expect(coord.X).To(BeNumerically("~", -73.5, epsilon))
+			Expect(coord.Y).To(BeNumerically("~", 45.5, epsilon))
+		})
+		It("should return the lon / lat bounds of a tile", func() {
+			p := &binning.Mercator{}
+			bounds := p.TileBounds(&binning.TileCoord{Z: 1, X: 1, Y: 1})
+			Expect(bounds.Left).To(BeNumerically("~", 0.0, epsilon))
+			Expect(bounds.Right).To(BeNumerically("~", 180.0, epsilon))
+			Expect(bounds.Bottom).To(BeNumerically("~", 0.0, epsilon))
+			Expect(bounds.Top).To(BeNumerically("~", 85.05112878, epsilon))
+		})
+		It("should have a single tile at zoom level 0", func() {
+			x, y := (&binning.Mercator{}).GridSize()
+			Expect(x).To(Equal(uint32(1)))
+			Expect(y).To(Equal(uint32(1)))
+		})
+	})
+
+	Describe("PlateCarree", func() {
+		It("should unproject a projected coordinate", func() {
+			p := &binning.PlateCarree{}
+			tile := p.Project(geometry.NewCoord(-73.5, 45.5), 8)
+			coord := p.Unproject(tile)
+			Expect(coord.X).To(BeNumerically("~", -73.5, epsilon))
+			Expect(coord.Y).To(BeNumerically("~", 45.5, epsilon))
+		})
+		It("should have two tiles across the x axis at zoom level 0", func() {
+			p := &binning.PlateCarree{}
+			x, y := p.GridSize()
+			Expect(x).To(Equal(uint32(2)))
+			Expect(y).To(Equal(uint32(1)))
+			bounds := p.TileBounds(&binning.TileCoord{Z: 0, X: 0, Y: 0})
+			Expect(bounds.Left).To(Equal(-180.0))
+			Expect(bounds.Right).To(Equal(0.0))
+			Expect(bounds.Bottom).To(Equal(-90.0))
+			Expect(bounds.Top).To(Equal(90.0))
+		})
+	})
+
+	Describe("ValidateTileCoord", func() {
+		It("should accept coordinates within the grid of the zoom level", func() {
+			err := binning.ValidateTileCoord(&binning.Mercator{}, &binning.TileCoord{Z: 2, X: 3, Y: 3})
+			Expect(err).To(BeNil())
+			err = binning.ValidateTileCoord(&binning.PlateCarree{}, &binning.TileCoord{Z: 2, X: 7, Y: 3})
+			Expect(err).To(BeNil())
+		})
+		It("should return an error for coordinates outside of the grid", func() {
+			err := binning.ValidateTileCoord(&binning.Mercator{}, &binning.TileCoord{Z: 2, X: 4, Y: 0})
+			Expect(err).NotTo(BeNil())
+			err = binning.ValidateTileCoord(&binning.Mercator{}, &binning.TileCoord{Z: 0, X: 0, Y: 1})
+			Expect(err).NotTo(BeNil())
+			err = binning.ValidateTileCoord(&binning.PlateCarree{}, &binning.TileCoord{Z: 2, X: 8, Y: 0})
+			Expect(err).NotTo(BeNil())
+		})
+		It("should accept every coordinate beyond zoom level 31", func() {
+			err := binning.ValidateTileCoord(&binning.Mercator{}, &binning.TileCoord{Z: 32, X: 4294967295, Y: 4294967295})
+			Expect(err).To(BeNil())
+		})
+	})
+
+})
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+var (
+	mutex    = sync.RWMutex{}
+	backends = make(map[string]BackendFactory)
+	stores   = make(map[string]StoreFactory)
+)
+
+// Backend represents the tile, meta and query constructors provided by a
+// generation backend.
+type Backend struct {
+	Queries map[string]veldt.QueryCtor
+	Binary  veldt.QueryCtor
+	Unary   veldt.QueryCtor
+	Tiles   map[string]veldt.TileCtor
+	Metas   map[string]veldt.MetaCtor
+	// GenericQuery, if set, instantiates constructors for query IDs that are
+	// not found in Queries.
+	GenericQuery func(id string) veldt.QueryCtor
+}
+
+// BackendFactory represents a function that instantiates a backend from its
+// connection parameters.
+type BackendFactory func(params map[string]interface{}) (*Backend, error)
+
+// StoreFactory represents a function that instantiates a store constructor
+// from its connection parameters.
+type StoreFactory func(params map[string]interface{}) (veldt.StoreCtor, error)
+
+// RegisterBackend registers a backend factory under the provided type string.
+func RegisterBackend(typ string, factory BackendFactory) {
+	mutex.Lock()
+	backends[typ] = factory
+	mutex.Unlock()
+	runtime.Gosched()
+}
+
+// RegisterStore registers a store factory under the provided type string.
+func RegisterStore(typ string, factory StoreFactory) {
+	mutex.Lock()
+	stores[typ] = factory
+	mutex.Unlock()
+	runtime.Gosched()
+}
+
+// ValidateParams checks the connection parameters of a backend or store
+// against their descriptions, as json.ValidateParams does, and additionally
+// rejects any parameter other than `type` which is not described, so that
+// misspelt parameters are not silently ignored.
+func ValidateParams(params map[string]interface{}, described []json.Param) error {
+	err := json.ValidateParams(params, described)
+	if err != nil {
+		return err
+	}
+	keys := unknownKeys(params, append([]json.Param{{Key: "type"}}, described...))
+	if len(keys) > 0 {
+		return unrecognizedParam(params, keys[0])
+	}
+	return nil
+}
+
+func getBackend(typ string) (BackendFactory, bool) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	factory, ok := backends[typ]
+	return factory, ok
+}
+
+func getStore(typ string) (StoreFactory, bool) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	factory, ok := stores[typ]
+	return factory, ok
+}
+
+func (b *Backend) query(id string) (veldt.QueryCtor, bool) {
+	ctor, ok := b.Queries[id]
+	if ok {
+		return ctor, true
+	}
+	if b.GenericQuery != nil {
+		return b.GenericQuery(id), true
+	}
+	return nil, false
+}
+
+// Error represents an invalid configuration document. It lists every issue
+// found in the document.
+type Error struct {
+	Issues []json.Issue
+}
+
+// Error returns each issue on its own line, prefixed by its JSON pointer.
+func (e *Error) Error() string {
+	lines := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		lines[i] = fmt.Sprintf("%s: %s", issue.Path, issue.Message)
+	}
+	return fmt.Sprintf("invalid config:\n%s", strings.Join(lines, "\n"))
+}
+
+// unknownKeys returns the sorted keys of the object which are not described by
+// the parameters.
+func unknownKeys(obj map[string]interface{}, params []json.Param) []string {
+	known := make(map[string]bool, len(params))
+	for _, param := range params {
+		known[param.Key] = true
+	}
+	var keys []string
+	for _, key := range sortedKeys(obj) {
+		if !known[key] {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedQueryIDs(ctors map[string]veldt.QueryCtor) []string {
+	ids := make([]string, 0, len(ctors))
+	for id := range ctors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func sortedTileIDs(ctors map[string]veldt.TileCtor) []string {
+	ids := make([]string, 0, len(ctors))
+	for id := range ctors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func sortedMetaIDs(ctors map[string]veldt.MetaCtor) []string {
+	ids := make([]string, 0, len(ctors))
+	for id := range ctors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
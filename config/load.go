@@ -0,0 +1,349 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+const (
+	defaultCompression = "gzip"
+	// renderTileID is the ID of the render tile type, which is available to
+	// every backend.
+	renderTileID = "png"
+)
+
+var (
+	documentParams = []json.Param{
+		{Key: "pipelines", Type: json.TypeObject, Required: true},
+	}
+	pipelineParams = []json.Param{
+		{Key: "backend", Type: json.TypeObject, Required: true},
+		{Key: "tiles", Type: json.TypeArray, Items: json.TypeString},
+		{Key: "metas", Type: json.TypeArray, Items: json.TypeString},
+		{Key: "queries", Type: json.TypeArray, Items: json.TypeString},
+		{Key: "store", Type: json.TypeObject},
+		{Key: "queue", Type: json.TypeObject},
+		{
+			Key:     "compression",
+			Type:    json.TypeString,
+			Default: defaultCompression,
+			Enum:    []interface{}{"gzip", "zlib", "none"},
+		},
+	}
+	typeParams = []json.Param{
+		{Key: "type", Type: json.TypeString, Required: true},
+	}
+	queueParams = []json.Param{
+		{Key: "maxConcurrent", Type: json.TypeInteger},
+		{Key: "length", Type: json.TypeInteger},
+	}
+)
+
+// Load parses the provided YAML or JSON document and returns the pipelines it
+// declares by ID. If the document is invalid, an *Error is returned.
+//
+// Ex:
+//     pipelines:
+//       elastic:
+//         backend:
+//           type: elastic
+//           host: localhost
+//           port: 9200
+//         tiles: [heatmap, micro]
+//         queries: [exists, range]
+//         store:
+//           type: redis
+//           host: localhost
+//           port: 6379
+//         queue:
+//           maxConcurrent: 32
+//           length: 1024
+//         compression: gzip
+//
+// If the tile, meta or query IDs are omitted, every type provided by the
+// backend is registered. The `png` tile type, which renders the heatmap tiles
+// of the pipeline into images, is registered only if listed.
+func Load(data []byte) (map[string]*veldt.Pipeline, error) {
+	var doc interface{}
+	err := yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, err
+	}
+	l := &loader{}
+	pipelines := l.loadDocument(normalize(doc))
+	if len(l.issues) > 0 {
+		// release the queues of the pipelines already built
+		for _, pipeline := range pipelines {
+			pipeline.Close()
+		}
+		return nil, &Error{
+			Issues: l.issues,
+		}
+	}
+	return pipelines, nil
+}
+
+// LoadFile reads the provided YAML or JSON file and returns the pipelines it
+// declares by ID.
+func LoadFile(filename string) (map[string]*veldt.Pipeline, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return Load(data)
+}
+
+type loader struct {
+	issues []json.Issue
+}
+
+func (l *loader) fail(path string, err error, val interface{}) {
+	l.issues = append(l.issues, json.NewIssues(path, err, val)...)
+}
+
+func (l *loader) checkKeys(path string, obj map[string]interface{}, params []json.Param) {
+	for _, key := range unknownKeys(obj, params) {
+		l.fail(path, unrecognizedParam(obj, key), obj)
+	}
+}
+
+func (l *loader) validate(path string, val interface{}, params []json.Param) (map[string]interface{}, bool) {
+	obj, ok := val.(map[string]interface{})
+	if !ok {
+		l.fail(path, &json.CodedError{
+			Code:    json.CodeType,
+			Message: "value is not of type `object`",
+		}, val)
+		return nil, false
+	}
+	err := json.ValidateParams(obj, params)
+	if err != nil {
+		l.fail(path, err, obj)
+		return nil, false
+	}
+	return obj, true
+}
+
+func (l *loader) loadDocument(doc interface{}) map[string]*veldt.Pipeline {
+	root, ok := l.validate("", doc, documentParams)
+	if !ok {
+		return nil
+	}
+	l.checkKeys("", root, documentParams)
+	configs := root["pipelines"].(map[string]interface{})
+	pipelines := make(map[string]*veldt.Pipeline, len(configs))
+	for _, id := range sortedKeys(configs) {
+		pipeline := l.loadPipeline(json.Pointer("pipelines", id), configs[id])
+		if pipeline != nil {
+			pipelines[id] = pipeline
+		}
+	}
+	return pipelines
+}
+
+func (l *loader) loadPipeline(path string, val interface{}) *veldt.Pipeline {
+	config, ok := l.validate(path, val, pipelineParams)
+	if !ok {
+		return nil
+	}
+	l.checkKeys(path, config, pipelineParams)
+
+	// instantiate the backend
+	typ, backend := l.loadBackend(path+json.Pointer("backend"), config["backend"])
+	if backend == nil {
+		return nil
+	}
+
+	pipeline := veldt.NewPipeline()
+
+	// add boolean expression types
+	if backend.Binary != nil {
+		pipeline.Binary(backend.Binary)
+	}
+	if backend.Unary != nil {
+		pipeline.Unary(backend.Unary)
+	}
+
+	// add query types
+	ids, ok := json.GetStringArray(config, "queries")
+	if !ok {
+		ids = sortedQueryIDs(backend.Queries)
+	}
+	for i, id := range ids {
+		ctor, ok := backend.query(id)
+		if !ok {
+			l.fail(path+json.Pointer("queries", strconv.Itoa(i)),
+				unrecognized("query", id, typ), id)
+			continue
+		}
+		pipeline.Query(id, ctor)
+	}
+
+	// add tile types
+	ids, ok = json.GetStringArray(config, "tiles")
+	if !ok {
+		ids = sortedTileIDs(backend.Tiles)
+	}
+	for i, id := range ids {
+		ctor, ok := backend.Tiles[id]
+		if !ok && id == renderTileID {
+			ctor, ok = veldt.NewRenderTile(pipeline), true
+		}
+		if !ok {
+			l.fail(path+json.Pointer("tiles", strconv.Itoa(i)),
+				unrecognized("tile", id, typ), id)
+			continue
+		}
+		pipeline.Tile(id, ctor)
+	}
+
+	// add meta types
+	ids, ok = json.GetStringArray(config, "metas")
+	if !ok {
+		ids = sortedMetaIDs(backend.Metas)
+	}
+	for i, id := range ids {
+		ctor, ok := backend.Metas[id]
+		if !ok {
+			l.fail(path+json.Pointer("metas", strconv.Itoa(i)),
+				unrecognized("meta", id, typ), id)
+			continue
+		}
+		pipeline.Meta(id, ctor)
+	}
+
+	// add store
+	store, ok := config["store"]
+	if ok {
+		typ, ctor := l.loadStore(path+json.Pointer("store"), store)
+		if ctor != nil {
+			pipeline.Store(typ, ctor)
+		}
+	}
+
+	// set queue limits
+	queue, ok := config["queue"]
+	if ok {
+		l.loadQueue(path+json.Pointer("queue"), pipeline, queue)
+	}
+
+	// set compression
+	pipeline.SetCompression(json.GetStringDefault(config, defaultCompression, "compression"))
+	return pipeline
+}
+
+func (l *loader) loadBackend(path string, val interface{}) (string, *Backend) {
+	params, ok := l.validate(path, val, typeParams)
+	if !ok {
+		return "", nil
+	}
+	typ := params["type"].(string)
+	factory, ok := getBackend(typ)
+	if !ok {
+		l.fail(path, &json.CodedError{
+			Code:    json.CodeUnrecognized,
+			Message: fmt.Sprintf("unrecognized backend type `%s`", typ),
+			Path:    []string{"type"},
+			Value:   typ,
+		}, params)
+		return "", nil
+	}
+	backend, err := factory(params)
+	if err != nil {
+		l.fail(path, err, params)
+		return "", nil
+	}
+	return typ, backend
+}
+
+func (l *loader) loadStore(path string, val interface{}) (string, veldt.StoreCtor) {
+	params, ok := l.validate(path, val, typeParams)
+	if !ok {
+		return "", nil
+	}
+	typ := params["type"].(string)
+	factory, ok := getStore(typ)
+	if !ok {
+		l.fail(path, &json.CodedError{
+			Code:    json.CodeUnrecognized,
+			Message: fmt.Sprintf("unrecognized store type `%s`", typ),
+			Path:    []string{"type"},
+			Value:   typ,
+		}, params)
+		return "", nil
+	}
+	ctor, err := factory(params)
+	if err != nil {
+		l.fail(path, err, params)
+		return "", nil
+	}
+	return typ, ctor
+}
+
+func (l *loader) loadQueue(path string, pipeline *veldt.Pipeline, val interface{}) {
+	params, ok := l.validate(path, val, queueParams)
+	if !ok {
+		return
+	}
+	l.checkKeys(path, params, queueParams)
+	max, ok := json.GetInt(params, "maxConcurrent")
+	if ok {
+		pipeline.SetMaxConcurrent(max)
+	}
+	length, ok := json.GetInt(params, "length")
+	if ok {
+		pipeline.SetQueueLength(length)
+	}
+}
+
+func unrecognizedParam(obj map[string]interface{}, key string) error {
+	return &json.CodedError{
+		Code:    json.CodeUnrecognized,
+		Message: fmt.Sprintf("unrecognized parameter `%s`", key),
+		Path:    []string{key},
+		Value:   obj[key],
+	}
+}
+
+func unrecognized(kind string, id string, backend string) error {
+	return &json.CodedError{
+		Code:    json.CodeUnrecognized,
+		Message: fmt.Sprintf("unrecognized %s type `%s` for backend `%s`", kind, id, backend),
+	}
+}
+
+// normalize converts decoded YAML into the types produced by encoding/json so
+// that the document can be read with the util/json helpers.
+func normalize(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[interface{}]interface{}:
+		obj := make(map[string]interface{}, len(v))
+		for key, sub := range v {
+			obj[fmt.Sprintf("%v", key)] = normalize(sub)
+		}
+		return obj
+	case map[string]interface{}:
+		for key, sub := range v {
+			v[key] = normalize(sub)
+		}
+		return v
+	case []interface{}:
+		for i, sub := range v {
+			v[i] = normalize(sub)
+		}
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	}
+	return val
+}
@@ -0,0 +1,249 @@
+package config_test
+
+import (
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/config"
+	"github.com/unchartedsoftware/veldt/query"
+	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type testTile struct {
+	tile.Bivariate
+}
+
+func (t *testTile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
+	return nil, nil
+}
+
+type testStore struct{}
+
+func (s *testStore) Set(key string, value []byte) error {
+	return nil
+}
+
+func (s *testStore) Get(key string) ([]byte, error) {
+	return nil, nil
+}
+
+func (s *testStore) Exists(key string) (bool, error) {
+	return false, nil
+}
+
+func (s *testStore) Close() {}
+
+func init() {
+	config.RegisterBackend("test", func(params map[string]interface{}) (*config.Backend, error) {
+		err := config.ValidateParams(params, []json.Param{
+			{Key: "host", Type: json.TypeString, Required: true},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &config.Backend{
+			Queries: map[string]veldt.QueryCtor{
+				"exists": func() (veldt.Query, error) {
+					return &query.Exists{}, nil
+				},
+			},
+			Binary: func() (veldt.Query, error) {
+				return &veldt.BinaryExpression{}, nil
+			},
+			Tiles: map[string]veldt.TileCtor{
+				"heatmap": func() (veldt.Tile, error) {
+					return &testTile{}, nil
+				},
+				"count": func() (veldt.Tile, error) {
+					return &testTile{}, nil
+				},
+			},
+		}, nil
+	})
+	config.RegisterStore("test", func(params map[string]interface{}) (veldt.StoreCtor, error) {
+		err := config.ValidateParams(params, nil)
+		if err != nil {
+			return nil, err
+		}
+		return func() (veldt.Store, error) {
+			return &testStore{}, nil
+		}, nil
+	})
+}
+
+var _ = Describe("Load", func() {
+
+	It("should return the pipelines declared in a YAML document", func() {
+		pipelines, err := config.Load([]byte(`
+pipelines:
+  a:
+    backend:
+      type: test
+      host: localhost
+    tiles: [heatmap]
+    store:
+      type: test
+    queue:
+      maxConcurrent: 8
+      length: 64
+    compression: zlib
+`))
+		Expect(err).To(BeNil())
+		Expect(pipelines).To(HaveKey("a"))
+		desc := pipelines["a"].Describe()
+		Expect(desc.Tiles).To(Equal([]string{"heatmap"}))
+		Expect(desc.Queries).To(Equal([]string{"exists"}))
+		Expect(desc.Binary).To(BeTrue())
+		Expect(desc.Unary).To(BeFalse())
+		Expect(desc.Store).To(Equal("test"))
+		Expect(desc.MaxConcurrent).To(Equal(8))
+		Expect(desc.QueueLength).To(Equal(64))
+		Expect(desc.Compression).To(Equal("zlib"))
+	})
+
+	It("should return the pipelines declared in a JSON document", func() {
+		pipelines, err := config.Load([]byte(`{
+			"pipelines": {
+				"a": {
+					"backend": {
+						"type": "test",
+						"host": "localhost"
+					}
+				}
+			}
+		}`))
+		Expect(err).To(BeNil())
+		desc := pipelines["a"].Describe()
+		Expect(desc.Tiles).To(Equal([]string{"count", "heatmap"}))
+		Expect(desc.Compression).To(Equal("gzip"))
+	})
+
+	It("should register the png tile type if listed", func() {
+		pipelines, err := config.Load([]byte(`
+pipelines:
+  a:
+    backend:
+      type: test
+      host: localhost
+    tiles: [heatmap, png]
+`))
+		Expect(err).To(BeNil())
+		desc := pipelines["a"].Describe()
+		Expect(desc.Tiles).To(Equal([]string{"heatmap", "png"}))
+	})
+
+	It("should return an *Error locating every issue in the document", func() {
+		_, err := config.Load([]byte(`
+pipelines:
+  a:
+    backend:
+      type: test
+    tiles: [heatmap]
+  b:
+    backend:
+      type: test
+      host: localhost
+    tiles: [heatmap, unknown]
+    store:
+      type: memcached
+    queue:
+      length: "64"
+    compress: gzip
+  c:
+    backend:
+      type: unknown
+`))
+		Expect(err).NotTo(BeNil())
+		cerr, ok := err.(*config.Error)
+		Expect(ok).To(BeTrue())
+		Expect(cerr.Issues).To(Equal([]json.Issue{
+			{
+				Path:    "/pipelines/a/backend/host",
+				Code:    json.CodeMissing,
+				Message: "`host` parameter missing",
+			},
+			{
+				Path:    "/pipelines/b/compress",
+				Code:    json.CodeUnrecognized,
+				Message: "unrecognized parameter `compress`",
+				Value:   "gzip",
+			},
+			{
+				Path:    "/pipelines/b/tiles/1",
+				Code:    json.CodeUnrecognized,
+				Message: "unrecognized tile type `unknown` for backend `test`",
+				Value:   "unknown",
+			},
+			{
+				Path:    "/pipelines/b/store/type",
+				Code:    json.CodeUnrecognized,
+				Message: "unrecognized store type `memcached`",
+				Value:   "memcached",
+			},
+			{
+				Path:    "/pipelines/b/queue/length",
+				Code:    json.CodeType,
+				Message: "`length` parameter is not of type `integer`",
+				Value:   "64",
+			},
+			{
+				Path:    "/pipelines/c/backend/type",
+				Code:    json.CodeUnrecognized,
+				Message: "unrecognized backend type `unknown`",
+				Value:   "unknown",
+			},
+		}))
+		Expect(err.Error()).To(ContainSubstring("/pipelines/b/tiles/1: unrecognized tile type `unknown`"))
+	})
+
+	It("should reject unrecognized backend and store parameters", func() {
+		_, err := config.Load([]byte(`
+pipelines:
+  a:
+    backend:
+      type: test
+      host: localhost
+      hots: localhost
+    store:
+      type: test
+      size: 64
+`))
+		Expect(err).NotTo(BeNil())
+		Expect(err.(*config.Error).Issues).To(Equal([]json.Issue{
+			{
+				Path:    "/pipelines/a/backend/hots",
+				Code:    json.CodeUnrecognized,
+				Message: "unrecognized parameter `hots`",
+				Value:   "localhost",
+			},
+		}))
+		_, err = config.Load([]byte(`
+pipelines:
+  a:
+    backend:
+      type: test
+      host: localhost
+    store:
+      type: test
+      size: 64
+`))
+		Expect(err).NotTo(BeNil())
+		Expect(err.(*config.Error).Issues).To(Equal([]json.Issue{
+			{
+				Path:    "/pipelines/a/store/size",
+				Code:    json.CodeUnrecognized,
+				Message: "unrecognized parameter `size`",
+				Value:   64.0,
+			},
+		}))
+	})
+
+	It("should return an error if the document is not an object", func() {
+		_, err := config.Load([]byte(`[1, 2]`))
+		Expect(err).NotTo(BeNil())
+		Expect(err.(*config.Error).Issues[0].Code).To(Equal(json.CodeType))
+	})
+})
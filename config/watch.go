@@ -0,0 +1,125 @@
+package config
+
+import (
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/fsnotify.v1"
+
+	"github.com/unchartedsoftware/veldt"
+)
+
+// Watcher registers the pipelines declared in a configuration file and
+// reloads them whenever the file changes.
+type Watcher struct {
+	filename string
+	handler  func(error)
+	watcher  *fsnotify.Watcher
+	ids      map[string]bool
+	mu       *sync.Mutex
+}
+
+// Watch loads and registers the pipelines declared in the provided file, then
+// reloads them each time the file changes. Errors encountered while reloading
+// are passed to the provided handler, if any, and leave the registered
+// pipelines untouched.
+func Watch(filename string, handler func(error)) (*Watcher, error) {
+	w := &Watcher{
+		filename: filepath.Clean(filename),
+		handler:  handler,
+		ids:      make(map[string]bool),
+		mu:       &sync.Mutex{},
+	}
+	err := w.Reload()
+	if err != nil {
+		return nil, err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	// watch the directory rather than the file so that editors which replace
+	// the file on save are handled
+	err = watcher.Add(filepath.Dir(w.filename))
+	if err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	w.watcher = watcher
+	go w.watch()
+	return w, nil
+}
+
+// Reload loads the configuration file and atomically replaces the registered
+// pipelines with the declared ones. Pipelines registered by a previous load
+// that are no longer declared are unregistered. Replaced pipelines are closed
+// once their requests in flight finish.
+func (w *Watcher) Reload() error {
+	pipelines, err := LoadFile(w.filename)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	// replace concurrently so that draining one pipeline does not delay the
+	// others
+	wg := &sync.WaitGroup{}
+	for id, pipeline := range pipelines {
+		wg.Add(1)
+		go func(id string, pipeline *veldt.Pipeline) {
+			veldt.Replace(id, pipeline)
+			wg.Done()
+		}(id, pipeline)
+	}
+	for id := range w.ids {
+		_, ok := pipelines[id]
+		if !ok {
+			wg.Add(1)
+			go func(id string) {
+				veldt.Replace(id, nil)
+				wg.Done()
+			}(id)
+		}
+	}
+	wg.Wait()
+	w.ids = make(map[string]bool, len(pipelines))
+	for id := range pipelines {
+		w.ids[id] = true
+	}
+	return nil
+}
+
+// Close stops watching the configuration file. The registered pipelines are
+// left untouched.
+func (w *Watcher) Close() error {
+	return w.watcher.Close()
+}
+
+func (w *Watcher) watch() {
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != w.filename {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			w.fail(w.Reload())
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			w.fail(err)
+		}
+	}
+}
+
+func (w *Watcher) fail(err error) {
+	if err != nil && w.handler != nil {
+		w.handler(err)
+	}
+}
@@ -0,0 +1,102 @@
+package config_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	watchConfig = `
+pipelines:
+  watched:
+    backend:
+      type: test
+      host: localhost
+    tiles: [heatmap]
+`
+	reloadConfig = `
+pipelines:
+  reloaded:
+    backend:
+      type: test
+      host: localhost
+    tiles: [heatmap, count]
+`
+)
+
+var _ = Describe("Watch", func() {
+
+	var dir string
+	var filename string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "veldt-config")
+		Expect(err).To(BeNil())
+		filename = filepath.Join(dir, "pipelines.yml")
+		err = ioutil.WriteFile(filename, []byte(watchConfig), 0644)
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		veldt.Unregister("watched")
+		veldt.Unregister("reloaded")
+		os.RemoveAll(dir)
+	})
+
+	It("should register the declared pipelines", func() {
+		watcher, err := config.Watch(filename, nil)
+		Expect(err).To(BeNil())
+		defer watcher.Close()
+		p, err := veldt.GetPipeline("watched")
+		Expect(err).To(BeNil())
+		Expect(p.Describe().Tiles).To(Equal([]string{"heatmap"}))
+	})
+
+	It("should replace the registered pipelines when the file changes", func() {
+		watcher, err := config.Watch(filename, nil)
+		Expect(err).To(BeNil())
+		defer watcher.Close()
+		prev, err := veldt.GetPipeline("watched")
+		Expect(err).To(BeNil())
+		err = ioutil.WriteFile(filename, []byte(reloadConfig), 0644)
+		Expect(err).To(BeNil())
+		Eventually(func() error {
+			_, err := veldt.GetPipeline("reloaded")
+			return err
+		}).Should(BeNil())
+		Eventually(func() error {
+			_, err := veldt.GetPipeline("watched")
+			return err
+		}).ShouldNot(BeNil())
+		Expect(prev.Generate(&veldt.TileRequest{})).NotTo(BeNil())
+	})
+
+	It("should pass reload errors to the handler and keep the registered pipelines", func() {
+		errs := make(chan error, 8)
+		watcher, err := config.Watch(filename, func(err error) {
+			errs <- err
+		})
+		Expect(err).To(BeNil())
+		defer watcher.Close()
+		err = ioutil.WriteFile(filename, []byte("pipelines: []"), 0644)
+		Expect(err).To(BeNil())
+		Eventually(errs).Should(Receive())
+		_, err = veldt.GetPipeline("watched")
+		Expect(err).To(BeNil())
+	})
+
+	It("should return an error if the file is invalid", func() {
+		err := ioutil.WriteFile(filename, []byte("pipelines: []"), 0644)
+		Expect(err).To(BeNil())
+		_, err = config.Watch(filename, nil)
+		Expect(err).NotTo(BeNil())
+	})
+})
@@ -0,0 +1,61 @@
+package veldt
+
+import (
+	"sort"
+)
+
+// Description represents the capabilities registered with a pipeline.
+type Description struct {
+	Tiles         []string `json:"tiles"`
+	Metas         []string `json:"metas"`
+	Queries       []string `json:"queries"`
+	Binary        bool     `json:"binary"`
+	Unary         bool     `json:"unary"`
+	Store         string   `json:"store"`
+	MaxConcurrent int      `json:"maxConcurrent"`
+	QueueLength   int      `json:"queueLength"`
+	Compression   string   `json:"compression"`
+}
+
+// Describe returns a description of the tile, meta and query types, boolean
+// operators, store, queue settings and compression of the pipeline.
+func (p *Pipeline) Describe() *Description {
+	return &Description{
+		Tiles:         sortedTileIDs(p.tiles),
+		Metas:         sortedMetaIDs(p.metas),
+		Queries:       sortedQueryIDs(p.queries),
+		Binary:        p.binary != nil,
+		Unary:         p.unary != nil,
+		Store:         p.storeID,
+		MaxConcurrent: p.queue.MaxConcurrent(),
+		QueueLength:   p.queue.Length(),
+		Compression:   p.compression,
+	}
+}
+
+func sortedTileIDs(ctors map[string]TileCtor) []string {
+	ids := make([]string, 0, len(ctors))
+	for id := range ctors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func sortedMetaIDs(ctors map[string]MetaCtor) []string {
+	ids := make([]string, 0, len(ctors))
+	for id := range ctors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func sortedQueryIDs(ctors map[string]QueryCtor) []string {
+	ids := make([]string, 0, len(ctors))
+	for id := range ctors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
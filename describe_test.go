@@ -0,0 +1,69 @@
+package veldt_test
+
+import (
+	"github.com/unchartedsoftware/veldt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type testStore struct{}
+
+func (s *testStore) Set(key string, value []byte) error {
+	return nil
+}
+
+func (s *testStore) Get(key string) ([]byte, error) {
+	return nil, nil
+}
+
+func (s *testStore) Exists(key string) (bool, error) {
+	return false, nil
+}
+
+func (s *testStore) Close() {}
+
+var _ = Describe("Describe", func() {
+
+	var pipeline *veldt.Pipeline
+
+	BeforeEach(func() {
+		pipeline = newTestPipeline()
+	})
+
+	It("should list the registered types in sorted order", func() {
+		pipeline.Tile("count", func() (veldt.Tile, error) {
+			return &testTile{}, nil
+		})
+		desc := pipeline.Describe()
+		Expect(desc.Tiles).To(Equal([]string{"count", "heatmap"}))
+		Expect(desc.Metas).To(BeEmpty())
+		Expect(desc.Queries).To(Equal([]string{"exists"}))
+	})
+
+	It("should report operator support", func() {
+		Expect(pipeline.Describe().Binary).To(BeFalse())
+		pipeline.Binary(func() (veldt.Query, error) {
+			return &veldt.BinaryExpression{}, nil
+		})
+		desc := pipeline.Describe()
+		Expect(desc.Binary).To(BeTrue())
+		Expect(desc.Unary).To(BeFalse())
+	})
+
+	It("should report the store, queue settings and compression", func() {
+		opened := false
+		pipeline.Store("test", func() (veldt.Store, error) {
+			opened = true
+			return &testStore{}, nil
+		})
+		pipeline.SetMaxConcurrent(16)
+		pipeline.SetQueueLength(128)
+		desc := pipeline.Describe()
+		Expect(desc.Store).To(Equal("test"))
+		Expect(opened).To(BeFalse())
+		Expect(desc.MaxConcurrent).To(Equal(16))
+		Expect(desc.QueueLength).To(Equal(128))
+		Expect(desc.Compression).To(Equal("gzip"))
+	})
+})
@@ -2,83 +2,89 @@ package veldt
 
 // GenerateTile generates a tile for the provided pipeline ID and JSON request.
 func GenerateTile(id string, args map[string]interface{}) error {
-	pipeline, err := GetPipeline(id)
+	pipeline, err := acquirePipeline(id)
 	if err != nil {
 		return err
 	}
+	defer pipeline.end()
 	req, err := pipeline.NewTileRequest(args)
 	if err != nil {
 		return err
 	}
-	return pipeline.Generate(req)
+	return pipeline.generate(req)
 }
 
 // GetTile retrieves a tile from the store for the provided pipeline ID
 // and JSON request.
 func GetTile(id string, args map[string]interface{}) ([]byte, error) {
-	pipeline, err := GetPipeline(id)
+	pipeline, err := acquirePipeline(id)
 	if err != nil {
 		return nil, err
 	}
+	defer pipeline.end()
 	req, err := pipeline.NewTileRequest(args)
 	if err != nil {
 		return nil, err
 	}
-	return pipeline.Get(req)
+	return pipeline.get(req)
 }
 
 // GenerateAndGetTile generates and retrieves a tile from the store
 // for the provided pipeline ID and JSON request.
 func GenerateAndGetTile(id string, args map[string]interface{}) ([]byte, error) {
-	pipeline, err := GetPipeline(id)
+	pipeline, err := acquirePipeline(id)
 	if err != nil {
 		return nil, err
 	}
+	defer pipeline.end()
 	req, err := pipeline.NewTileRequest(args)
 	if err != nil {
 		return nil, err
 	}
-	return pipeline.GenerateAndGet(req)
+	return pipeline.generateAndGet(req)
 }
 
 // GenerateMeta generates meta data for the provided pipeline ID and JSON
 // request.
 func GenerateMeta(id string, args map[string]interface{}) error {
-	pipeline, err := GetPipeline(id)
+	pipeline, err := acquirePipeline(id)
 	if err != nil {
 		return err
 	}
+	defer pipeline.end()
 	req, err := pipeline.NewMetaRequest(args)
 	if err != nil {
 		return err
 	}
-	return pipeline.Generate(req)
+	return pipeline.generate(req)
 }
 
 // GetMeta retrieves metadata from the store for the provided pipeline
 // ID and JSON request.
 func GetMeta(id string, args map[string]interface{}) ([]byte, error) {
-	pipeline, err := GetPipeline(id)
+	pipeline, err := acquirePipeline(id)
 	if err != nil {
 		return nil, err
 	}
+	defer pipeline.end()
 	req, err := pipeline.NewMetaRequest(args)
 	if err != nil {
 		return nil, err
 	}
-	return pipeline.Get(req)
+	return pipeline.get(req)
 }
 
 // GenerateAndGetMeta generates and retrieves a metadata from the store
 // for the provided pipeline ID and JSON request.
 func GenerateAndGetMeta(id string, args map[string]interface{}) ([]byte, error) {
-	pipeline, err := GetPipeline(id)
+	pipeline, err := acquirePipeline(id)
 	if err != nil {
 		return nil, err
 	}
+	defer pipeline.end()
 	req, err := pipeline.NewMetaRequest(args)
 	if err != nil {
 		return nil, err
 	}
-	return pipeline.GenerateAndGet(req)
+	return pipeline.generateAndGet(req)
 }
@@ -0,0 +1,72 @@
+package citus
+
+import (
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+// AggregatedEdgeTile represents a citus implementation of the aggregated edge
+// tile.
+type AggregatedEdgeTile struct {
+	Tile
+	Edge
+	tile.AggregatedEdge
+}
+
+// NewAggregatedEdgeTile instantiates and returns a new tile struct.
+func NewAggregatedEdgeTile(cfg *Config) veldt.TileCtor {
+	return func() (veldt.Tile, error) {
+		e := &AggregatedEdgeTile{}
+		e.Config = cfg
+		return e, nil
+	}
+}
+
+// Parse parses the provided JSON object and populates the tiles attributes.
+func (e *AggregatedEdgeTile) Parse(params map[string]interface{}) error {
+	err := e.Edge.Parse(params)
+	if err != nil {
+		return err
+	}
+	return e.AggregatedEdge.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (e *AggregatedEdgeTile) Params() []json.Param {
+	return json.MergeParams(
+		e.Edge.Params(),
+		e.AggregatedEdge.Params())
+}
+
+// Create generates a tile from the provided URI, tile coordinate and query
+// parameters.
+func (e *AggregatedEdgeTile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
+	// Initialize the tile processing.
+	client, citusQuery, err := e.InitializeTile(uri, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// add tiling query
+	citusQuery = e.Edge.AddQuery(coord, citusQuery)
+
+	// add aggs
+	citusQuery = e.Edge.AddFlowAggs(coord, e.AggregatedEdge.Resolution, citusQuery)
+
+	// send query
+	res, err := client.Query(citusQuery.GetQuery(false), citusQuery.QueryArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	// get flows
+	flows, err := e.Edge.GetFlows(res)
+	if err != nil {
+		return nil, err
+	}
+
+	// encode and return results
+	return e.AggregatedEdge.Encode(flows, e.Edge.Unprojector(coord))
+}
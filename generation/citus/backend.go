@@ -0,0 +1,66 @@
+package citus
+
+import (
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/config"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+func init() {
+	config.RegisterBackend("citus", NewBackend)
+}
+
+// NewBackend instantiates and returns the citus tile, meta and query types for
+// the provided connection parameters.
+func NewBackend(params map[string]interface{}) (*config.Backend, error) {
+	err := config.ValidateParams(params, []json.Param{
+		{Key: "host", Type: json.TypeString, Default: "localhost"},
+		{Key: "port", Type: json.TypeInteger, Default: 5432},
+		{Key: "database", Type: json.TypeString, Required: true},
+		{Key: "user", Type: json.TypeString, Required: true},
+		{Key: "password", Type: json.TypeString, Default: ""},
+	})
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{
+		Host:     json.GetStringDefault(params, "localhost", "host"),
+		Port:     uint16(json.GetIntDefault(params, 5432, "port")),
+		Database: json.GetStringDefault(params, "", "database"),
+		User:     json.GetStringDefault(params, "", "user"),
+		Password: json.GetStringDefault(params, "", "password"),
+	}
+	return &config.Backend{
+		Queries: map[string]veldt.QueryCtor{
+			"exists": NewExists,
+			"has":    NewHas,
+			"equals": NewEquals,
+			"range":  NewRange,
+		},
+		Binary: NewBinaryExpression,
+		Unary:  NewUnaryExpression,
+		Tiles: map[string]veldt.TileCtor{
+			"heatmap":             NewHeatmapTile(cfg),
+			"hexbin":              NewHexbinTile(cfg),
+			"count":               NewCountTile(cfg),
+			"frequency":           NewFrequencyTile(cfg),
+			"macro":               NewMacroTile(cfg),
+			"cluster":             NewClusterTile(cfg),
+			"kde":                 NewKDETile(cfg),
+			"stats":               NewStatsTile(cfg),
+			"distribution":        NewDistributionTile(cfg),
+			"cube":                NewCubeTile(cfg),
+			"micro":               NewMicroTile(cfg),
+			"aggregatedEdge":      NewAggregatedEdgeTile(cfg),
+			"topTermCount":        NewTopTermCountTile(cfg),
+			"topTermFrequency":    NewTopTermFrequencyTile(cfg),
+			"targetTermCount":     NewTargetTermCountTile(cfg),
+			"targetTermFrequency": NewTargetTermFrequencyTile(cfg),
+			"termsFrequency":      NewTermsFrequencyTile(cfg),
+			"termsFrequencyCount": NewTermsFrequencyCountTile(cfg),
+		},
+		Metas: map[string]veldt.MetaCtor{
+			"default": NewDefaultMeta(cfg),
+		},
+	}, nil
+}
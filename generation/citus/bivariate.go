@@ -2,13 +2,29 @@ package citus
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/jackc/pgx"
 
 	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/geometry"
 	"github.com/unchartedsoftware/veldt/tile"
 )
 
+const (
+	// linearBin computes the bin of a value from the minimum of the bounds,
+	// without clamping values outside of them
+	linearBin = "floor((%s - %s::double precision) / %s::double precision * %s)::bigint"
+	// mercatorX computes the x position of a longitude within the tile
+	mercatorX = "((%s + 180) / 360 * %s - %s) * %s"
+	// mercatorY computes the y position of a latitude within the tile
+	mercatorY = "((1 + ln(tan(radians(%[1]s)) + 1 / cos(radians(%[1]s))) / pi()) / 2 * %[2]s - %[3]s) * %[4]s"
+	// mercatorXBin computes the x bin of a longitude within the tile
+	mercatorXBin = "floor(" + mercatorX + ")::bigint"
+	// mercatorYBin computes the y bin of a latitude within the tile
+	mercatorYBin = "floor(" + mercatorY + ")::bigint"
+)
+
 // Bivariate represents a bivariate tile generator.
 type Bivariate struct {
 	tile.Bivariate
@@ -16,51 +32,77 @@ type Bivariate struct {
 
 // AddQuery adds the tiling query to the provided query object.
 func (b *Bivariate) AddQuery(coord *binning.TileCoord, query *Query) *Query {
-	// get tile bounds
-	bounds := b.TileBounds(coord)
-	// x
-	minXArg := query.AddParameter(int64(bounds.MinX()))
-	maxXArg := query.AddParameter(int64(bounds.MaxX()))
-	rangeQueryX := fmt.Sprintf("%s >= %s and %s < %s", b.XField, minXArg, b.XField, maxXArg)
-	query.Where(rangeQueryX)
-	// y
-	minYArg := query.AddParameter(int64(bounds.MinY()))
-	maxYArg := query.AddParameter(int64(bounds.MaxY()))
-	rangeQueryY := fmt.Sprintf("%s >= %s and %s < %s", b.YField, minYArg, b.YField, maxYArg)
-	query.Where(rangeQueryY)
-	// result
+	// query the tile bounds, including any padding
+	addRangeQuery(query, b.QueryBounds(coord), b.XField, b.YField)
 	return query
 }
 
 // AddAggs adds the tiling aggregations to the provided query object.
 func (b *Bivariate) AddAggs(coord *binning.TileCoord, query *Query) *Query {
-	bounds := b.TileBounds(coord)
-	// bin
-	minX := int64(bounds.MinX())
-	maxX := int64(bounds.MaxX())
-	minY := int64(bounds.MinY())
-	maxY := int64(bounds.MaxY())
+	if b.isMercator() {
+		return b.addMercatorAggs(coord, query)
+	}
+	addLinearBuckets(query, b.QueryBounds(coord), b.PaddedResolution(), b.XField, b.YField, "x_bucket", "y_bucket")
+	query.GroupBy("x_bucket")
+	query.GroupBy("y_bucket")
+	return query
+}
+
+// addMercatorAggs bins each row by its projected position so that the bins
+// follow the mercator distortion of the y axis. The tile is offset by the
+// padding so that the first bin is the first padding bin.
+func (b *Bivariate) addMercatorAggs(coord *binning.TileCoord, query *Query) *Query {
+	pow2Arg := query.AddParameter(math.Pow(2, float64(coord.Z)))
+	resolutionArg := query.AddParameter(float64(b.Resolution))
+	pad := b.PaddingFraction()
 	// x_bucket
-	minXArg := query.AddParameter(minX)
-	maxXArg := query.AddParameter(maxX)
-	bucketArg := query.AddParameter(b.Resolution)
-	queryString := fmt.Sprintf("width_bucket(%s, %s, %s, %s) - 1 AS x_bucket", b.XField, minXArg, maxXArg, bucketArg)
-	query.Select(queryString);
+	tileXArg := query.AddParameter(float64(coord.X) - pad)
+	query.Select(fmt.Sprintf(mercatorXBin+" AS x_bucket", b.XField, pow2Arg, tileXArg, resolutionArg))
 	// y_bucket
-	minYArg := query.AddParameter(minY)
-	maxYArg := query.AddParameter(maxY)
-	queryString = fmt.Sprintf("width_bucket(%s, %s, %s, %s) - 1 AS y_bucket", b.YField, minYArg, maxYArg, bucketArg)
-	query.Select(queryString);
-	query.GroupBy("x_bucket");
-	query.GroupBy("y_bucket");
-	// result
+	tileYArg := query.AddParameter(float64(coord.Y) - pad)
+	query.Select(fmt.Sprintf(mercatorYBin+" AS y_bucket", b.YField, pow2Arg, tileYArg, resolutionArg))
+	query.GroupBy("x_bucket")
+	query.GroupBy("y_bucket")
 	return query
 }
 
+// addRangeQuery adds the range queries of the provided bounds on the x and y
+// fields. The bounds are cast so that small ranges keep their precision on
+// integer columns.
+func addRangeQuery(query *Query, bounds *geometry.Bounds, xField string, yField string) {
+	// x
+	minXArg := query.AddParameter(bounds.MinX())
+	maxXArg := query.AddParameter(bounds.MaxX())
+	query.Where(fmt.Sprintf("%s >= %s::double precision and %s < %s::double precision", xField, minXArg, xField, maxXArg))
+	// y
+	minYArg := query.AddParameter(bounds.MinY())
+	maxYArg := query.AddParameter(bounds.MaxY())
+	query.Where(fmt.Sprintf("%s >= %s::double precision and %s < %s::double precision", yField, minYArg, yField, maxYArg))
+}
+
+// addLinearBuckets selects the bins of the x and y fields at the provided
+// resolution, from the left and bottom of the bounds, as the elasticsearch
+// backend does.
+func addLinearBuckets(query *Query, bounds *geometry.Bounds, resolution int, xField string, yField string, xAlias string, yAlias string) {
+	resolutionArg := query.AddParameter(float64(resolution))
+	leftArg := query.AddParameter(bounds.Left)
+	rangeXArg := query.AddParameter(bounds.Right - bounds.Left)
+	query.Select(fmt.Sprintf(linearBin+" AS %s", xField, leftArg, rangeXArg, resolutionArg, xAlias))
+	bottomArg := query.AddParameter(bounds.Bottom)
+	rangeYArg := query.AddParameter(bounds.Top - bounds.Bottom)
+	query.Select(fmt.Sprintf(linearBin+" AS %s", yField, bottomArg, rangeYArg, resolutionArg, yAlias))
+}
+
+func (b *Bivariate) isMercator() bool {
+	_, ok := b.Projection.(*binning.Mercator)
+	return ok
+}
+
 // GetBins parses the resulting histograms into bins.
 func (b *Bivariate) GetBins(coord *binning.TileCoord, rows *pgx.Rows) ([]float64, error) {
-	// allocate bins buffer
-	bins := make([]float64, b.Resolution*b.Resolution)
+	// allocate bins buffer, including any padding
+	resolution := b.PaddedResolution()
+	bins := make([]float64, resolution*resolution)
 	// fill bins buffer
 	for rows.Next() {
 		var x, y int64
@@ -72,13 +114,24 @@ func (b *Bivariate) GetBins(coord *binning.TileCoord, rows *pgx.Rows) ([]float64
 				err)
 		}
 
-		index := x + int64(b.Resolution)*y
+		index := b.clampBin(x) + resolution*b.clampBin(y)
 		bins[index] += value
 	}
 
 	return bins, nil
 }
 
+func (b *Bivariate) clampBin(bin int64) int {
+	resolution := b.PaddedResolution()
+	if bin < 0 {
+		return 0
+	}
+	if bin > int64(resolution)-1 {
+		return resolution - 1
+	}
+	return int(bin)
+}
+
 // GetXBin given an x value, returns the corresponding bin.
 // This is a passthru for citus since we are binning from 0 to resolution in the query
 func (b *Bivariate) GetXBin(coord *binning.TileCoord, x float64) int {
@@ -0,0 +1,200 @@
+package citus
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+// evalBin evaluates the selected bin expression of the query for a row with
+// the provided field value.
+func evalBin(query *Query, bucket string, field string, val float64) float64 {
+	return evalSelect(query, bucket, field, val)
+}
+
+// evalSelect evaluates the selected expression of the query for a row with the
+// provided field value. Averages are evaluated for the single row.
+func evalSelect(query *Query, bucket string, field string, val float64) float64 {
+	suffix := " AS " + bucket
+	for _, expr := range query.Fields {
+		if !strings.HasSuffix(expr, suffix) {
+			continue
+		}
+		vars := map[string]float64{
+			field: val,
+		}
+		for i, arg := range query.QueryArgs {
+			vars[fmt.Sprintf("$%d", i+1)] = arg.(float64)
+		}
+		expr = strings.TrimSuffix(expr, suffix)
+		expr = strings.Replace(expr, "::bigint", "", -1)
+		expr = strings.Replace(expr, "::double precision", "", -1)
+		expr = strings.Replace(expr, "AVG(", "(", -1)
+		return Eval(expr, vars)
+	}
+	Fail(fmt.Sprintf("`%s` is not selected", bucket))
+	return 0
+}
+
+var _ = Describe("Bivariate", func() {
+
+	var query *Query
+
+	BeforeEach(func() {
+		var err error
+		query, err = NewQuery()
+		Expect(err).To(BeNil())
+	})
+
+	Describe("linear", func() {
+
+		// a tile of a unit range, whose bounds are all fractional
+		coord := &binning.TileCoord{Z: 20, X: 3, Y: 5}
+		size := 1 / math.Pow(2, 20)
+
+		var bivariate *Bivariate
+
+		BeforeEach(func() {
+			bivariate = &Bivariate{}
+			err := bivariate.Parse(JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"projection": "linear",
+					"left": 0,
+					"right": 1,
+					"bottom": 0,
+					"top": 1,
+					"resolution": 256
+				}`))
+			Expect(err).To(BeNil())
+		})
+
+		It("should query the fractional bounds of the tile", func() {
+			bounds := bivariate.TileBounds(coord)
+			bivariate.AddQuery(coord, query)
+			Expect(query.WhereClauses).To(Equal([]string{
+				"x >= $1::double precision and x < $2::double precision",
+				"y >= $3::double precision and y < $4::double precision",
+			}))
+			Expect(query.QueryArgs).To(Equal([]interface{}{
+				3 * size,
+				4 * size,
+				bounds.MinY(),
+				bounds.MaxY(),
+			}))
+			Expect(bounds.MinY()).To(BeNumerically(">", 0))
+		})
+
+		It("should bin by the fractional bounds of the tile", func() {
+			bounds := bivariate.TileBounds(coord)
+			bivariate.AddAggs(coord, query)
+			Expect(query.Fields).To(Equal([]string{
+				"floor((x - $2::double precision) / $3::double precision * $1)::bigint AS x_bucket",
+				"floor((y - $4::double precision) / $5::double precision * $1)::bigint AS y_bucket",
+			}))
+			Expect(query.QueryArgs).To(Equal([]interface{}{
+				256.0,
+				3 * size,
+				size,
+				bounds.Bottom,
+				bounds.Top - bounds.Bottom,
+			}))
+			Expect(evalBin(query, "x_bucket", "x", 3.5*size)).To(Equal(128.0))
+			Expect(evalBin(query, "y_bucket", "y", bounds.Bottom+bounds.RangeY()/4)).To(Equal(64.0))
+		})
+
+		It("should bin y from the bottom of inverted bounds", func() {
+			err := bivariate.Parse(JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"projection": "linear",
+					"left": 0,
+					"right": 1,
+					"bottom": 1,
+					"top": 0,
+					"resolution": 256
+				}`))
+			Expect(err).To(BeNil())
+			bounds := bivariate.TileBounds(coord)
+			bivariate.AddAggs(coord, query)
+			Expect(bounds.Bottom).To(BeNumerically(">", bounds.Top))
+			Expect(evalBin(query, "y_bucket", "y", bounds.Bottom-bounds.RangeY()/4)).To(Equal(64.0))
+		})
+	})
+
+	Describe("mercator", func() {
+
+		zoom := uint32(12)
+		resolution := 256.0
+
+		// lon / lat points in each hemisphere
+		points := []*binning.LonLat{
+			binning.NewLonLat(0.5, 0.5),
+			binning.NewLonLat(-122.4194, 37.7749),
+			binning.NewLonLat(151.2093, -33.8688),
+			binning.NewLonLat(-70.6693, -79.5),
+		}
+
+		var bivariate *Bivariate
+
+		BeforeEach(func() {
+			bivariate = &Bivariate{}
+			err := bivariate.Parse(JSON(
+				`{
+					"xField": "lon",
+					"yField": "lat",
+					"projection": "mercator",
+					"resolution": 256
+				}`))
+			Expect(err).To(BeNil())
+		})
+
+		It("should bin by the projected position of each point", func() {
+			for _, point := range points {
+				frac := binning.LonLatToFractionalTile(point, zoom)
+				coord := &binning.TileCoord{Z: zoom, X: uint32(frac.X), Y: uint32(frac.Y)}
+				query, err := NewQuery()
+				Expect(err).To(BeNil())
+				bivariate.AddAggs(coord, query)
+				x := evalBin(query, "x_bucket", "lon", point.Lon)
+				y := evalBin(query, "y_bucket", "lat", point.Lat)
+				Expect(x).To(Equal(math.Floor((frac.X - float64(coord.X)) * resolution)))
+				Expect(y).To(Equal(math.Floor((frac.Y - float64(coord.Y)) * resolution)))
+			}
+		})
+
+		It("should bin y from the bottom of the tile", func() {
+			coord := &binning.TileCoord{Z: zoom, X: 655, Y: 2510}
+			projection := &binning.Mercator{}
+			bottom := projection.Unproject(&binning.FractionalTileCoord{Z: zoom, X: 655.5, Y: 2510.001})
+			top := projection.Unproject(&binning.FractionalTileCoord{Z: zoom, X: 655.5, Y: 2510.999})
+			bivariate.AddAggs(coord, query)
+			Expect(evalBin(query, "y_bucket", "lat", bottom.Y)).To(Equal(0.0))
+			Expect(evalBin(query, "y_bucket", "lat", top.Y)).To(Equal(resolution - 1))
+			Expect(evalBin(query, "x_bucket", "lon", bottom.X)).To(Equal(resolution / 2))
+		})
+
+		It("should offset the bins by the padding", func() {
+			bivariate.Padding = 8
+			for _, point := range points {
+				frac := binning.LonLatToFractionalTile(point, zoom)
+				coord := &binning.TileCoord{Z: zoom, X: uint32(frac.X), Y: uint32(frac.Y)}
+				query, err := NewQuery()
+				Expect(err).To(BeNil())
+				bivariate.AddAggs(coord, query)
+				x := evalBin(query, "x_bucket", "lon", point.Lon)
+				y := evalBin(query, "y_bucket", "lat", point.Lat)
+				Expect(x).To(Equal(math.Floor((frac.X-float64(coord.X))*resolution) + 8))
+				Expect(y).To(Equal(math.Floor((frac.Y-float64(coord.Y))*resolution) + 8))
+			}
+		})
+	})
+})
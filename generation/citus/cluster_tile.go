@@ -0,0 +1,170 @@
+package citus
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+// ClusterTile represents a citus implementation of the cluster tile.
+type ClusterTile struct {
+	Tile
+	Bivariate
+	TopHits
+	tile.Cluster
+}
+
+// NewClusterTile instantiates and returns a new tile struct.
+func NewClusterTile(cfg *Config) veldt.TileCtor {
+	return func() (veldt.Tile, error) {
+		c := &ClusterTile{}
+		c.Config = cfg
+		return c, nil
+	}
+}
+
+// Parse parses the provided JSON object and populates the tiles attributes.
+func (c *ClusterTile) Parse(params map[string]interface{}) error {
+	err := c.Bivariate.Parse(params)
+	if err != nil {
+		return err
+	}
+	err = c.Cluster.Parse(params)
+	if err != nil {
+		return err
+	}
+	// representative hits are optional
+	if json.Exists(params, "hitsCount") {
+		err = c.TopHits.Parse(params)
+		if err != nil {
+			return err
+		}
+	}
+	// bin by cluster cell
+	c.Bivariate.Resolution = c.Cluster.Resolution()
+	return nil
+}
+
+// Params returns the parameters accepted by the tile. The resolution is set
+// by the radius of the clusters.
+func (c *ClusterTile) Params() []json.Param {
+	return json.OmitParams(
+		json.MergeParams(
+			c.Bivariate.Params(),
+			c.Cluster.Params(),
+			c.TopHits.Params()),
+		"resolution")
+}
+
+// Create generates a tile from the provided URI, tile coordinate and query
+// parameters.
+func (c *ClusterTile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
+	// Initialize the tile processing.
+	client, citusQuery, err := c.InitializeTile(uri, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// add tiling query
+	citusQuery = c.Bivariate.AddQuery(coord, citusQuery)
+
+	// add aggs, with the count, centroid and hits of each cell
+	citusQuery = c.Bivariate.AddAggs(coord, citusQuery)
+	citusQuery.Select("COUNT(*) AS count")
+	citusQuery = c.addCentroidAggs(coord, citusQuery)
+	hits := c.TopHits.HitsCount > 0 && len(c.TopHits.IncludeFields) > 0
+	if hits {
+		citusQuery.Select(c.getHitsAgg(citusQuery))
+	}
+
+	// send query
+	res, err := client.Query(citusQuery.GetQuery(false), citusQuery.QueryArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to cells
+	var cells []*tile.ClusterCell
+	for res.Next() {
+		var x, y, count int64
+		var centroidX, centroidY float64
+		var hitsJSON string
+		dest := []interface{}{&x, &y, &count, &centroidX, &centroidY}
+		if hits {
+			dest = append(dest, &hitsJSON)
+		}
+		err := res.Scan(dest...)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing cluster aggregation: %v", err)
+		}
+		cell := &tile.ClusterCell{
+			X:     c.Bivariate.clampBin(x),
+			Y:     c.Bivariate.clampBin(y),
+			Count: uint64(count),
+		}
+		cell.CentroidX, cell.CentroidY = c.getCentroid(coord, centroidX, centroidY)
+		if hits {
+			cell.Hits, err = json.UnmarshalArray([]byte(hitsJSON))
+			if err != nil {
+				return nil, err
+			}
+		}
+		cells = append(cells, cell)
+	}
+
+	// encode and return results
+	return c.Cluster.Encode(coord, cells)
+}
+
+// addCentroidAggs adds the aggregations of the centroid of each cell to the
+// provided query object. Under the mercator projection the mean of the
+// projected positions differs from the projection of the mean latitude, so the
+// positions within the tile are averaged instead of the raw values.
+func (c *ClusterTile) addCentroidAggs(coord *binning.TileCoord, query *Query) *Query {
+	if c.Bivariate.isMercator() {
+		pow2Arg := query.AddParameter(math.Pow(2, float64(coord.Z)))
+		resolutionArg := query.AddParameter(binning.MaxTileResolution)
+		tileXArg := query.AddParameter(float64(coord.X))
+		tileYArg := query.AddParameter(float64(coord.Y))
+		query.Select(fmt.Sprintf("AVG("+mercatorX+") AS centroid_x", c.Bivariate.XField, pow2Arg, tileXArg, resolutionArg))
+		query.Select(fmt.Sprintf("AVG("+mercatorY+") AS centroid_y", c.Bivariate.YField, pow2Arg, tileYArg, resolutionArg))
+		return query
+	}
+	query.Select(fmt.Sprintf("AVG(%s) AS centroid_x", c.Bivariate.XField))
+	query.Select(fmt.Sprintf("AVG(%s) AS centroid_y", c.Bivariate.YField))
+	return query
+}
+
+// getCentroid returns the centroid within the range of [0 : 256) for the tile
+// from the results of the centroid aggregations.
+func (c *ClusterTile) getCentroid(coord *binning.TileCoord, x float64, y float64) (float64, float64) {
+	if c.Bivariate.isMercator() {
+		return x, y
+	}
+	return c.Bivariate.GetX(coord, x), c.Bivariate.GetY(coord, y)
+}
+
+// getHitsAgg returns the aggregate selecting the included fields of the top
+// hits of each cell as a JSON array.
+func (c *ClusterTile) getHitsAgg(query *Query) string {
+	fields := make([]string, len(c.TopHits.IncludeFields))
+	for i, field := range c.TopHits.IncludeFields {
+		fields[i] = fmt.Sprintf("'%s', %s", field, field)
+	}
+	order := ""
+	if c.TopHits.SortField != "" {
+		if c.TopHits.SortOrder == "desc" {
+			order = fmt.Sprintf(" ORDER BY %s DESC", c.TopHits.SortField)
+		} else {
+			order = fmt.Sprintf(" ORDER BY %s", c.TopHits.SortField)
+		}
+	}
+	countArg := query.AddParameter(c.TopHits.HitsCount)
+	return fmt.Sprintf("array_to_json((array_agg(json_build_object(%s)%s))[1:%s])::text AS hits",
+		strings.Join(fields, ", "), order, countArg)
+}
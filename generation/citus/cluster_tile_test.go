@@ -0,0 +1,96 @@
+package citus
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+var _ = Describe("ClusterTile", func() {
+
+	var cluster *ClusterTile
+	var query *Query
+
+	BeforeEach(func() {
+		cluster = &ClusterTile{}
+		var err error
+		query, err = NewQuery()
+		Expect(err).To(BeNil())
+	})
+
+	Describe("Parse", func() {
+		It("should return an error if the `resolution` is provided", func() {
+			err := cluster.Parse(JSON(
+				`{
+					"xField": "lon",
+					"yField": "lat",
+					"projection": "mercator",
+					"resolution": 64
+				}`))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Params", func() {
+		It("should not describe the `resolution`", func() {
+			for _, param := range cluster.Params() {
+				Expect(param.Key).NotTo(Equal("resolution"))
+			}
+		})
+	})
+
+	Describe("addCentroidAggs", func() {
+		It("should average the mercator positions of the points within the tile", func() {
+			err := cluster.Parse(JSON(
+				`{
+					"xField": "lon",
+					"yField": "lat",
+					"projection": "mercator"
+				}`))
+			Expect(err).To(BeNil())
+			// two points far apart in latitude within a single zoom 1 tile
+			coord := &binning.TileCoord{Z: 1, X: 1, Y: 1}
+			points := []*binning.LonLat{
+				binning.NewLonLat(10, 5),
+				binning.NewLonLat(170, 80),
+			}
+			cluster.addCentroidAggs(coord, query)
+			var meanX, meanY, expectedX, expectedY float64
+			for _, point := range points {
+				meanX += evalSelect(query, "centroid_x", "lon", point.Lon) / 2
+				meanY += evalSelect(query, "centroid_y", "lat", point.Lat) / 2
+				frac := binning.LonLatToFractionalTile(point, coord.Z)
+				expectedX += (frac.X - 1) * binning.MaxTileResolution / 2
+				expectedY += (frac.Y - 1) * binning.MaxTileResolution / 2
+			}
+			centroidX, centroidY := cluster.getCentroid(coord, meanX, meanY)
+			Expect(centroidX).To(BeNumerically("~", expectedX, 1e-9))
+			Expect(centroidY).To(BeNumerically("~", expectedY, 1e-9))
+		})
+
+		It("should average the raw values under linear projections", func() {
+			err := cluster.Parse(JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"projection": "linear",
+					"left": 0,
+					"right": 256,
+					"bottom": 0,
+					"top": 256
+				}`))
+			Expect(err).To(BeNil())
+			coord := &binning.TileCoord{Z: 0, X: 0, Y: 0}
+			cluster.addCentroidAggs(coord, query)
+			Expect(query.Fields).To(Equal([]string{
+				"AVG(x) AS centroid_x",
+				"AVG(y) AS centroid_y",
+			}))
+			centroidX, centroidY := cluster.getCentroid(coord, 10, 20)
+			Expect(centroidX).To(Equal(10.0))
+			Expect(centroidY).To(Equal(20.0))
+		})
+	})
+})
@@ -0,0 +1,129 @@
+package citus
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+// CubeTile represents a citus implementation of the cube tile.
+type CubeTile struct {
+	Bivariate
+	Frequency
+	tile.Cube
+	Tile
+}
+
+// NewCubeTile instantiates and returns a new tile struct.
+func NewCubeTile(cfg *Config) veldt.TileCtor {
+	return func() (veldt.Tile, error) {
+		c := &CubeTile{}
+		c.Config = cfg
+		return c, nil
+	}
+}
+
+// Parse parses the provided JSON object and populates the tiles attributes.
+func (c *CubeTile) Parse(params map[string]interface{}) error {
+	err := c.Bivariate.Parse(params)
+	if err != nil {
+		return err
+	}
+	err = c.Frequency.Parse(params)
+	if err != nil {
+		return err
+	}
+	_, err = strconv.ParseFloat(c.Frequency.Interval, 64)
+	if err != nil {
+		return fmt.Errorf("`interval` parameter `%s` is not numeric", c.Frequency.Interval)
+	}
+	return nil
+}
+
+// Params returns the parameters accepted by the tile.
+func (c *CubeTile) Params() []json.Param {
+	return json.MergeParams(
+		c.Bivariate.Params(),
+		c.Frequency.Params())
+}
+
+// Create generates a tile from the provided URI, tile coordinate and query
+// parameters.
+func (c *CubeTile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
+	// Initialize the tile processing.
+	client, citusQuery, err := c.InitializeTile(uri, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// add tiling query
+	citusQuery = c.Bivariate.AddQuery(coord, citusQuery)
+
+	// add frequency query
+	citusQuery = c.Frequency.AddQuery(citusQuery)
+
+	// add aggs, grouping each bin by the index of its time bucket
+	citusQuery = c.Bivariate.AddAggs(coord, citusQuery)
+	interval, _ := strconv.ParseFloat(c.Frequency.Interval, 64)
+	intervalArg := citusQuery.AddParameter(interval)
+	citusQuery.Select(fmt.Sprintf("floor(%s / %s::double precision)::bigint AS time_bucket", c.Frequency.FrequencyField, intervalArg))
+	citusQuery.Select("CAST(COUNT(*) AS FLOAT) AS value")
+	citusQuery.GroupBy("time_bucket")
+
+	// send query
+	res, err := client.Query(citusQuery.GetQuery(false), citusQuery.QueryArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	// get the counts of each bin keyed by time bucket index
+	resolution := c.Bivariate.Resolution
+	totals := make(map[int64]float64)
+	var rows []*tile.CubeBin
+	for res.Next() {
+		var x, y, index int64
+		var value float64
+		err := res.Scan(&x, &y, &index, &value)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing cube aggregation: %v", err)
+		}
+		totals[index] += value
+		rows = append(rows, &tile.CubeBin{
+			X:     c.Bivariate.clampBin(x),
+			Y:     c.Bivariate.clampBin(y),
+			Time:  int(index),
+			Count: value,
+		})
+	}
+
+	// get the time buckets of the range
+	frequency, err := c.Frequency.CreateBuckets(totals)
+	if err != nil {
+		return nil, err
+	}
+	timestamps := make([]float64, len(frequency))
+	for i, bucket := range frequency {
+		timestamps[i] = bucket.Bucket
+	}
+
+	// assign each bin its time bucket, relative to the start of the range
+	var cube []*tile.CubeBin
+	if len(frequency) > 0 {
+		start := int(math.Floor(frequency[0].Bucket/interval + 0.5))
+		for _, row := range rows {
+			row.Time -= start
+			if row.Time < 0 || row.Time >= len(frequency) {
+				continue
+			}
+			cube = append(cube, row)
+		}
+	}
+
+	// encode the result
+	return c.Cube.Encode(resolution, timestamps, cube)
+}
@@ -135,6 +135,12 @@ func (g *DefaultMeta) Parse(params map[string]interface{}) error {
 	return nil
 }
 
+// Params returns the parameters accepted by the meta data generator. There are
+// currently none.
+func (g *DefaultMeta) Params() []json.Param {
+	return []json.Param{}
+}
+
 // Create generates metadata from the provided URI.
 func (g *DefaultMeta) Create(uri string) ([]byte, error) {
 	client, err := NewClient(g.Config)
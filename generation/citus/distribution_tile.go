@@ -0,0 +1,133 @@
+package citus
+
+import (
+	"fmt"
+
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+// DistributionTile represents a citus implementation of the distribution
+// tile.
+type DistributionTile struct {
+	Bivariate
+	tile.Distribution
+	Tile
+}
+
+// NewDistributionTile instantiates and returns a new tile struct.
+func NewDistributionTile(cfg *Config) veldt.TileCtor {
+	return func() (veldt.Tile, error) {
+		d := &DistributionTile{}
+		d.Config = cfg
+		return d, nil
+	}
+}
+
+// Parse parses the provided JSON object and populates the tiles attributes.
+func (d *DistributionTile) Parse(params map[string]interface{}) error {
+	err := d.Bivariate.Parse(params)
+	if err != nil {
+		return err
+	}
+	return d.Distribution.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (d *DistributionTile) Params() []json.Param {
+	return json.MergeParams(
+		d.Bivariate.Params(),
+		d.Distribution.Params())
+}
+
+// Create generates a tile from the provided URI, tile coordinate and query
+// parameters.
+func (d *DistributionTile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
+	// Initialize the tile processing.
+	client, citusQuery, err := d.InitializeTile(uri, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// add tiling query
+	citusQuery = d.addQuery(coord, citusQuery)
+
+	// add the histogram aggs
+	field := d.Distribution.ValueField
+	var bucket string
+	if d.Distribution.Bounded {
+		// the upper bound is extended to the end of the last bucket
+		minArg := citusQuery.AddParameter(d.Distribution.Min)
+		maxArg := citusQuery.AddParameter(d.Distribution.Origin() + float64(d.Distribution.NumBuckets())*d.Distribution.Interval)
+		countArg := citusQuery.AddParameter(d.Distribution.NumBuckets())
+		bucket = fmt.Sprintf("width_bucket(%s, %s::double precision, %s::double precision, %s) - 1", field, minArg, maxArg, countArg)
+	} else {
+		// select the bucket index rather than its value so that fractional
+		// intervals keep their precision
+		intervalArg := citusQuery.AddParameter(d.Distribution.Interval)
+		bucket = fmt.Sprintf("floor(%s / %s::double precision)::bigint", field, intervalArg)
+	}
+	citusQuery.Select(fmt.Sprintf("%s AS bucket", bucket))
+	citusQuery.Select("COUNT(*) AS count")
+	citusQuery.GroupBy("bucket")
+
+	// send query
+	res, err := client.Query(citusQuery.GetQuery(false), citusQuery.QueryArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	// get buckets
+	counts := make(map[int64]uint64)
+	for res.Next() {
+		var index, count int64
+		err := res.Scan(&index, &count)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing histogram: %v", err)
+		}
+		counts[index] += uint64(count)
+	}
+	buckets, err := d.Distribution.CreateBuckets(counts)
+	if err != nil {
+		return nil, err
+	}
+
+	// get percentiles, the percentiles of an empty tile are undefined
+	var values []float64
+	if len(d.Distribution.Percentiles) > 0 && len(counts) > 0 {
+		percentilesQuery, err := d.CreateQuery(query)
+		if err != nil {
+			return nil, err
+		}
+		percentilesQuery.From(uri)
+		percentilesQuery = d.addQuery(coord, percentilesQuery)
+		percentilesArg := percentilesQuery.AddParameter(d.Distribution.Percentiles)
+		percentilesQuery.Select(fmt.Sprintf("percentile_cont(%s::double precision[]) WITHIN GROUP (ORDER BY %s) AS percentiles", percentilesArg, field))
+		row := client.QueryRow(percentilesQuery.GetQuery(false), percentilesQuery.QueryArgs...)
+		err = row.Scan(&values)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing percentiles: %v", err)
+		}
+	}
+
+	// encode the result
+	return d.Distribution.Encode(buckets, values)
+}
+
+// addQuery adds the tiling query and the bounds of the histogram to the
+// provided query object.
+func (d *DistributionTile) addQuery(coord *binning.TileCoord, query *Query) *Query {
+	query = d.Bivariate.AddQuery(coord, query)
+	if d.Distribution.Bounded {
+		minArg := query.AddParameter(d.Distribution.Min)
+		maxArg := query.AddParameter(d.Distribution.Max)
+		query.Where(fmt.Sprintf("%s >= %s::double precision and %s <= %s::double precision",
+			d.Distribution.ValueField, minArg, d.Distribution.ValueField, maxArg))
+	} else {
+		// rows without a value have no bucket
+		query.Where(fmt.Sprintf("%s IS NOT NULL", d.Distribution.ValueField))
+	}
+	return query
+}
@@ -0,0 +1,78 @@
+package citus
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/jackc/pgx"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+)
+
+// Edge represents a citus implementation of the edge tile.
+type Edge struct {
+	tile.Edge
+}
+
+// AddQuery adds the tiling query to the provided query object.
+func (e *Edge) AddQuery(coord *binning.TileCoord, query *Query) *Query {
+	bounds := e.TileBounds(coord)
+	// Require at least 1 of the points, possibly both.
+	if e.Edge.RequireSrc || !e.Edge.RequireDst {
+		addRangeQuery(query, bounds, e.Edge.SrcXField, e.Edge.SrcYField)
+	}
+	if e.Edge.RequireDst {
+		addRangeQuery(query, bounds, e.Edge.DstXField, e.Edge.DstYField)
+	}
+	return query
+}
+
+// AddFlowAggs adds the aggregations grouping edges by their source and
+// destination bins at the provided resolution and summing their weights.
+func (e *Edge) AddFlowAggs(coord *binning.TileCoord, resolution int, query *Query) *Query {
+	e.addBuckets(coord, resolution, query, e.Edge.SrcXField, e.Edge.SrcYField, "src")
+	e.addBuckets(coord, resolution, query, e.Edge.DstXField, e.Edge.DstYField, "dst")
+	query.Select(fmt.Sprintf("CAST(SUM(%s) AS FLOAT) AS weight", e.Edge.WeightField))
+	query.GroupBy("src_x_bucket")
+	query.GroupBy("src_y_bucket")
+	query.GroupBy("dst_x_bucket")
+	query.GroupBy("dst_y_bucket")
+	return query
+}
+
+func (e *Edge) addBuckets(coord *binning.TileCoord, resolution int, query *Query, xField string, yField string, prefix string) {
+	// bin each row by its projected position so that the bins follow the
+	// mercator distortion of the y axis
+	if _, ok := e.Projection.(*binning.Mercator); ok {
+		resolutionArg := query.AddParameter(float64(resolution))
+		pow2Arg := query.AddParameter(math.Pow(2, float64(coord.Z)))
+		tileXArg := query.AddParameter(float64(coord.X))
+		tileYArg := query.AddParameter(float64(coord.Y))
+		query.Select(fmt.Sprintf(mercatorXBin+" AS %s_x_bucket", xField, pow2Arg, tileXArg, resolutionArg, prefix))
+		query.Select(fmt.Sprintf(mercatorYBin+" AS %[5]s_y_bucket", yField, pow2Arg, tileYArg, resolutionArg, prefix))
+		return
+	}
+	addLinearBuckets(query, e.TileBounds(coord), resolution, xField, yField, prefix+"_x_bucket", prefix+"_y_bucket")
+}
+
+// GetFlows parses the resulting rows into flows.
+func (e *Edge) GetFlows(rows *pgx.Rows) ([]*tile.Flow, error) {
+	var flows []*tile.Flow
+	for rows.Next() {
+		var srcX, srcY, dstX, dstY int64
+		var weight float64
+		err := rows.Scan(&srcX, &srcY, &dstX, &dstY, &weight)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing flow aggregation: %v", err)
+		}
+		flows = append(flows, &tile.Flow{
+			SrcX:   int(srcX),
+			SrcY:   int(srcY),
+			DstX:   int(dstX),
+			DstY:   int(dstY),
+			Weight: weight,
+		})
+	}
+	return flows, nil
+}
@@ -0,0 +1,65 @@
+package citus
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+var _ = Describe("Edge", func() {
+
+	coord := &binning.TileCoord{Z: 1, X: 1, Y: 0}
+
+	var query *Query
+	var edge *Edge
+
+	BeforeEach(func() {
+		var err error
+		query, err = NewQuery()
+		Expect(err).To(BeNil())
+		edge = &Edge{}
+		err = edge.Parse(JSON(
+			`{
+				"srcXField": "src_x",
+				"srcYField": "src_y",
+				"dstXField": "dst_x",
+				"dstYField": "dst_y",
+				"weightField": "weight",
+				"projection": "linear",
+				"left": 0,
+				"right": 256,
+				"bottom": 0,
+				"top": 256
+			}`))
+		Expect(err).To(BeNil())
+	})
+
+	It("should query the tile bounds of the required points", func() {
+		edge.AddQuery(coord, query)
+		Expect(query.WhereClauses).To(Equal([]string{
+			"src_x >= $1::double precision and src_x < $2::double precision",
+			"src_y >= $3::double precision and src_y < $4::double precision",
+		}))
+		Expect(query.QueryArgs).To(Equal([]interface{}{
+			128.0,
+			256.0,
+			0.0,
+			128.0,
+		}))
+	})
+
+	It("should bin the points as the bivariate tiles do", func() {
+		edge.AddFlowAggs(coord, 64, query)
+		Expect(query.Fields[0]).To(Equal("floor((src_x - $2::double precision) / $3::double precision * $1)::bigint AS src_x_bucket"))
+		Expect(evalBin(query, "src_x_bucket", "src_x", 130)).To(Equal(1.0))
+		Expect(evalBin(query, "src_y_bucket", "src_y", 126)).To(Equal(63.0))
+	})
+
+	It("should not clamp the bins of points outside of the tile", func() {
+		edge.AddFlowAggs(coord, 64, query)
+		Expect(evalBin(query, "dst_x_bucket", "dst_x", 64)).To(Equal(-32.0))
+		Expect(evalBin(query, "dst_y_bucket", "dst_y", 192)).To(Equal(96.0))
+	})
+})
@@ -17,7 +17,7 @@ type Frequency struct {
 
 // FrequencyResult represents a single frequency result bucket.
 type FrequencyResult struct {
-	Bucket int64
+	Bucket float64
 	Value  float64
 }
 
@@ -33,7 +33,9 @@ func (f *Frequency) AddAggs(query *Query) *Query {
 	//Ignoring potential error. Should really be done in some kind of setup function.
 	intervalNum, _ := strconv.ParseFloat(f.Interval, 64)
 	intervalArg := query.AddParameter(intervalNum)
-	queryString := fmt.Sprintf("(%s / %s * %s)", f.FrequencyField, intervalArg, intervalArg)
+	// select the bucket index rather than its value so that fractional
+	// intervals keep their precision
+	queryString := fmt.Sprintf("floor(%s / %s::double precision)::bigint", f.FrequencyField, intervalArg)
 	query.GroupBy(queryString)
 	query.Select(fmt.Sprintf("%s as bucket", queryString))
 	query.Select("COUNT(*) as frequency")
@@ -82,8 +84,14 @@ func (f *Frequency) GetBuckets(rows *pgx.Rows) ([]*FrequencyResult, error) {
 	return f.CreateBuckets(results)
 }
 
-// CreateBuckets creates the frequency buckets, including the empty buckets as defined by the tile params.
+// CreateBuckets creates the frequency buckets, including the empty buckets as
+// defined by the tile params. The results are keyed by bucket index.
 func (f *Frequency) CreateBuckets(results map[int64]float64) ([]*FrequencyResult, error) {
+	intervalNum, err := strconv.ParseFloat(f.Interval, 64)
+	if err != nil {
+		return nil, err
+	}
+
 	//Find the min & max buckets.
 	min, max := int64(math.MaxInt64), int64(math.MinInt64)
 	for k := range results {
@@ -95,38 +103,34 @@ func (f *Frequency) CreateBuckets(results map[int64]float64) ([]*FrequencyResult
 		}
 	}
 
-	//Define the window limits.
+	//Define the window limits as bucket indices, the end is exclusive.
 	windowStart, windowEnd := int64(0), int64(0)
 	if f.GT != nil {
-		windowStart = castFrequency(f.GT)
+		windowStart = castFrequency(f.GT, intervalNum)
 	} else if f.GTE != nil {
-		windowStart = castFrequency(f.GTE)
+		windowStart = castFrequency(f.GTE, intervalNum)
 	} else {
 		windowStart = min
 	}
 	if f.LT != nil {
-		windowEnd = castFrequency(f.LT)
+		windowEnd = castFrequency(f.LT, intervalNum)
 	} else if f.LTE != nil {
-		windowEnd = castFrequency(f.LTE)
+		windowEnd = castFrequency(f.LTE, intervalNum) + 1
 	} else {
-		windowEnd = max
+		windowEnd = max + 1
 	}
-
-	//Create the buckets.
-	intervalNum, err := strconv.ParseFloat(f.Interval, 64)
-	if err != nil {
-		return nil, err
+	if windowEnd <= windowStart {
+		return []*FrequencyResult{}, nil
 	}
 
-	//May be off by 1 as result of type conversion.
-	numberOfBuckets := int64((windowEnd - windowStart)) / int64(intervalNum)
-	buckets := make([]*FrequencyResult, numberOfBuckets)
+	//Create the buckets.
+	buckets := make([]*FrequencyResult, windowEnd-windowStart)
 	for i := range buckets {
 		//If value is not in the map, 0 will be returned as default value.
-		bucket := int64(float64(i)*intervalNum) + windowStart
-		frequency := results[bucket]
+		index := windowStart + int64(i)
+		frequency := results[index]
 		buckets[i] = &FrequencyResult{
-			Bucket: bucket,
+			Bucket: float64(index) * intervalNum,
 			Value:  frequency,
 		}
 	}
@@ -144,14 +148,15 @@ func (f *Frequency) encodeResult(frequency []*FrequencyResult) []map[string]inte
 	return buckets
 }
 
-func castFrequency(val interface{}) int64 {
+// castFrequency returns the index of the bucket containing the value.
+func castFrequency(val interface{}, interval float64) int64 {
 	numF, isNum := val.(float64)
 	if isNum {
-		return int64(numF)
+		return int64(math.Floor(numF / interval))
 	}
 	numI, isNum := val.(int64)
 	if isNum {
-		return numI
+		return int64(math.Floor(float64(numI) / interval))
 	}
 	// TODO: Figure out which types are allowed, and what to do if bad data is
 	// received.
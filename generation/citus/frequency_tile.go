@@ -3,6 +3,7 @@ package citus
 import (
 	"github.com/unchartedsoftware/veldt"
 	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
 	"github.com/unchartedsoftware/veldt/util/json"
 )
 
@@ -11,6 +12,7 @@ type FrequencyTile struct {
 	Bivariate
 	Frequency
 	Tile
+	tile.Tabular
 }
 
 // NewFrequencyTile instantiates and returns a new tile struct.
@@ -28,7 +30,19 @@ func (t *FrequencyTile) Parse(params map[string]interface{}) error {
 	if err != nil {
 		return err
 	}
-	return t.Frequency.Parse(params)
+	err = t.Frequency.Parse(params)
+	if err != nil {
+		return err
+	}
+	return t.Tabular.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (t *FrequencyTile) Params() []json.Param {
+	return json.MergeParams(
+		t.Bivariate.Params(),
+		t.Frequency.Params(),
+		t.Tabular.Params())
 }
 
 // Create generates a tile from the provided URI, tile coordinate and query
@@ -62,7 +76,11 @@ func (t *FrequencyTile) Create(uri string, coord *binning.TileCoord, query veldt
 	}
 
 	buckets := t.Frequency.encodeResult(frequency)
+	table := tile.NewTable("timestamp", "count")
+	for _, bucket := range buckets {
+		table.AddRow(bucket)
+	}
 
 	// marshal results
-	return json.Marshal(buckets)
+	return t.Tabular.Encode(table, buckets)
 }
@@ -0,0 +1,34 @@
+package citus
+
+import (
+	"fmt"
+
+	"github.com/unchartedsoftware/veldt/tile"
+)
+
+// Heatmap represents a citus implementation of the heatmap tile.
+type Heatmap struct {
+	tile.Heatmap
+}
+
+// AddAggs adds the bin value aggregate to the provided query object.
+func (h *Heatmap) AddAggs(query *Query) *Query {
+	var aggregate string
+	switch h.Aggregation {
+	case tile.SumAggregation:
+		aggregate = fmt.Sprintf("SUM(%s)", h.ValueField)
+	case tile.AvgAggregation:
+		aggregate = fmt.Sprintf("AVG(%s)", h.ValueField)
+	case tile.MinAggregation:
+		aggregate = fmt.Sprintf("MIN(%s)", h.ValueField)
+	case tile.MaxAggregation:
+		aggregate = fmt.Sprintf("MAX(%s)", h.ValueField)
+	case tile.CardinalityAggregation:
+		aggregate = fmt.Sprintf("COUNT(DISTINCT %s)", h.ValueField)
+	default:
+		aggregate = "COUNT(*)"
+	}
+	// bins without any values for the field aggregate to 0
+	query.Select(fmt.Sprintf("COALESCE(CAST(%s AS FLOAT), 0) AS value", aggregate))
+	return query
+}
@@ -1,15 +1,15 @@
 package citus
 
 import (
-	"encoding/binary"
-
 	"github.com/unchartedsoftware/veldt"
 	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/util/json"
 )
 
 // HeatmapTile represents a citus implementation of the heatmap tile.
 type HeatmapTile struct {
 	Bivariate
+	Heatmap
 	Tile
 }
 
@@ -24,7 +24,18 @@ func NewHeatmapTile(cfg *Config) veldt.TileCtor {
 
 // Parse parses the provided JSON object and populates the tiles attributes.
 func (h *HeatmapTile) Parse(params map[string]interface{}) error {
-	return h.Bivariate.Parse(params)
+	err := h.Bivariate.Parse(params)
+	if err != nil {
+		return err
+	}
+	return h.Heatmap.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (h *HeatmapTile) Params() []json.Param {
+	return json.MergeParams(
+		h.Bivariate.Params(),
+		h.Heatmap.Params())
 }
 
 // Create generates a tile from the provided URI, tile coordinate and query
@@ -42,8 +53,9 @@ func (h *HeatmapTile) Create(uri string, coord *binning.TileCoord, query veldt.Q
 	// add aggs
 	citusQuery = h.Bivariate.AddAggs(coord, citusQuery)
 
-	//May support AVG (& others) in the future. May as well make it a float for now.
-	citusQuery.Select("CAST(COUNT(*) AS FLOAT) AS value")
+	// add the bin value aggregate
+	citusQuery = h.Heatmap.AddAggs(citusQuery)
+
 	// send query
 	res, err := client.Query(citusQuery.GetQuery(false), citusQuery.QueryArgs...)
 	if err != nil {
@@ -56,12 +68,12 @@ func (h *HeatmapTile) Create(uri string, coord *binning.TileCoord, query veldt.Q
 		return nil, err
 	}
 
-	// convert to byte array
-	bits := make([]byte, len(bins)*4)
+	// convert to float32
+	values := make([]float32, len(bins))
 	for i, bin := range bins {
-		binary.LittleEndian.PutUint32(
-			bits[i*4:i*4+4],
-			uint32(bin))
+		values[i] = float32(bin)
 	}
-	return bits, nil
+
+	// encode the result
+	return h.Heatmap.Encode(values)
 }
@@ -0,0 +1,111 @@
+package citus
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/jackc/pgx"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+)
+
+const (
+	// linearPixel computes the global pixel of a value within the tile bounds
+	linearPixel = "((%[2]s + (%[1]s - %[3]s) / %[4]s) * 256)"
+	// mercatorPixel computes the global pixel of a latitude
+	mercatorPixel = "((1 + ln(tan(radians(%[1]s)) + 1 / cos(radians(%[1]s))) / pi()) / 2 * %[2]s * 256)"
+	// fractionalHex computes a fractional axial coordinate from the pixel
+	fractionalHex = "(%[3]s * %[1]s + %[4]s * %[2]s)"
+)
+
+// Hexbin represents a citus implementation of the hexbin tile.
+type Hexbin struct {
+	tile.Hexbin
+}
+
+// AddQuery adds the tiling query to the provided query object.
+func (h *Hexbin) AddQuery(coord *binning.TileCoord, query *Query) *Query {
+	// include the cells straddling the tile edges
+	addRangeQuery(query, h.QueryBounds(coord), h.XField, h.YField)
+	return query
+}
+
+// AddAggs adds the tiling aggregations to the provided query object, which
+// group each row by the axial coordinate of its cell.
+func (h *Hexbin) AddAggs(coord *binning.TileCoord, query *Query) *Query {
+	bounds := h.TileBounds(coord)
+	// pixel coordinates
+	x := fmt.Sprintf(linearPixel,
+		h.XField,
+		addFloatParameter(query, float64(coord.X)),
+		addFloatParameter(query, bounds.Left),
+		addFloatParameter(query, bounds.Right-bounds.Left))
+	var y string
+	if _, ok := h.Projection.(*binning.Mercator); ok {
+		y = fmt.Sprintf(mercatorPixel,
+			h.YField,
+			addFloatParameter(query, math.Pow(2, float64(coord.Z))))
+	} else {
+		y = fmt.Sprintf(linearPixel,
+			h.YField,
+			addFloatParameter(query, float64(coord.Y)),
+			addFloatParameter(query, bounds.Bottom),
+			addFloatParameter(query, bounds.Top-bounds.Bottom))
+	}
+	// fractional axial coordinates
+	a, b, c, d := h.Grid.Basis()
+	fq := fmt.Sprintf(fractionalHex, x, y, addFloatParameter(query, a), addFloatParameter(query, b))
+	fr := fmt.Sprintf(fractionalHex, x, y, addFloatParameter(query, c), addFloatParameter(query, d))
+	fs := fmt.Sprintf("(-%s - %s)", fq, fr)
+	// rounded cube coordinates
+	rq := fmt.Sprintf("floor(%s + 0.5)", fq)
+	rr := fmt.Sprintf("floor(%s + 0.5)", fr)
+	rs := fmt.Sprintf("floor(%s + 0.5)", fs)
+	// rounding errors
+	dq := fmt.Sprintf("abs(%s - %s)", rq, fq)
+	dr := fmt.Sprintf("abs(%s - %s)", rr, fr)
+	ds := fmt.Sprintf("abs(%s - %s)", rs, fs)
+	// reset the component with the largest rounding error
+	resetQ := fmt.Sprintf("(%[1]s > %[2]s AND %[1]s > %[3]s)", dq, dr, ds)
+	resetR := fmt.Sprintf("(%s > %s)", dr, ds)
+	query.Select(fmt.Sprintf("(CASE WHEN %s THEN -%s - %s ELSE %s END)::bigint AS q_bucket",
+		resetQ, rr, rs, rq))
+	query.Select(fmt.Sprintf("(CASE WHEN %s THEN %s WHEN %s THEN -%s - %s ELSE %s END)::bigint AS r_bucket",
+		resetQ, rr, resetR, rq, rs, rr))
+	query.GroupBy("q_bucket")
+	query.GroupBy("r_bucket")
+	// result
+	return query
+}
+
+// GetCells parses the resulting rows into the cells of the tile.
+func (h *Hexbin) GetCells(coord *binning.TileCoord, rows *pgx.Rows) ([]*tile.HexCell, error) {
+	var cells []*tile.HexCell
+	for rows.Next() {
+		var q, r int64
+		var value float64
+		err := rows.Scan(&q, &r, &value)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing hexbin aggregation: %v",
+				err)
+		}
+		hex := binning.HexCoord{
+			Q: q,
+			R: r,
+		}
+		// cells belong to the tile containing their center
+		if !h.Contains(coord, &hex) {
+			continue
+		}
+		cells = append(cells, &tile.HexCell{
+			HexCoord: hex,
+			Count:    value,
+		})
+	}
+	return cells, nil
+}
+
+func addFloatParameter(query *Query, val float64) string {
+	return query.AddParameter(val) + "::double precision"
+}
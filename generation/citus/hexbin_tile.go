@@ -0,0 +1,59 @@
+package citus
+
+import (
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/binning"
+)
+
+// HexbinTile represents a citus implementation of the hexbin tile.
+type HexbinTile struct {
+	Hexbin
+	Tile
+}
+
+// NewHexbinTile instantiates and returns a new tile struct.
+func NewHexbinTile(cfg *Config) veldt.TileCtor {
+	return func() (veldt.Tile, error) {
+		h := &HexbinTile{}
+		h.Config = cfg
+		return h, nil
+	}
+}
+
+// Parse parses the provided JSON object and populates the tiles attributes.
+func (h *HexbinTile) Parse(params map[string]interface{}) error {
+	return h.Hexbin.Parse(params)
+}
+
+// Create generates a tile from the provided URI, tile coordinate and query
+// parameters.
+func (h *HexbinTile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
+	// Initialize the tile processing.
+	client, citusQuery, err := h.InitializeTile(uri, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// add tiling query
+	citusQuery = h.Hexbin.AddQuery(coord, citusQuery)
+
+	// add aggs
+	citusQuery = h.Hexbin.AddAggs(coord, citusQuery)
+
+	citusQuery.Select("CAST(COUNT(*) AS FLOAT) AS value")
+
+	// send query
+	res, err := client.Query(citusQuery.GetQuery(false), citusQuery.QueryArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	// get cells
+	cells, err := h.Hexbin.GetCells(coord, res)
+	if err != nil {
+		return nil, err
+	}
+
+	// encode the result
+	return h.Hexbin.Encode(cells), nil
+}
@@ -0,0 +1,86 @@
+package citus
+
+import (
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+// KDETile represents a citus implementation of the kernel density estimation
+// tile.
+type KDETile struct {
+	Bivariate
+	tile.KDE
+	Tile
+}
+
+// NewKDETile instantiates and returns a new tile struct.
+func NewKDETile(cfg *Config) veldt.TileCtor {
+	return func() (veldt.Tile, error) {
+		k := &KDETile{}
+		k.Config = cfg
+		return k, nil
+	}
+}
+
+// Parse parses the provided JSON object and populates the tiles attributes.
+func (k *KDETile) Parse(params map[string]interface{}) error {
+	err := k.Bivariate.Parse(params)
+	if err != nil {
+		return err
+	}
+	return k.KDE.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (k *KDETile) Params() []json.Param {
+	return json.MergeParams(
+		k.Bivariate.Params(),
+		k.KDE.Params())
+}
+
+// Create generates a tile from the provided URI, tile coordinate and query
+// parameters.
+func (k *KDETile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
+	// pad the bins by the support of the kernel
+	resolution := k.Bivariate.Resolution
+	bandwidthX, bandwidthY := k.KDE.BandwidthBins(
+		resolution,
+		k.Bivariate.BinSizeX(coord),
+		k.Bivariate.BinSizeY(coord))
+	k.Bivariate.Padding = k.KDE.Padding(resolution, bandwidthX, bandwidthY)
+
+	// Initialize the tile processing.
+	client, citusQuery, err := k.InitializeTile(uri, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// add tiling query
+	citusQuery = k.Bivariate.AddQuery(coord, citusQuery)
+
+	// add aggs
+	citusQuery = k.Bivariate.AddAggs(coord, citusQuery)
+
+	// count the points in each bin
+	citusQuery.Select("CAST(COUNT(*) AS FLOAT) AS value")
+
+	// send query
+	res, err := client.Query(citusQuery.GetQuery(false), citusQuery.QueryArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	// get bins
+	counts, err := k.Bivariate.GetBins(coord, res)
+	if err != nil {
+		return nil, err
+	}
+
+	// convolve the counts
+	densities := k.KDE.Convolve(counts, resolution, k.Bivariate.Padding, bandwidthX, bandwidthY)
+
+	// encode the result
+	return k.KDE.Encode(densities)
+}
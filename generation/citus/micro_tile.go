@@ -6,6 +6,7 @@ import (
 	"github.com/unchartedsoftware/veldt"
 	"github.com/unchartedsoftware/veldt/binning"
 	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
 )
 
 // MicroTile represents a citus implementation of the micro tile.
@@ -47,6 +48,14 @@ func (m *MicroTile) Parse(params map[string]interface{}) error {
 	return nil
 }
 
+// Params returns the parameters accepted by the tile.
+func (m *MicroTile) Params() []json.Param {
+	return json.MergeParams(
+		m.Bivariate.Params(),
+		m.TopHits.Params(),
+		m.Micro.Params())
+}
+
 // Create generates a tile from the provided URI, tile coordinate and query
 // parameters.
 func (m *MicroTile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
@@ -74,6 +83,9 @@ func (m *MicroTile) Create(uri string, coord *binning.TileCoord, query veldt.Que
 		return nil, err
 	}
 
+	// scale from [0 : 256) into the pixel space of the tile
+	scale := float64(m.Micro.Resolution) / binning.MaxTileResolution
+
 	// convert to point array
 	points := make([]float32, len(hits)*2)
 	for i, hit := range hits {
@@ -83,10 +95,10 @@ func (m *MicroTile) Create(uri string, coord *binning.TileCoord, query veldt.Que
 			return nil, fmt.Errorf("could not parse position from hit: %v", hit)
 		}
 		// add to point array
-		points[i*2] = float32(x)
-		points[i*2+1] = float32(y)
+		points[i*2] = float32(x * scale)
+		points[i*2+1] = float32(y * scale)
 	}
 
 	// encode and return results
-	return m.Micro.Encode(hits, points)
+	return m.Micro.Encode(hits, points, m.Bivariate.Unprojector(coord))
 }
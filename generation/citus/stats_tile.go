@@ -0,0 +1,101 @@
+package citus
+
+import (
+	"fmt"
+
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+// StatsTile represents a citus implementation of the stats tile.
+type StatsTile struct {
+	Bivariate
+	tile.Stats
+	Tile
+}
+
+// NewStatsTile instantiates and returns a new tile struct.
+func NewStatsTile(cfg *Config) veldt.TileCtor {
+	return func() (veldt.Tile, error) {
+		s := &StatsTile{}
+		s.Config = cfg
+		return s, nil
+	}
+}
+
+// Parse parses the provided JSON object and populates the tiles attributes.
+func (s *StatsTile) Parse(params map[string]interface{}) error {
+	err := s.Bivariate.Parse(params)
+	if err != nil {
+		return err
+	}
+	return s.Stats.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (s *StatsTile) Params() []json.Param {
+	return json.MergeParams(
+		s.Bivariate.Params(),
+		s.Stats.Params())
+}
+
+// Create generates a tile from the provided URI, tile coordinate and query
+// parameters.
+func (s *StatsTile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
+	// Initialize the tile processing.
+	client, citusQuery, err := s.InitializeTile(uri, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// add tiling query
+	citusQuery = s.Bivariate.AddQuery(coord, citusQuery)
+
+	// add aggs, with the statistics of each bin. The statistics of bins
+	// without any values for the field are discarded when encoded
+	citusQuery = s.Bivariate.AddAggs(coord, citusQuery)
+	field := s.Stats.ValueField
+	citusQuery.Select(fmt.Sprintf("COUNT(%s) AS count", field))
+	citusQuery.Select(fmt.Sprintf("COALESCE(CAST(SUM(%s) AS FLOAT), 0) AS sum", field))
+	citusQuery.Select(fmt.Sprintf("COALESCE(CAST(AVG(%s) AS FLOAT), 0) AS mean", field))
+	citusQuery.Select(fmt.Sprintf("COALESCE(CAST(MIN(%s) AS FLOAT), 0) AS min", field))
+	citusQuery.Select(fmt.Sprintf("COALESCE(CAST(MAX(%s) AS FLOAT), 0) AS max", field))
+	citusQuery.Select(fmt.Sprintf("COALESCE(CAST(STDDEV_POP(%s) AS FLOAT), 0) AS stddev", field))
+
+	// send query
+	res, err := client.Query(citusQuery.GetQuery(false), citusQuery.QueryArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to bins, merging the rows clamped into the same bin
+	resolution := s.Bivariate.PaddedResolution()
+	bins := make([]*tile.BinStats, resolution*resolution)
+	for res.Next() {
+		var x, y, count int64
+		var sum, mean, min, max, stddev float64
+		err := res.Scan(&x, &y, &count, &sum, &mean, &min, &max, &stddev)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing stats aggregation: %v", err)
+		}
+		index := s.Bivariate.clampBin(x) + resolution*s.Bivariate.clampBin(y)
+		bin := &tile.BinStats{
+			Count:  uint64(count),
+			Sum:    sum,
+			Mean:   mean,
+			Min:    min,
+			Max:    max,
+			StdDev: stddev,
+		}
+		if bins[index] != nil {
+			bins[index].Merge(bin)
+		} else {
+			bins[index] = bin
+		}
+	}
+
+	// encode the result
+	return s.Stats.Encode(bins)
+}
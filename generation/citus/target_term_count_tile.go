@@ -3,6 +3,7 @@ package citus
 import (
 	"github.com/unchartedsoftware/veldt"
 	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
 	"github.com/unchartedsoftware/veldt/util/json"
 )
 
@@ -12,6 +13,7 @@ type TargetTermCountTile struct {
 	Bivariate
 	TargetTerms
 	Tile
+	tile.Tabular
 }
 
 // NewTargetTermCountTile instantiates and returns a new tile struct.
@@ -29,7 +31,19 @@ func (t *TargetTermCountTile) Parse(params map[string]interface{}) error {
 	if err != nil {
 		return err
 	}
-	return t.TargetTerms.Parse(params)
+	err = t.TargetTerms.Parse(params)
+	if err != nil {
+		return err
+	}
+	return t.Tabular.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (t *TargetTermCountTile) Params() []json.Param {
+	return json.MergeParams(
+		t.Bivariate.Params(),
+		t.TargetTerms.Params(),
+		t.Tabular.Params())
 }
 
 // Create generates a tile from the provided URI, tile coordinate and query
@@ -60,5 +74,13 @@ func (t *TargetTermCountTile) Create(uri string, coord *binning.TileCoord, query
 	}
 
 	// marshal results
-	return json.Marshal(terms)
+	table := tile.NewTable("term", "count")
+	for term, count := range terms {
+		table.AddRow(map[string]interface{}{
+			"term":  term,
+			"count": count,
+		})
+	}
+	table.SortBy("term")
+	return t.Tabular.Encode(table, terms)
 }
@@ -3,6 +3,7 @@ package citus
 import (
 	"github.com/unchartedsoftware/veldt"
 	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
 	"github.com/unchartedsoftware/veldt/util/json"
 )
 
@@ -11,6 +12,7 @@ type TermsFrequencyCountTile struct {
 	Bivariate
 	TermsFrequency
 	Tile
+	tile.Tabular
 }
 
 // NewTermsFrequencyCountTile instantiates and returns a new tile struct.
@@ -28,7 +30,19 @@ func (t *TermsFrequencyCountTile) Parse(params map[string]interface{}) error {
 	if err != nil {
 		return err
 	}
-	return t.TermsFrequency.Parse(params)
+	err = t.TermsFrequency.Parse(params)
+	if err != nil {
+		return err
+	}
+	return t.Tabular.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (t *TermsFrequencyCountTile) Params() []json.Param {
+	return json.MergeParams(
+		t.Bivariate.Params(),
+		t.TermsFrequency.Params(),
+		t.Tabular.Params())
 }
 
 // Create generates a tile from the provided URI, tile coordinate and query parameters.
@@ -58,5 +72,13 @@ func (t *TermsFrequencyCountTile) Create(uri string, coord *binning.TileCoord, q
 	}
 
 	// marshal results
-	return json.Marshal(terms)
+	table := tile.NewTable("term", "count")
+	for term, count := range terms {
+		table.AddRow(map[string]interface{}{
+			"term":  term,
+			"count": count,
+		})
+	}
+	table.SortBy("term")
+	return t.Tabular.Encode(table, terms)
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/unchartedsoftware/veldt"
 	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
 	"github.com/unchartedsoftware/veldt/util/json"
 )
 
@@ -14,6 +15,7 @@ type TermsFrequencyTile struct {
 	TermsFrequency
 	Frequency
 	Tile
+	tile.Tabular
 }
 
 // NewTermsFrequencyTile instantiates and returns a new tile struct.
@@ -31,7 +33,19 @@ func (t *TermsFrequencyTile) Parse(params map[string]interface{}) error {
 	if err != nil {
 		return err
 	}
-	return t.TermsFrequency.Parse(params)
+	err = t.TermsFrequency.Parse(params)
+	if err != nil {
+		return err
+	}
+	return t.Tabular.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (t *TermsFrequencyTile) Params() []json.Param {
+	return json.MergeParams(
+		t.Bivariate.Params(),
+		t.TermsFrequency.Params(),
+		t.Tabular.Params())
 }
 
 // Create generates a tile from the provided URI, tile coordinate and query parameters.
@@ -76,6 +90,7 @@ func (t *TermsFrequencyTile) Create(uri string, coord *binning.TileCoord, query
 
 	// Build frequency buckets & encode.
 	result := make(map[string][]map[string]interface{})
+	table := tile.NewTable("term", "timestamp", "count")
 	for term, frequency := range rawResults {
 		// get buckets
 		buckets, err := t.Frequency.CreateBuckets(frequency)
@@ -85,7 +100,15 @@ func (t *TermsFrequencyTile) Create(uri string, coord *binning.TileCoord, query
 		// add frequency
 		frequency := t.Frequency.encodeResult(buckets)
 		result[term] = frequency
+		for _, bucket := range frequency {
+			table.AddRow(map[string]interface{}{
+				"term":      term,
+				"timestamp": bucket["timestamp"],
+				"count":     bucket["count"],
+			})
+		}
 	}
+	table.SortBy("term")
 	// marshal results
-	return json.Marshal(result)
+	return t.Tabular.Encode(table, result)
 }
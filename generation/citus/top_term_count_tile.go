@@ -3,6 +3,7 @@ package citus
 import (
 	"github.com/unchartedsoftware/veldt"
 	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
 	"github.com/unchartedsoftware/veldt/util/json"
 )
 
@@ -12,6 +13,7 @@ type TopTermCountTile struct {
 	Bivariate
 	TopTerms
 	Tile
+	tile.Tabular
 }
 
 // NewTopTermCountTile instantiates and returns a new tile struct.
@@ -29,7 +31,19 @@ func (t *TopTermCountTile) Parse(params map[string]interface{}) error {
 	if err != nil {
 		return err
 	}
-	return t.TopTerms.Parse(params)
+	err = t.TopTerms.Parse(params)
+	if err != nil {
+		return err
+	}
+	return t.Tabular.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (t *TopTermCountTile) Params() []json.Param {
+	return json.MergeParams(
+		t.Bivariate.Params(),
+		t.TopTerms.Params(),
+		t.Tabular.Params())
 }
 
 // Create generates a tile from the provided URI, tile coordinate and query
@@ -58,5 +72,13 @@ func (t *TopTermCountTile) Create(uri string, coord *binning.TileCoord, query ve
 	if err != nil {
 		return nil, err
 	}
-	return json.Marshal(counts)
+	table := tile.NewTable("term", "count")
+	for term, count := range counts {
+		table.AddRow(map[string]interface{}{
+			"term":  term,
+			"count": count,
+		})
+	}
+	table.SortBy("term")
+	return t.Tabular.Encode(table, counts)
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/unchartedsoftware/veldt"
 	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
 	"github.com/unchartedsoftware/veldt/util/json"
 )
 
@@ -15,6 +16,7 @@ type TopTermFrequencyTile struct {
 	TopTerms
 	Frequency
 	Tile
+	tile.Tabular
 }
 
 // NewTopTermFrequencyTile instantiates and returns a new tile struct.
@@ -32,7 +34,19 @@ func (t *TopTermFrequencyTile) Parse(params map[string]interface{}) error {
 	if err != nil {
 		return err
 	}
-	return t.TopTerms.Parse(params)
+	err = t.TopTerms.Parse(params)
+	if err != nil {
+		return err
+	}
+	return t.Tabular.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (t *TopTermFrequencyTile) Params() []json.Param {
+	return json.MergeParams(
+		t.Bivariate.Params(),
+		t.TopTerms.Params(),
+		t.Tabular.Params())
 }
 
 // Create generates a tile from the provided URI, tile coordinate and query
@@ -78,6 +92,7 @@ func (t *TopTermFrequencyTile) Create(uri string, coord *binning.TileCoord, quer
 
 	// encode
 	result := make(map[string][]map[string]interface{})
+	table := tile.NewTable("term", "timestamp", "count")
 	for term, frequency := range rawResults {
 		// get buckets
 		buckets, err := t.Frequency.CreateBuckets(frequency)
@@ -87,7 +102,15 @@ func (t *TopTermFrequencyTile) Create(uri string, coord *binning.TileCoord, quer
 		// add frequency
 		frequency := t.Frequency.encodeResult(buckets)
 		result[term] = frequency
+		for _, bucket := range frequency {
+			table.AddRow(map[string]interface{}{
+				"term":      term,
+				"timestamp": bucket["timestamp"],
+				"count":     bucket["count"],
+			})
+		}
 	}
+	table.SortBy("term")
 	// marshal results
-	return json.Marshal(result)
+	return t.Tabular.Encode(table, result)
 }
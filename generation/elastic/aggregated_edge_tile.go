@@ -0,0 +1,81 @@
+package elastic
+
+import (
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+// AggregatedEdgeTile represents an elasticsearch implementation of the
+// aggregated edge tile.
+type AggregatedEdgeTile struct {
+	Elastic
+	Edge
+	tile.AggregatedEdge
+}
+
+// NewAggregatedEdgeTile instantiates and returns a new tile struct.
+func NewAggregatedEdgeTile(host, port string) veldt.TileCtor {
+	return func() (veldt.Tile, error) {
+		e := &AggregatedEdgeTile{}
+		e.Host = host
+		e.Port = port
+		return e, nil
+	}
+}
+
+// Parse parses the provided JSON object and populates the tiles attributes.
+func (e *AggregatedEdgeTile) Parse(params map[string]interface{}) error {
+	err := e.Edge.Parse(params)
+	if err != nil {
+		return err
+	}
+	return e.AggregatedEdge.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (e *AggregatedEdgeTile) Params() []json.Param {
+	return json.MergeParams(
+		e.Edge.Params(),
+		e.AggregatedEdge.Params())
+}
+
+// Create generates a tile from the provided URI, tile coordinate and query
+// parameters.
+func (e *AggregatedEdgeTile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
+	// create search service
+	search, err := e.CreateSearchService(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	// create root query
+	q, err := e.CreateQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	// add tiling query
+	q = q.Must(e.Edge.GetQuery(coord))
+
+	// set the query
+	search.Query(q)
+
+	// set the aggregation
+	search.Aggregation("srcX", e.Edge.GetFlowAggs(coord, e.AggregatedEdge.Resolution))
+
+	// send query
+	res, err := search.Do()
+	if err != nil {
+		return nil, err
+	}
+
+	// get flows
+	flows, err := e.Edge.GetFlows(&res.Aggregations)
+	if err != nil {
+		return nil, err
+	}
+
+	// encode and return results
+	return e.AggregatedEdge.Encode(flows, e.Edge.Unprojector(coord))
+}
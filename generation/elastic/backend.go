@@ -0,0 +1,61 @@
+package elastic
+
+import (
+	"strconv"
+
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/config"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+func init() {
+	config.RegisterBackend("elastic", NewBackend)
+}
+
+// NewBackend instantiates and returns the elasticsearch tile, meta and query
+// types for the provided connection parameters.
+func NewBackend(params map[string]interface{}) (*config.Backend, error) {
+	err := config.ValidateParams(params, []json.Param{
+		{Key: "host", Type: json.TypeString, Default: "localhost"},
+		{Key: "port", Type: json.TypeInteger, Default: 9200},
+	})
+	if err != nil {
+		return nil, err
+	}
+	host := json.GetStringDefault(params, "localhost", "host")
+	port := strconv.Itoa(json.GetIntDefault(params, 9200, "port"))
+	return &config.Backend{
+		Queries: map[string]veldt.QueryCtor{
+			"exists":        NewExists,
+			"has":           NewHas,
+			"equals":        NewEquals,
+			"range":         NewRange,
+			"matchesString": NewMatchesString,
+		},
+		Binary: NewBinaryExpression,
+		Unary:  NewUnaryExpression,
+		Tiles: map[string]veldt.TileCtor{
+			"heatmap":             NewHeatmapTile(host, port),
+			"hexbin":              NewHexbinTile(host, port),
+			"count":               NewCountTile(host, port),
+			"frequency":           NewFrequencyTile(host, port),
+			"macro":               NewMacroTile(host, port),
+			"cluster":             NewClusterTile(host, port),
+			"kde":                 NewKDETile(host, port),
+			"stats":               NewStatsTile(host, port),
+			"distribution":        NewDistributionTile(host, port),
+			"cube":                NewCubeTile(host, port),
+			"micro":               NewMicroTile(host, port),
+			"macroEdge":           NewMacroEdgeTile(host, port),
+			"aggregatedEdge":      NewAggregatedEdgeTile(host, port),
+			"binnedTopHits":       NewBinnedTopHits(host, port),
+			"topTermCount":        NewTopTermCountTile(host, port),
+			"topTermFrequency":    NewTopTermFrequencyTile(host, port),
+			"targetTermCount":     NewTargetTermCountTile(host, port),
+			"targetTermFrequency": NewTargetTermFrequencyTile(host, port),
+		},
+		Metas: map[string]veldt.MetaCtor{
+			"default": NewDefaultMeta(host, port),
+		},
+	}, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/unchartedsoftware/veldt"
 	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
 	"github.com/unchartedsoftware/veldt/util/json"
 )
 
@@ -14,6 +15,7 @@ type BinnedTopHits struct {
 	Elastic
 	Bivariate
 	TopHits
+	tile.Tabular
 }
 
 // NewBinnedTopHits instantiates and returns a new tile struct.
@@ -32,7 +34,19 @@ func (b *BinnedTopHits) Parse(params map[string]interface{}) error {
 	if err != nil {
 		return err
 	}
-	return b.Bivariate.Parse(params)
+	err = b.Bivariate.Parse(params)
+	if err != nil {
+		return err
+	}
+	return b.Tabular.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (b *BinnedTopHits) Params() []json.Param {
+	return json.MergeParams(
+		b.Bivariate.Params(),
+		b.TopHits.Params(),
+		b.Tabular.Params())
 }
 
 // Create generates a tile from the provided URI, tile coordinate and query
@@ -89,8 +103,9 @@ func (b *BinnedTopHits) Create(uri string, coord *binning.TileCoord, query veldt
 	binSize := binning.MaxTileResolution / float64(b.Resolution)
 	halfSize := float64(binSize / 2)
 
-	// convert to point array
+	// convert to point array, and a table with a row per hit
 	points := make([]float32, len(bins)*2)
+	table := tile.NewTable("x", "y")
 	numPoints := 0
 	for i, bin := range bins {
 		if bin != nil {
@@ -99,11 +114,20 @@ func (b *BinnedTopHits) Create(uri string, coord *binning.TileCoord, query veldt
 			points[numPoints*2] = x
 			points[numPoints*2+1] = y
 			numPoints++
+			for _, hit := range bin {
+				row := make(map[string]interface{})
+				for field, val := range hit {
+					row[field] = val
+				}
+				row["x"] = x
+				row["y"] = y
+				table.AddRow(row)
+			}
 		}
 	}
 
 	//encode
-	return json.Marshal(map[string]interface{}{
+	return b.Tabular.Encode(table, map[string]interface{}{
 		"points": points[0 : numPoints*2],
 		"hits":   bins,
 	})
@@ -7,9 +7,26 @@ import (
 	"gopkg.in/olivere/elastic.v3"
 
 	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/geometry"
 	"github.com/unchartedsoftware/veldt/tile"
 )
 
+const (
+	// linearBin computes the bin of a value within the tile bounds
+	linearBin = "floor((doc['%[1]s'].value - min) / range * resolution)"
+	// mercatorX computes the x position of a longitude within the tile
+	mercatorX = "((doc['%[1]s'].value + 180) / 360 * pow2 - tile) * resolution"
+	// mercatorY computes the y position of a latitude within the tile
+	mercatorY = "((1 + ln(tan(doc['%[1]s'].value * pi / 180) + 1 / cos(doc['%[1]s'].value * pi / 180)) / pi) / 2 * pow2 - tile) * resolution"
+	// mercatorXBin computes the x bin of a longitude within the tile
+	mercatorXBin = "floor(" + mercatorX + ")"
+	// mercatorYBin computes the y bin of a latitude within the tile
+	mercatorYBin = "floor(" + mercatorY + ")"
+	// clampedBin clamps a bin to the bins of the tile, so that documents
+	// rounded outside of the tile are aggregated into its edge bins
+	clampedBin = "min(max(%s, 0), bins - 1)"
+)
+
 // Bivariate represents an elasticsearch implementation of the bivariate tile.
 type Bivariate struct {
 	tile.Bivariate
@@ -17,36 +34,23 @@ type Bivariate struct {
 
 // GetQuery returns the tiling query.
 func (b *Bivariate) GetQuery(coord *binning.TileCoord) elastic.Query {
-	// get tile bounds
-	bounds := b.TileBounds(coord)
+	// get tile bounds, including any padding
+	bounds := b.QueryBounds(coord)
 	// create the range queries
 	query := elastic.NewBoolQuery()
 	query.Must(elastic.NewRangeQuery(b.XField).
-		Gte(int64(bounds.MinX())).
-		Lt(int64(bounds.MaxX())))
+		Gte(bounds.MinX()).
+		Lt(bounds.MaxX()))
 	query.Must(elastic.NewRangeQuery(b.YField).
-		Gte(int64(bounds.MinY())).
-		Lt(int64(bounds.MaxY())))
+		Gte(bounds.MinY()).
+		Lt(bounds.MaxY()))
 	return query
 }
 
 // GetAggs returns the tiling aggregation.
 func (b *Bivariate) GetAggs(coord *binning.TileCoord) map[string]elastic.Aggregation {
-	bounds := b.TileBounds(coord)
-	// compute binning itnernal
-	intervalX := int64(math.Max(1, b.BinSizeX(coord)))
-	intervalY := int64(math.Max(1, b.BinSizeY(coord)))
 	// create the binning aggregations
-	x := elastic.NewHistogramAggregation().
-		Field(b.XField).
-		Offset(int64(bounds.MinX())).
-		Interval(intervalX).
-		MinDocCount(1)
-	y := elastic.NewHistogramAggregation().
-		Field(b.YField).
-		Offset(int64(bounds.MinY())).
-		Interval(intervalY).
-		MinDocCount(1)
+	x, y := b.getHistograms(coord)
 	x.SubAggregation("y", y)
 	return map[string]elastic.Aggregation{
 		"x": x,
@@ -56,21 +60,8 @@ func (b *Bivariate) GetAggs(coord *binning.TileCoord) map[string]elastic.Aggrega
 
 // GetAggsWithNested returns the tiling aggregation with a nested child agg.
 func (b *Bivariate) GetAggsWithNested(coord *binning.TileCoord, id string, nested elastic.Aggregation) map[string]elastic.Aggregation {
-	bounds := b.TileBounds(coord)
-	// compute binning itnernal
-	intervalX := int64(math.Max(1, b.BinSizeX(coord)))
-	intervalY := int64(math.Max(1, b.BinSizeY(coord)))
 	// create the binning aggregations
-	x := elastic.NewHistogramAggregation().
-		Field(b.XField).
-		Offset(int64(bounds.MinX())).
-		Interval(intervalX).
-		MinDocCount(1)
-	y := elastic.NewHistogramAggregation().
-		Field(b.YField).
-		Offset(int64(bounds.MinY())).
-		Interval(intervalY).
-		MinDocCount(1)
+	x, y := b.getHistograms(coord)
 	x.SubAggregation("y", y)
 	aggs := map[string]elastic.Aggregation{
 		"x": x,
@@ -83,6 +74,87 @@ func (b *Bivariate) GetAggsWithNested(coord *binning.TileCoord, id string, neste
 	return aggs
 }
 
+// getHistograms returns histograms keyed by bin. Histogram intervals are
+// integers, so each document is binned by a script to preserve the precision
+// of small ranges.
+func (b *Bivariate) getHistograms(coord *binning.TileCoord) (*elastic.HistogramAggregation, *elastic.HistogramAggregation) {
+	bins := b.PaddedResolution()
+	var xScript, yScript *elastic.Script
+	if isMercator(b.Projection) {
+		// bin each document by its projected position so that the bins
+		// follow the mercator distortion of the y axis, offsetting the tile
+		// so that the first bin is the first padding bin
+		pad := b.PaddingFraction()
+		xScript = getMercatorScript(clampBin(mercatorXBin), b.XField, coord.Z, float64(coord.X)-pad, b.Resolution)
+		yScript = getMercatorScript(clampBin(mercatorYBin), b.YField, coord.Z, float64(coord.Y)-pad, b.Resolution)
+	} else {
+		bounds := b.QueryBounds(coord)
+		xScript = getLinearScript(clampBin(linearBin), b.XField, bounds.Left, bounds.Right, bins)
+		yScript = getLinearScript(clampBin(linearBin), b.YField, bounds.Bottom, bounds.Top, bins)
+	}
+	xScript.Param("bins", float64(bins))
+	yScript.Param("bins", float64(bins))
+	return getHistogram(xScript), getHistogram(yScript)
+}
+
+func getHistograms(coord *binning.TileCoord, projection binning.Projection, bounds *geometry.Bounds, xField string, yField string, resolution int) (*elastic.HistogramAggregation, *elastic.HistogramAggregation) {
+	var xScript, yScript *elastic.Script
+	if isMercator(projection) {
+		// bin each document by its projected position so that the bins
+		// follow the mercator distortion of the y axis
+		xScript = getMercatorScript(mercatorXBin, xField, coord.Z, float64(coord.X), resolution)
+		yScript = getMercatorScript(mercatorYBin, yField, coord.Z, float64(coord.Y), resolution)
+	} else {
+		xScript = getLinearScript(linearBin, xField, bounds.Left, bounds.Right, resolution)
+		yScript = getLinearScript(linearBin, yField, bounds.Bottom, bounds.Top, resolution)
+	}
+	return getHistogram(xScript), getHistogram(yScript)
+}
+
+func getHistogram(script *elastic.Script) *elastic.HistogramAggregation {
+	return elastic.NewHistogramAggregation().
+		Script(script).
+		Interval(1).
+		MinDocCount(1)
+}
+
+func clampBin(script string) string {
+	return fmt.Sprintf(clampedBin, script)
+}
+
+func getLinearScript(script string, field string, min float64, max float64, resolution int) *elastic.Script {
+	return elastic.NewScriptInline(fmt.Sprintf(script, field)).
+		Lang("expression").
+		Param("min", min).
+		Param("range", max-min).
+		Param("resolution", float64(resolution))
+}
+
+func getMercatorScript(script string, field string, z uint32, tile float64, resolution int) *elastic.Script {
+	return elastic.NewScriptInline(fmt.Sprintf(script, field)).
+		Lang("expression").
+		Param("pi", math.Pi).
+		Param("pow2", math.Pow(2, float64(z))).
+		Param("tile", tile).
+		Param("resolution", float64(resolution))
+}
+
+func isMercator(projection binning.Projection) bool {
+	_, ok := projection.(*binning.Mercator)
+	return ok
+}
+
+func (b *Bivariate) getBin(key int64) int {
+	resolution := b.PaddedResolution()
+	if key < 0 {
+		return 0
+	}
+	if key > int64(resolution)-1 {
+		return resolution - 1
+	}
+	return int(key)
+}
+
 // GetBins parses the resulting histograms into bins.
 func (b *Bivariate) GetBins(coord *binning.TileCoord, aggs *elastic.Aggregations) ([]*elastic.AggregationBucketHistogramItem, error) {
 	// parse aggregations
@@ -90,20 +162,20 @@ func (b *Bivariate) GetBins(coord *binning.TileCoord, aggs *elastic.Aggregations
 	if !ok {
 		return nil, fmt.Errorf("histogram aggregation `x` was not found")
 	}
-	// allocate bins
-	bins := make([]*elastic.AggregationBucketHistogramItem, b.Resolution*b.Resolution)
+	// allocate bins, including any padding
+	resolution := b.PaddedResolution()
+	bins := make([]*elastic.AggregationBucketHistogramItem, resolution*resolution)
 	// fill bins
 	for _, xBucket := range xAgg.Buckets {
-		x := xBucket.Key
-		xBin := b.GetXBin(coord, float64(x))
+		// keys are already bins
+		xBin := b.getBin(xBucket.Key)
 		yAgg, ok := xBucket.Histogram("y")
 		if !ok {
 			return nil, fmt.Errorf("histogram aggregation `y` was not found")
 		}
 		for _, yBucket := range yAgg.Buckets {
-			y := yBucket.Key
-			yBin := b.GetYBin(coord, float64(y))
-			index := xBin + b.Resolution*yBin
+			yBin := b.getBin(yBucket.Key)
+			index := xBin + resolution*yBin
 			bins[index] = yBucket
 		}
 	}
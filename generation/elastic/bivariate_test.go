@@ -0,0 +1,190 @@
+package elastic
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"gopkg.in/olivere/elastic.v3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/util/json"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+// source returns the JSON source of the query or aggregation.
+func source(s elastic.Query) map[string]interface{} {
+	src, err := s.Source()
+	Expect(err).To(BeNil())
+	bytes, err := json.Marshal(src)
+	Expect(err).To(BeNil())
+	res, err := json.Unmarshal(bytes)
+	Expect(err).To(BeNil())
+	return res
+}
+
+// getFloat returns the float at the path of the JSON source.
+func getFloat(src map[string]interface{}, path ...string) float64 {
+	val, ok := json.GetFloat(src, path...)
+	Expect(ok).To(BeTrue())
+	return val
+}
+
+// evalBin evaluates the bin script of the histogram aggregation for a document
+// with the provided field value.
+func evalBin(agg elastic.Aggregation, field string, val float64) float64 {
+	return evalScript(agg, "histogram", field, val)
+}
+
+// evalScript evaluates the script of the aggregation of the provided type for
+// a document with the provided field value.
+func evalScript(agg elastic.Aggregation, typ string, field string, val float64) float64 {
+	src := source(agg)
+	script, ok := json.GetString(src, typ, "script", "inline")
+	Expect(ok).To(BeTrue())
+	params, ok := json.GetChild(src, typ, "script", "params")
+	Expect(ok).To(BeTrue())
+	vars := map[string]float64{
+		field: val,
+	}
+	for key := range params {
+		vars[key] = getFloat(params, key)
+	}
+	script = strings.Replace(script, fmt.Sprintf("doc['%s'].value", field), field, -1)
+	return Eval(script, vars)
+}
+
+var _ = Describe("Bivariate", func() {
+
+	Describe("linear", func() {
+
+		// a tile of a unit range, whose bounds are all fractional
+		coord := &binning.TileCoord{Z: 20, X: 3, Y: 5}
+		size := 1 / math.Pow(2, 20)
+
+		var bivariate *Bivariate
+
+		BeforeEach(func() {
+			bivariate = &Bivariate{}
+			err := bivariate.Parse(JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"projection": "linear",
+					"left": 0,
+					"right": 1,
+					"bottom": 0,
+					"top": 1,
+					"resolution": 256
+				}`))
+			Expect(err).To(BeNil())
+		})
+
+		It("should query the fractional bounds of the tile", func() {
+			bounds := bivariate.TileBounds(coord)
+			query := source(bivariate.GetQuery(coord))
+			must, ok := json.GetChildArray(query, "bool", "must")
+			Expect(ok).To(BeTrue())
+			Expect(must).To(HaveLen(2))
+			Expect(getFloat(must[0], "range", "x", "from")).To(Equal(3 * size))
+			Expect(getFloat(must[0], "range", "x", "to")).To(Equal(4 * size))
+			Expect(getFloat(must[1], "range", "y", "from")).To(Equal(bounds.MinY()))
+			Expect(getFloat(must[1], "range", "y", "to")).To(Equal(bounds.MaxY()))
+			Expect(bounds.MinY()).To(BeNumerically(">", 0))
+		})
+
+		It("should bin by the fractional bounds of the tile", func() {
+			bounds := bivariate.TileBounds(coord)
+			aggs := bivariate.GetAggs(coord)
+			x := source(aggs["x"])
+			Expect(getFloat(x, "histogram", "script", "params", "min")).To(Equal(3 * size))
+			Expect(getFloat(x, "histogram", "script", "params", "range")).To(Equal(size))
+			Expect(getFloat(x, "histogram", "script", "params", "resolution")).To(Equal(256.0))
+			y := source(aggs["y"])
+			Expect(getFloat(y, "histogram", "script", "params", "min")).To(Equal(bounds.Bottom))
+			Expect(getFloat(y, "histogram", "script", "params", "range")).To(Equal(bounds.Top - bounds.Bottom))
+		})
+
+		It("should clamp keys just outside of the tile into its edge bins", func() {
+			aggs := bivariate.GetAggs(coord)
+			Expect(evalBin(aggs["x"], "x", 4*size)).To(Equal(255.0))
+			Expect(evalBin(aggs["x"], "x", 3*size-size/1024)).To(Equal(0.0))
+			Expect(evalBin(aggs["x"], "x", 3.5*size)).To(Equal(128.0))
+		})
+	})
+
+	Describe("mercator", func() {
+
+		zoom := uint32(12)
+		resolution := 256.0
+
+		// lon / lat points in each hemisphere
+		points := []*binning.LonLat{
+			binning.NewLonLat(0.5, 0.5),
+			binning.NewLonLat(-122.4194, 37.7749),
+			binning.NewLonLat(151.2093, -33.8688),
+			binning.NewLonLat(-70.6693, -79.5),
+		}
+
+		var bivariate *Bivariate
+
+		BeforeEach(func() {
+			bivariate = &Bivariate{}
+			err := bivariate.Parse(JSON(
+				`{
+					"xField": "lon",
+					"yField": "lat",
+					"projection": "mercator",
+					"resolution": 256
+				}`))
+			Expect(err).To(BeNil())
+		})
+
+		It("should bin by the projected position of each point", func() {
+			for _, point := range points {
+				frac := binning.LonLatToFractionalTile(point, zoom)
+				coord := &binning.TileCoord{Z: zoom, X: uint32(frac.X), Y: uint32(frac.Y)}
+				aggs := bivariate.GetAggs(coord)
+				x := evalBin(aggs["x"], "lon", point.Lon)
+				y := evalBin(aggs["y"], "lat", point.Lat)
+				Expect(x).To(Equal(math.Floor((frac.X - float64(coord.X)) * resolution)))
+				Expect(y).To(Equal(math.Floor((frac.Y - float64(coord.Y)) * resolution)))
+			}
+		})
+
+		It("should bin y from the bottom of the tile", func() {
+			coord := &binning.TileCoord{Z: zoom, X: 655, Y: 2510}
+			projection := &binning.Mercator{}
+			bottom := projection.Unproject(&binning.FractionalTileCoord{Z: zoom, X: 655.5, Y: 2510.001})
+			top := projection.Unproject(&binning.FractionalTileCoord{Z: zoom, X: 655.5, Y: 2510.999})
+			aggs := bivariate.GetAggs(coord)
+			Expect(evalBin(aggs["y"], "lat", bottom.Y)).To(Equal(0.0))
+			Expect(evalBin(aggs["y"], "lat", top.Y)).To(Equal(resolution - 1))
+			Expect(evalBin(aggs["x"], "lon", bottom.X)).To(Equal(resolution / 2))
+		})
+
+		It("should clamp keys just outside of the tile into its edge bins", func() {
+			coord := &binning.TileCoord{Z: zoom, X: 655, Y: 2510}
+			projection := &binning.Mercator{}
+			above := projection.Unproject(&binning.FractionalTileCoord{Z: zoom, X: 655.5, Y: 2511.0001})
+			aggs := bivariate.GetAggs(coord)
+			Expect(evalBin(aggs["y"], "lat", above.Y)).To(Equal(resolution - 1))
+		})
+
+		It("should offset the bins by the padding", func() {
+			bivariate.Padding = 8
+			for _, point := range points {
+				frac := binning.LonLatToFractionalTile(point, zoom)
+				coord := &binning.TileCoord{Z: zoom, X: uint32(frac.X), Y: uint32(frac.Y)}
+				aggs := bivariate.GetAggs(coord)
+				x := evalBin(aggs["x"], "lon", point.Lon)
+				y := evalBin(aggs["y"], "lat", point.Lat)
+				Expect(x).To(Equal(math.Floor((frac.X-float64(coord.X))*resolution) + 8))
+				Expect(y).To(Equal(math.Floor((frac.Y-float64(coord.Y))*resolution) + 8))
+			}
+		})
+	})
+})
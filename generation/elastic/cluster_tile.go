@@ -0,0 +1,168 @@
+package elastic
+
+import (
+	"fmt"
+
+	"gopkg.in/olivere/elastic.v3"
+
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+// ClusterTile represents an elasticsearch implementation of the cluster tile.
+type ClusterTile struct {
+	Elastic
+	Bivariate
+	TopHits
+	tile.Cluster
+}
+
+// NewClusterTile instantiates and returns a new tile struct.
+func NewClusterTile(host, port string) veldt.TileCtor {
+	return func() (veldt.Tile, error) {
+		c := &ClusterTile{}
+		c.Host = host
+		c.Port = port
+		return c, nil
+	}
+}
+
+// Parse parses the provided JSON object and populates the tiles attributes.
+func (c *ClusterTile) Parse(params map[string]interface{}) error {
+	err := c.Bivariate.Parse(params)
+	if err != nil {
+		return err
+	}
+	err = c.Cluster.Parse(params)
+	if err != nil {
+		return err
+	}
+	// representative hits are optional
+	if json.Exists(params, "hitsCount") {
+		err = c.TopHits.Parse(params)
+		if err != nil {
+			return err
+		}
+	}
+	// bin by cluster cell
+	c.Bivariate.Resolution = c.Cluster.Resolution()
+	return nil
+}
+
+// Params returns the parameters accepted by the tile. The resolution is set
+// by the radius of the clusters.
+func (c *ClusterTile) Params() []json.Param {
+	return json.OmitParams(
+		json.MergeParams(
+			c.Bivariate.Params(),
+			c.Cluster.Params(),
+			c.TopHits.Params()),
+		"resolution")
+}
+
+// Create generates a tile from the provided URI, tile coordinate and query
+// parameters.
+func (c *ClusterTile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
+	// create search service
+	search, err := c.CreateSearchService(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	// create root query
+	q, err := c.CreateQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	// add tiling query
+	q.Must(c.Bivariate.GetQuery(coord))
+	// set the query
+	search.Query(q)
+
+	// get aggs, with the centroid and hits of each cell
+	x, y := c.Bivariate.getHistograms(coord)
+	centroidXAgg, centroidYAgg := c.getCentroidAggs(coord)
+	y.SubAggregation("centroid-x", centroidXAgg)
+	y.SubAggregation("centroid-y", centroidYAgg)
+	if c.TopHits.HitsCount > 0 {
+		y.SubAggregation("top-hits", c.TopHits.GetAggs()["top-hits"])
+	}
+	x.SubAggregation("y", y)
+
+	// set the aggregation
+	search.Aggregation("x", x)
+
+	// send query
+	res, err := search.Do()
+	if err != nil {
+		return nil, err
+	}
+
+	// get bins
+	bins, err := c.Bivariate.GetBins(coord, &res.Aggregations)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to cells
+	resolution := c.Bivariate.Resolution
+	var cells []*tile.ClusterCell
+	for i, bin := range bins {
+		if bin == nil {
+			continue
+		}
+		centroidX, ok := bin.Avg("centroid-x")
+		if !ok || centroidX.Value == nil {
+			return nil, fmt.Errorf("avg aggregation `centroid-x` was not found")
+		}
+		centroidY, ok := bin.Avg("centroid-y")
+		if !ok || centroidY.Value == nil {
+			return nil, fmt.Errorf("avg aggregation `centroid-y` was not found")
+		}
+		cell := &tile.ClusterCell{
+			X:     i % resolution,
+			Y:     i / resolution,
+			Count: uint64(bin.DocCount),
+		}
+		cell.CentroidX, cell.CentroidY = c.getCentroid(coord, *centroidX.Value, *centroidY.Value)
+		if c.TopHits.HitsCount > 0 {
+			cell.Hits, err = c.TopHits.GetTopHits(&bin.Aggregations)
+			if err != nil {
+				return nil, err
+			}
+		}
+		cells = append(cells, cell)
+	}
+
+	// encode and return results
+	return c.Cluster.Encode(coord, cells)
+}
+
+// getCentroidAggs returns the aggregations of the centroid of each cell. Under
+// the mercator projection the mean of the projected positions differs from the
+// projection of the mean latitude, so the positions within the tile are
+// averaged instead of the raw values.
+func (c *ClusterTile) getCentroidAggs(coord *binning.TileCoord) (*elastic.AvgAggregation, *elastic.AvgAggregation) {
+	if isMercator(c.Bivariate.Projection) {
+		resolution := int(binning.MaxTileResolution)
+		x := elastic.NewAvgAggregation().
+			Script(getMercatorScript(mercatorX, c.Bivariate.XField, coord.Z, float64(coord.X), resolution))
+		y := elastic.NewAvgAggregation().
+			Script(getMercatorScript(mercatorY, c.Bivariate.YField, coord.Z, float64(coord.Y), resolution))
+		return x, y
+	}
+	x := elastic.NewAvgAggregation().Field(c.Bivariate.XField)
+	y := elastic.NewAvgAggregation().Field(c.Bivariate.YField)
+	return x, y
+}
+
+// getCentroid returns the centroid within the range of [0 : 256) for the tile
+// from the results of the centroid aggregations.
+func (c *ClusterTile) getCentroid(coord *binning.TileCoord, x float64, y float64) (float64, float64) {
+	if isMercator(c.Bivariate.Projection) {
+		return x, y
+	}
+	return c.Bivariate.GetX(coord, x), c.Bivariate.GetY(coord, y)
+}
@@ -0,0 +1,100 @@
+package elastic
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+var _ = Describe("ClusterTile", func() {
+
+	var cluster *ClusterTile
+
+	BeforeEach(func() {
+		cluster = &ClusterTile{}
+	})
+
+	Describe("Parse", func() {
+		It("should return an error if the `resolution` is provided", func() {
+			err := cluster.Parse(JSON(
+				`{
+					"xField": "lon",
+					"yField": "lat",
+					"projection": "mercator",
+					"resolution": 64
+				}`))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Params", func() {
+		It("should not describe the `resolution`", func() {
+			for _, param := range cluster.Params() {
+				Expect(param.Key).NotTo(Equal("resolution"))
+			}
+		})
+	})
+
+	Describe("getCentroidAggs", func() {
+		It("should average the mercator positions of the points within the tile", func() {
+			err := cluster.Parse(JSON(
+				`{
+					"xField": "lon",
+					"yField": "lat",
+					"projection": "mercator"
+				}`))
+			Expect(err).To(BeNil())
+			// two points far apart in latitude within a single zoom 1 tile
+			coord := &binning.TileCoord{Z: 1, X: 1, Y: 1}
+			points := []*binning.LonLat{
+				binning.NewLonLat(10, 5),
+				binning.NewLonLat(170, 80),
+			}
+			x, y := cluster.getCentroidAggs(coord)
+			var meanX, meanY, expectedX, expectedY float64
+			for _, point := range points {
+				meanX += evalScript(x, "avg", "lon", point.Lon) / 2
+				meanY += evalScript(y, "avg", "lat", point.Lat) / 2
+				frac := binning.LonLatToFractionalTile(point, coord.Z)
+				expectedX += (frac.X - 1) * binning.MaxTileResolution / 2
+				expectedY += (frac.Y - 1) * binning.MaxTileResolution / 2
+			}
+			centroidX, centroidY := cluster.getCentroid(coord, meanX, meanY)
+			Expect(centroidX).To(BeNumerically("~", expectedX, 1e-9))
+			Expect(centroidY).To(BeNumerically("~", expectedY, 1e-9))
+			// the projection of the mean latitude is elsewhere
+			_, projected, _ := cluster.Bivariate.GetXY(coord, map[string]interface{}{
+				"lon": 90.0,
+				"lat": 42.5,
+			})
+			Expect(projected).NotTo(BeNumerically("~", expectedY, 1))
+		})
+
+		It("should average the raw values under linear projections", func() {
+			err := cluster.Parse(JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"projection": "linear",
+					"left": 0,
+					"right": 256,
+					"bottom": 0,
+					"top": 256
+				}`))
+			Expect(err).To(BeNil())
+			coord := &binning.TileCoord{Z: 0, X: 0, Y: 0}
+			x, y := cluster.getCentroidAggs(coord)
+			Expect(source(x)).To(Equal(map[string]interface{}{
+				"avg": map[string]interface{}{"field": "x"},
+			}))
+			Expect(source(y)).To(Equal(map[string]interface{}{
+				"avg": map[string]interface{}{"field": "y"},
+			}))
+			centroidX, centroidY := cluster.getCentroid(coord, 10, 20)
+			Expect(centroidX).To(Equal(10.0))
+			Expect(centroidY).To(Equal(20.0))
+		})
+	})
+})
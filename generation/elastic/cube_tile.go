@@ -0,0 +1,136 @@
+package elastic
+
+import (
+	"fmt"
+
+	"gopkg.in/olivere/elastic.v3"
+
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+// CubeTile represents an elasticsearch implementation of the cube tile.
+type CubeTile struct {
+	Elastic
+	Bivariate
+	Frequency
+	tile.Cube
+}
+
+// NewCubeTile instantiates and returns a new tile struct.
+func NewCubeTile(host, port string) veldt.TileCtor {
+	return func() (veldt.Tile, error) {
+		c := &CubeTile{}
+		c.Host = host
+		c.Port = port
+		return c, nil
+	}
+}
+
+// Parse parses the provided JSON object and populates the tiles attributes.
+func (c *CubeTile) Parse(params map[string]interface{}) error {
+	err := c.Bivariate.Parse(params)
+	if err != nil {
+		return err
+	}
+	return c.Frequency.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (c *CubeTile) Params() []json.Param {
+	return json.MergeParams(
+		c.Bivariate.Params(),
+		c.Frequency.Params())
+}
+
+// Create generates a tile from the provided URI, tile coordinate and query
+// parameters.
+func (c *CubeTile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
+	// create search service
+	search, err := c.CreateSearchService(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	// create root query
+	q, err := c.CreateQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	// add tiling query
+	q.Must(c.Bivariate.GetQuery(coord))
+	// add frequency query
+	q.Must(c.Frequency.GetQuery())
+	// set the query
+	search.Query(q)
+
+	// the top level frequency defines every time bucket of the range, while
+	// the frequency nested within each bin only returns the non-empty buckets
+	search.Aggregation("frequency", c.Frequency.GetAggs()["frequency"])
+	nested := elastic.NewDateHistogramAggregation().
+		Field(c.Frequency.FrequencyField).
+		Interval(c.Frequency.Interval).
+		MinDocCount(1)
+	if c.Frequency.GTE != nil {
+		nested.Offset(castTimeToString(c.Frequency.GTE))
+	}
+	if c.Frequency.GT != nil {
+		nested.Offset(castTimeToString(c.Frequency.GT))
+	}
+	aggs := c.Bivariate.GetAggsWithNested(coord, "frequency", nested)
+	search.Aggregation("x", aggs["x"])
+
+	// send query
+	res, err := search.Do()
+	if err != nil {
+		return nil, err
+	}
+
+	// get the time buckets
+	frequency, err := c.Frequency.GetBuckets(&res.Aggregations)
+	if err != nil {
+		return nil, err
+	}
+	timestamps := make([]float64, len(frequency))
+	times := make(map[int64]int, len(frequency))
+	for i, bucket := range frequency {
+		timestamps[i] = float64(bucket.Key)
+		times[bucket.Key] = i
+	}
+
+	// get bins
+	bins, err := c.Bivariate.GetBins(coord, &res.Aggregations)
+	if err != nil {
+		return nil, err
+	}
+
+	// get the time buckets of each bin
+	resolution := c.Bivariate.Resolution
+	var cube []*tile.CubeBin
+	for i, bin := range bins {
+		if bin == nil {
+			continue
+		}
+		buckets, ok := bin.DateHistogram("frequency")
+		if !ok {
+			return nil, fmt.Errorf("date histogram aggregation `frequency` was not found")
+		}
+		for _, bucket := range buckets.Buckets {
+			time, ok := times[bucket.Key]
+			if !ok {
+				continue
+			}
+			cube = append(cube, &tile.CubeBin{
+				X:     i % resolution,
+				Y:     i / resolution,
+				Time:  time,
+				Count: float64(bucket.DocCount),
+			})
+		}
+	}
+
+	// encode the result
+	return c.Cube.Encode(resolution, timestamps, cube)
+}
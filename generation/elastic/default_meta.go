@@ -31,6 +31,12 @@ func (m *DefaultMeta) Parse(params map[string]interface{}) error {
 	return nil
 }
 
+// Params returns the parameters accepted by the meta data generator. There are
+// currently none.
+func (m *DefaultMeta) Params() []json.Param {
+	return []json.Param{}
+}
+
 // Create generates metadata from the provided URI.
 func (m *DefaultMeta) Create(uri string) ([]byte, error) {
 	// get the raw mappings
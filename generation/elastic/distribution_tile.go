@@ -0,0 +1,158 @@
+package elastic
+
+import (
+	"fmt"
+	"strconv"
+
+	"gopkg.in/olivere/elastic.v3"
+
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+const (
+	// distributionBucket computes the histogram bucket of a value
+	distributionBucket = "floor((doc['%s'].value - origin) / interval)"
+)
+
+// DistributionTile represents an elasticsearch implementation of the
+// distribution tile.
+type DistributionTile struct {
+	Elastic
+	Bivariate
+	tile.Distribution
+}
+
+// NewDistributionTile instantiates and returns a new tile struct.
+func NewDistributionTile(host, port string) veldt.TileCtor {
+	return func() (veldt.Tile, error) {
+		d := &DistributionTile{}
+		d.Host = host
+		d.Port = port
+		return d, nil
+	}
+}
+
+// Parse parses the provided JSON object and populates the tiles attributes.
+func (d *DistributionTile) Parse(params map[string]interface{}) error {
+	err := d.Bivariate.Parse(params)
+	if err != nil {
+		return err
+	}
+	return d.Distribution.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (d *DistributionTile) Params() []json.Param {
+	return json.MergeParams(
+		d.Bivariate.Params(),
+		d.Distribution.Params())
+}
+
+// Create generates a tile from the provided URI, tile coordinate and query
+// parameters.
+func (d *DistributionTile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
+	// create search service
+	search, err := d.CreateSearchService(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	// create root query
+	q, err := d.CreateQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	// add tiling query
+	q.Must(d.Bivariate.GetQuery(coord))
+	// add the bounds of the histogram
+	if d.Distribution.Bounded {
+		q.Must(elastic.NewRangeQuery(d.Distribution.ValueField).
+			Gte(d.Distribution.Min).
+			Lte(d.Distribution.Max))
+	} else {
+		// the script buckets documents without a value as zero
+		q.Must(elastic.NewExistsQuery(d.Distribution.ValueField))
+	}
+	// set the query
+	search.Query(q)
+
+	// set the aggregations. Histogram intervals are integers, so each value
+	// is bucketed by a script to preserve the precision of small intervals
+	histogram := elastic.NewHistogramAggregation().
+		Script(elastic.NewScriptInline(fmt.Sprintf(distributionBucket, d.Distribution.ValueField)).
+			Lang("expression").
+			Param("origin", d.Distribution.Origin()).
+			Param("interval", d.Distribution.Interval)).
+		Interval(1).
+		MinDocCount(1)
+	search.Aggregation("histogram", histogram)
+	if len(d.Distribution.Percentiles) > 0 {
+		percentiles := elastic.NewPercentilesAggregation().
+			Field(d.Distribution.ValueField).
+			Percentiles(d.Distribution.Percentiles...)
+		search.Aggregation("percentiles", percentiles)
+	}
+
+	// send query
+	res, err := search.Do()
+	if err != nil {
+		return nil, err
+	}
+
+	// get buckets
+	histogramAgg, ok := res.Aggregations.Histogram("histogram")
+	if !ok {
+		return nil, fmt.Errorf("histogram aggregation `histogram` was not found")
+	}
+	counts := make(map[int64]uint64, len(histogramAgg.Buckets))
+	for _, bucket := range histogramAgg.Buckets {
+		counts[bucket.Key] += uint64(bucket.DocCount)
+	}
+	buckets, err := d.Distribution.CreateBuckets(counts)
+	if err != nil {
+		return nil, err
+	}
+
+	// get percentiles, the percentiles of an empty tile are undefined
+	var values []float64
+	if len(d.Distribution.Percentiles) > 0 && len(counts) > 0 {
+		values, err = d.getPercentiles(&res.Aggregations)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// encode the result
+	return d.Distribution.Encode(buckets, values)
+}
+
+// getPercentiles returns the values of the percentiles in the order they were
+// requested.
+func (d *DistributionTile) getPercentiles(aggs *elastic.Aggregations) ([]float64, error) {
+	percentilesAgg, ok := aggs.Percentiles("percentiles")
+	if !ok {
+		return nil, fmt.Errorf("percentiles aggregation `percentiles` was not found")
+	}
+	// the percentiles are keyed by their formatted value, so parse them
+	// rather than assume the formatting
+	results := make(map[float64]float64, len(percentilesAgg.Values))
+	for key, value := range percentilesAgg.Values {
+		percentile, err := strconv.ParseFloat(key, 64)
+		if err != nil {
+			return nil, fmt.Errorf("percentile `%s` is not numeric", key)
+		}
+		results[percentile] = value
+	}
+	values := make([]float64, len(d.Distribution.Percentiles))
+	for i, percentile := range d.Distribution.Percentiles {
+		value, ok := results[percentile]
+		if !ok {
+			return nil, fmt.Errorf("percentile `%v` was not found", percentile)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
@@ -1,6 +1,8 @@
 package elastic
 
 import (
+	"fmt"
+
 	"gopkg.in/olivere/elastic.v3"
 
 	"github.com/unchartedsoftware/veldt/binning"
@@ -22,20 +24,78 @@ func (e *Edge) GetQuery(coord *binning.TileCoord) elastic.Query {
 	// Require at least 1 of the points, possibly both.
 	if e.Edge.RequireSrc || !e.Edge.RequireDst {
 		query.Must(elastic.NewRangeQuery(e.Edge.SrcXField).
-			Gte(int64(bounds.MinX())).
-			Lt(int64(bounds.MaxX())))
+			Gte(bounds.MinX()).
+			Lt(bounds.MaxX()))
 		query.Must(elastic.NewRangeQuery(e.Edge.SrcYField).
-			Gte(int64(bounds.MinY())).
-			Lt(int64(bounds.MaxY())))
+			Gte(bounds.MinY()).
+			Lt(bounds.MaxY()))
 	}
 	if e.Edge.RequireDst {
 		query.Must(elastic.NewRangeQuery(e.Edge.DstXField).
-			Gte(int64(bounds.MinX())).
-			Lt(int64(bounds.MaxX())))
+			Gte(bounds.MinX()).
+			Lt(bounds.MaxX()))
 		query.Must(elastic.NewRangeQuery(e.Edge.DstYField).
-			Gte(int64(bounds.MinY())).
-			Lt(int64(bounds.MaxY())))
+			Gte(bounds.MinY()).
+			Lt(bounds.MaxY()))
 	}
 
 	return query
 }
+
+// GetFlowAggs returns the aggregations grouping edges by their source and
+// destination bins at the provided resolution and summing their weights. The
+// histograms are nested from the source x bin to the destination y bin.
+func (e *Edge) GetFlowAggs(coord *binning.TileCoord, resolution int) elastic.Aggregation {
+	bounds := e.TileBounds(coord)
+	srcX, srcY := getHistograms(coord, e.Projection, bounds, e.SrcXField, e.SrcYField, resolution)
+	dstX, dstY := getHistograms(coord, e.Projection, bounds, e.DstXField, e.DstYField, resolution)
+	dstY.SubAggregation("weight", elastic.NewSumAggregation().Field(e.WeightField))
+	dstX.SubAggregation("dstY", dstY)
+	srcY.SubAggregation("dstX", dstX)
+	srcX.SubAggregation("srcY", srcY)
+	return srcX
+}
+
+// GetFlows parses the resulting histograms into flows.
+func (e *Edge) GetFlows(aggs *elastic.Aggregations) ([]*tile.Flow, error) {
+	srcXAgg, ok := aggs.Histogram("srcX")
+	if !ok {
+		return nil, fmt.Errorf("histogram aggregation `srcX` was not found")
+	}
+	var flows []*tile.Flow
+	for _, srcXBucket := range srcXAgg.Buckets {
+		srcYAgg, ok := srcXBucket.Histogram("srcY")
+		if !ok {
+			return nil, fmt.Errorf("histogram aggregation `srcY` was not found")
+		}
+		for _, srcYBucket := range srcYAgg.Buckets {
+			dstXAgg, ok := srcYBucket.Histogram("dstX")
+			if !ok {
+				return nil, fmt.Errorf("histogram aggregation `dstX` was not found")
+			}
+			for _, dstXBucket := range dstXAgg.Buckets {
+				dstYAgg, ok := dstXBucket.Histogram("dstY")
+				if !ok {
+					return nil, fmt.Errorf("histogram aggregation `dstY` was not found")
+				}
+				for _, dstYBucket := range dstYAgg.Buckets {
+					weight, ok := dstYBucket.Sum("weight")
+					if !ok {
+						return nil, fmt.Errorf("sum aggregation `weight` was not found")
+					}
+					if weight.Value == nil {
+						continue
+					}
+					flows = append(flows, &tile.Flow{
+						SrcX:   int(srcXBucket.Key),
+						SrcY:   int(srcYBucket.Key),
+						DstX:   int(dstXBucket.Key),
+						DstY:   int(dstYBucket.Key),
+						Weight: *weight.Value,
+					})
+				}
+			}
+		}
+	}
+	return flows, nil
+}
@@ -83,7 +83,9 @@ func castTimeToString(val interface{}) string {
 func castTime(val interface{}) interface{} {
 	num, isNum := val.(float64)
 	if isNum {
-		return int64(num)
+		// whole numbers are encoded without a fraction, so epoch millis are
+		// unaffected while numeric fields keep their precision
+		return num
 	}
 	str, isStr := val.(string)
 	if isStr {
@@ -3,6 +3,7 @@ package elastic
 import (
 	"github.com/unchartedsoftware/veldt"
 	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
 	"github.com/unchartedsoftware/veldt/util/json"
 )
 
@@ -12,6 +13,7 @@ type FrequencyTile struct {
 	Elastic
 	Bivariate
 	Frequency
+	tile.Tabular
 }
 
 // NewFrequencyTile instantiates and returns a new tile struct.
@@ -30,7 +32,19 @@ func (t *FrequencyTile) Parse(params map[string]interface{}) error {
 	if err != nil {
 		return err
 	}
-	return t.Frequency.Parse(params)
+	err = t.Frequency.Parse(params)
+	if err != nil {
+		return err
+	}
+	return t.Tabular.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (t *FrequencyTile) Params() []json.Param {
+	return json.MergeParams(
+		t.Bivariate.Params(),
+		t.Frequency.Params(),
+		t.Tabular.Params())
 }
 
 // Create generates a tile from the provided URI, tile coordinate and query
@@ -72,12 +86,14 @@ func (t *FrequencyTile) Create(uri string, coord *binning.TileCoord, query veldt
 	}
 
 	buckets := make([]map[string]interface{}, len(frequency))
+	table := tile.NewTable("timestamp", "count")
 	for i, bucket := range frequency {
 		buckets[i] = map[string]interface{}{
 			"timestamp": bucket.Key,
 			"count":     bucket.DocCount,
 		}
+		table.AddRow(buckets[i])
 	}
 	// marshal results
-	return json.Marshal(buckets)
+	return t.Tabular.Encode(table, buckets)
 }
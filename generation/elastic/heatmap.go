@@ -0,0 +1,65 @@
+package elastic
+
+import (
+	"fmt"
+
+	"gopkg.in/olivere/elastic.v3"
+
+	"github.com/unchartedsoftware/veldt/tile"
+)
+
+// Heatmap represents an elasticsearch implementation of the heatmap tile.
+type Heatmap struct {
+	tile.Heatmap
+}
+
+// GetAggs returns the metric aggregation nested within each bin, or nil if the
+// bins are counted.
+func (h *Heatmap) GetAggs() map[string]elastic.Aggregation {
+	var agg elastic.Aggregation
+	switch h.Aggregation {
+	case tile.SumAggregation:
+		agg = elastic.NewSumAggregation().Field(h.ValueField)
+	case tile.AvgAggregation:
+		agg = elastic.NewAvgAggregation().Field(h.ValueField)
+	case tile.MinAggregation:
+		agg = elastic.NewMinAggregation().Field(h.ValueField)
+	case tile.MaxAggregation:
+		agg = elastic.NewMaxAggregation().Field(h.ValueField)
+	case tile.CardinalityAggregation:
+		agg = elastic.NewCardinalityAggregation().Field(h.ValueField)
+	default:
+		return nil
+	}
+	return map[string]elastic.Aggregation{
+		"value": agg,
+	}
+}
+
+// GetValue returns the aggregated value of the bin.
+func (h *Heatmap) GetValue(bin *elastic.AggregationBucketHistogramItem) (float64, error) {
+	var metric *elastic.AggregationValueMetric
+	var ok bool
+	switch h.Aggregation {
+	case tile.SumAggregation:
+		metric, ok = bin.Sum("value")
+	case tile.AvgAggregation:
+		metric, ok = bin.Avg("value")
+	case tile.MinAggregation:
+		metric, ok = bin.Min("value")
+	case tile.MaxAggregation:
+		metric, ok = bin.Max("value")
+	case tile.CardinalityAggregation:
+		metric, ok = bin.Cardinality("value")
+	default:
+		return float64(bin.DocCount), nil
+	}
+	if !ok {
+		return 0, fmt.Errorf("%s aggregation `value` was not found", h.Aggregation)
+	}
+	if metric.Value == nil {
+		// bins without any values for the field
+		return 0, nil
+	}
+	return *metric.Value, nil
+}
@@ -1,16 +1,16 @@
 package elastic
 
 import (
-	"encoding/binary"
-
 	"github.com/unchartedsoftware/veldt"
 	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/util/json"
 )
 
 // HeatmapTile represents an elasticsearch implementation of the heatmap tile.
 type HeatmapTile struct {
 	Elastic
 	Bivariate
+	Heatmap
 }
 
 // NewHeatmapTile instantiates and returns a new tile struct.
@@ -25,7 +25,18 @@ func NewHeatmapTile(host, port string) veldt.TileCtor {
 
 // Parse parses the provided JSON object and populates the tiles attributes.
 func (h *HeatmapTile) Parse(params map[string]interface{}) error {
-	return h.Bivariate.Parse(params)
+	err := h.Bivariate.Parse(params)
+	if err != nil {
+		return err
+	}
+	return h.Heatmap.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (h *HeatmapTile) Params() []json.Param {
+	return json.MergeParams(
+		h.Bivariate.Params(),
+		h.Heatmap.Params())
 }
 
 // Create generates a tile from the provided URI, tile coordinate and query
@@ -48,7 +59,8 @@ func (h *HeatmapTile) Create(uri string, coord *binning.TileCoord, query veldt.Q
 	search.Query(q)
 
 	// get aggs
-	aggs := h.Bivariate.GetAggs(coord)
+	valueAggs := h.Heatmap.GetAggs()
+	aggs := h.Bivariate.GetAggsWithNested(coord, "value", valueAggs["value"])
 	// set the aggregation
 	search.Aggregation("x", aggs["x"])
 
@@ -64,14 +76,18 @@ func (h *HeatmapTile) Create(uri string, coord *binning.TileCoord, query veldt.Q
 		return nil, err
 	}
 
-	// convert to byte array
-	bits := make([]byte, len(bins)*4)
+	// aggregate the bin values
+	values := make([]float32, len(bins))
 	for i, bin := range bins {
 		if bin != nil {
-			binary.LittleEndian.PutUint32(
-				bits[i*4:i*4+4],
-				uint32(bin.DocCount))
+			value, err := h.Heatmap.GetValue(bin)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = float32(value)
 		}
 	}
-	return bits, nil
+
+	// encode the result
+	return h.Heatmap.Encode(values)
 }
@@ -0,0 +1,136 @@
+package elastic
+
+import (
+	"fmt"
+	"math"
+
+	"gopkg.in/olivere/elastic.v3"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+)
+
+const (
+	// linearPixel computes the global pixel of a value within the tile bounds
+	linearPixel = "((tile%[2]s + (doc['%[1]s'].value - min%[2]s) / range%[2]s) * 256)"
+	// mercatorPixel computes the global pixel of a latitude
+	mercatorPixel = "((1 + ln(tan(doc['%[1]s'].value * pi / 180) + 1 / cos(doc['%[1]s'].value * pi / 180)) / pi) / 2 * pow2 * 256)"
+	// fractionalHex computes a fractional axial coordinate from the pixel
+	fractionalHex = "(%[3]s * %[1]s + %[4]s * %[2]s)"
+)
+
+// Hexbin represents an elasticsearch implementation of the hexbin tile.
+type Hexbin struct {
+	tile.Hexbin
+}
+
+// GetQuery returns the tiling query.
+func (h *Hexbin) GetQuery(coord *binning.TileCoord) elastic.Query {
+	// include the cells straddling the tile edges
+	bounds := h.QueryBounds(coord)
+	// create the range queries
+	query := elastic.NewBoolQuery()
+	query.Must(elastic.NewRangeQuery(h.XField).
+		Gte(bounds.MinX()).
+		Lt(bounds.MaxX()))
+	query.Must(elastic.NewRangeQuery(h.YField).
+		Gte(bounds.MinY()).
+		Lt(bounds.MaxY()))
+	return query
+}
+
+// GetAggs returns the tiling aggregation, which buckets each document by the
+// q and then r axial coordinate of its cell.
+func (h *Hexbin) GetAggs(coord *binning.TileCoord) map[string]elastic.Aggregation {
+	q, r := h.getHexScripts()
+	params := h.getScriptParams(coord)
+	x := elastic.NewHistogramAggregation().
+		Script(elastic.NewScriptInline(q).Lang("expression").Params(params)).
+		Interval(1).
+		MinDocCount(1)
+	y := elastic.NewHistogramAggregation().
+		Script(elastic.NewScriptInline(r).Lang("expression").Params(params)).
+		Interval(1).
+		MinDocCount(1)
+	x.SubAggregation("r", y)
+	return map[string]elastic.Aggregation{
+		"q": x,
+		"r": y,
+	}
+}
+
+// GetCells parses the resulting histograms into the cells of the tile.
+func (h *Hexbin) GetCells(coord *binning.TileCoord, aggs *elastic.Aggregations) ([]*tile.HexCell, error) {
+	qAgg, ok := aggs.Histogram("q")
+	if !ok {
+		return nil, fmt.Errorf("histogram aggregation `q` was not found")
+	}
+	var cells []*tile.HexCell
+	for _, qBucket := range qAgg.Buckets {
+		rAgg, ok := qBucket.Histogram("r")
+		if !ok {
+			return nil, fmt.Errorf("histogram aggregation `r` was not found")
+		}
+		for _, rBucket := range rAgg.Buckets {
+			hex := binning.HexCoord{
+				Q: qBucket.Key,
+				R: rBucket.Key,
+			}
+			// cells belong to the tile containing their center
+			if !h.Contains(coord, &hex) {
+				continue
+			}
+			cells = append(cells, &tile.HexCell{
+				HexCoord: hex,
+				Count:    float64(rBucket.DocCount),
+			})
+		}
+	}
+	return cells, nil
+}
+
+func (h *Hexbin) getHexScripts() (string, string) {
+	// pixel coordinates
+	x := fmt.Sprintf(linearPixel, h.XField, "X")
+	y := fmt.Sprintf(linearPixel, h.YField, "Y")
+	if _, ok := h.Projection.(*binning.Mercator); ok {
+		y = fmt.Sprintf(mercatorPixel, h.YField)
+	}
+	// fractional axial coordinates
+	fq := fmt.Sprintf(fractionalHex, x, y, "a", "b")
+	fr := fmt.Sprintf(fractionalHex, x, y, "c", "d")
+	fs := fmt.Sprintf("(-%s - %s)", fq, fr)
+	// rounded cube coordinates
+	rq := fmt.Sprintf("floor(%s + 0.5)", fq)
+	rr := fmt.Sprintf("floor(%s + 0.5)", fr)
+	rs := fmt.Sprintf("floor(%s + 0.5)", fs)
+	// rounding errors
+	dq := fmt.Sprintf("abs(%s - %s)", rq, fq)
+	dr := fmt.Sprintf("abs(%s - %s)", rr, fr)
+	ds := fmt.Sprintf("abs(%s - %s)", rs, fs)
+	// reset the component with the largest rounding error
+	resetQ := fmt.Sprintf("(%[1]s > %[2]s && %[1]s > %[3]s)", dq, dr, ds)
+	resetR := fmt.Sprintf("(%s > %s)", dr, ds)
+	q := fmt.Sprintf("%s ? (-%s - %s) : %s", resetQ, rr, rs, rq)
+	r := fmt.Sprintf("%s ? %s : (%s ? (-%s - %s) : %s)", resetQ, rr, resetR, rq, rs, rr)
+	return q, r
+}
+
+func (h *Hexbin) getScriptParams(coord *binning.TileCoord) map[string]interface{} {
+	bounds := h.TileBounds(coord)
+	a, b, c, d := h.Grid.Basis()
+	return map[string]interface{}{
+		"tileX":  float64(coord.X),
+		"tileY":  float64(coord.Y),
+		"minX":   bounds.Left,
+		"minY":   bounds.Bottom,
+		"rangeX": bounds.Right - bounds.Left,
+		"rangeY": bounds.Top - bounds.Bottom,
+		"pow2":   math.Pow(2, float64(coord.Z)),
+		"pi":     math.Pi,
+		"a":      a,
+		"b":      b,
+		"c":      c,
+		"d":      d,
+	}
+}
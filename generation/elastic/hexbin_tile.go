@@ -0,0 +1,67 @@
+package elastic
+
+import (
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/binning"
+)
+
+// HexbinTile represents an elasticsearch implementation of the hexbin tile.
+type HexbinTile struct {
+	Elastic
+	Hexbin
+}
+
+// NewHexbinTile instantiates and returns a new tile struct.
+func NewHexbinTile(host, port string) veldt.TileCtor {
+	return func() (veldt.Tile, error) {
+		h := &HexbinTile{}
+		h.Host = host
+		h.Port = port
+		return h, nil
+	}
+}
+
+// Parse parses the provided JSON object and populates the tiles attributes.
+func (h *HexbinTile) Parse(params map[string]interface{}) error {
+	return h.Hexbin.Parse(params)
+}
+
+// Create generates a tile from the provided URI, tile coordinate and query
+// parameters.
+func (h *HexbinTile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
+	// create search service
+	search, err := h.CreateSearchService(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	// create root query
+	q, err := h.CreateQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	// add tiling query
+	q.Must(h.Hexbin.GetQuery(coord))
+	// set the query
+	search.Query(q)
+
+	// get aggs
+	aggs := h.Hexbin.GetAggs(coord)
+	// set the aggregation
+	search.Aggregation("q", aggs["q"])
+
+	// send query
+	res, err := search.Do()
+	if err != nil {
+		return nil, err
+	}
+
+	// get cells
+	cells, err := h.Hexbin.GetCells(coord, &res.Aggregations)
+	if err != nil {
+		return nil, err
+	}
+
+	// encode the result
+	return h.Hexbin.Encode(cells), nil
+}
@@ -0,0 +1,101 @@
+package elastic
+
+import (
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+// KDETile represents an elasticsearch implementation of the kernel density
+// estimation tile.
+type KDETile struct {
+	Elastic
+	Bivariate
+	tile.KDE
+}
+
+// NewKDETile instantiates and returns a new tile struct.
+func NewKDETile(host, port string) veldt.TileCtor {
+	return func() (veldt.Tile, error) {
+		k := &KDETile{}
+		k.Host = host
+		k.Port = port
+		return k, nil
+	}
+}
+
+// Parse parses the provided JSON object and populates the tiles attributes.
+func (k *KDETile) Parse(params map[string]interface{}) error {
+	err := k.Bivariate.Parse(params)
+	if err != nil {
+		return err
+	}
+	return k.KDE.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (k *KDETile) Params() []json.Param {
+	return json.MergeParams(
+		k.Bivariate.Params(),
+		k.KDE.Params())
+}
+
+// Create generates a tile from the provided URI, tile coordinate and query
+// parameters.
+func (k *KDETile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
+	// pad the bins by the support of the kernel
+	resolution := k.Bivariate.Resolution
+	bandwidthX, bandwidthY := k.KDE.BandwidthBins(
+		resolution,
+		k.Bivariate.BinSizeX(coord),
+		k.Bivariate.BinSizeY(coord))
+	k.Bivariate.Padding = k.KDE.Padding(resolution, bandwidthX, bandwidthY)
+
+	// create search service
+	search, err := k.CreateSearchService(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	// create root query
+	q, err := k.CreateQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	// add tiling query
+	q.Must(k.Bivariate.GetQuery(coord))
+	// set the query
+	search.Query(q)
+
+	// get aggs
+	aggs := k.Bivariate.GetAggs(coord)
+	// set the aggregation
+	search.Aggregation("x", aggs["x"])
+
+	// send query
+	res, err := search.Do()
+	if err != nil {
+		return nil, err
+	}
+
+	// get bins
+	bins, err := k.Bivariate.GetBins(coord, &res.Aggregations)
+	if err != nil {
+		return nil, err
+	}
+
+	// count the points in each bin
+	counts := make([]float64, len(bins))
+	for i, bin := range bins {
+		if bin != nil {
+			counts[i] = float64(bin.DocCount)
+		}
+	}
+
+	// convolve the counts
+	densities := k.KDE.Convolve(counts, resolution, k.Bivariate.Padding, bandwidthX, bandwidthY)
+
+	// encode the result
+	return k.KDE.Encode(densities)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/unchartedsoftware/veldt"
 	"github.com/unchartedsoftware/veldt/binning"
 	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
 )
 
 // MacroEdgeTile represents an elasticsearch implementation of the Edge tile.
@@ -47,6 +48,14 @@ func (e *MacroEdgeTile) Parse(params map[string]interface{}) error {
 	return e.MacroEdge.Parse(params)
 }
 
+// Params returns the parameters accepted by the tile.
+func (e *MacroEdgeTile) Params() []json.Param {
+	return json.MergeParams(
+		e.Edge.Params(),
+		e.TopHits.Params(),
+		e.MacroEdge.Params())
+}
+
 // Create generates a tile from the provided URI, tile coordinate and query
 // parameters.
 func (e *MacroEdgeTile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
@@ -110,5 +119,5 @@ func (e *MacroEdgeTile) Create(uri string, coord *binning.TileCoord, query veldt
 	}
 
 	// encode and return results
-	return e.MacroEdge.Encode(points)
+	return e.MacroEdge.Encode(points, e.Edge.Unprojector(coord))
 }
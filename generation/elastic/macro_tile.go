@@ -6,6 +6,7 @@ import (
 	"github.com/unchartedsoftware/veldt"
 	"github.com/unchartedsoftware/veldt/binning"
 	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
 )
 
 // MacroTile represents an elasticsearch implementation of the macro tile.
@@ -34,6 +35,13 @@ func (m *MacroTile) Parse(params map[string]interface{}) error {
 	return m.Macro.Parse(params)
 }
 
+// Params returns the parameters accepted by the tile.
+func (m *MacroTile) Params() []json.Param {
+	return json.MergeParams(
+		m.Bivariate.Params(),
+		m.Macro.Params())
+}
+
 // Create generates a tile from the provided URI, tile coordinate and query
 // parameters.
 func (m *MacroTile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
@@ -70,8 +78,8 @@ func (m *MacroTile) Create(uri string, coord *binning.TileCoord, query veldt.Que
 		return nil, err
 	}
 
-	// bin width
-	binSize := binning.MaxTileResolution / float64(m.Resolution)
+	// bin width, in the pixel space of the tile
+	binSize := float64(m.Macro.Resolution) / float64(m.Bivariate.Resolution)
 	halfSize := float64(binSize / 2)
 
 	// convert to point array
@@ -79,8 +87,8 @@ func (m *MacroTile) Create(uri string, coord *binning.TileCoord, query veldt.Que
 	numPoints := 0
 	for i, bin := range bins {
 		if bin != nil {
-			x := float32(float64(i%m.Resolution)*binSize + halfSize)
-			y := float32(math.Floor(float64(i/m.Resolution))*binSize + halfSize)
+			x := float32(float64(i%m.Bivariate.Resolution)*binSize + halfSize)
+			y := float32(math.Floor(float64(i/m.Bivariate.Resolution))*binSize + halfSize)
 			points[numPoints*2] = x
 			points[numPoints*2+1] = y
 			numPoints++
@@ -88,5 +96,5 @@ func (m *MacroTile) Create(uri string, coord *binning.TileCoord, query veldt.Que
 	}
 
 	// encode the result
-	return m.Macro.Encode(points[0 : numPoints*2])
+	return m.Macro.Encode(points[0 : numPoints*2], m.Bivariate.Unprojector(coord))
 }
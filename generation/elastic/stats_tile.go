@@ -0,0 +1,115 @@
+package elastic
+
+import (
+	"fmt"
+
+	"gopkg.in/olivere/elastic.v3"
+
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+// StatsTile represents an elasticsearch implementation of the stats tile.
+type StatsTile struct {
+	Elastic
+	Bivariate
+	tile.Stats
+}
+
+// NewStatsTile instantiates and returns a new tile struct.
+func NewStatsTile(host, port string) veldt.TileCtor {
+	return func() (veldt.Tile, error) {
+		s := &StatsTile{}
+		s.Host = host
+		s.Port = port
+		return s, nil
+	}
+}
+
+// Parse parses the provided JSON object and populates the tiles attributes.
+func (s *StatsTile) Parse(params map[string]interface{}) error {
+	err := s.Bivariate.Parse(params)
+	if err != nil {
+		return err
+	}
+	return s.Stats.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (s *StatsTile) Params() []json.Param {
+	return json.MergeParams(
+		s.Bivariate.Params(),
+		s.Stats.Params())
+}
+
+// Create generates a tile from the provided URI, tile coordinate and query
+// parameters.
+func (s *StatsTile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
+	// create search service
+	search, err := s.CreateSearchService(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	// create root query
+	q, err := s.CreateQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	// add tiling query
+	q.Must(s.Bivariate.GetQuery(coord))
+	// set the query
+	search.Query(q)
+
+	// get aggs, with the extended stats of each bin
+	stats := elastic.NewExtendedStatsAggregation().Field(s.Stats.ValueField)
+	aggs := s.Bivariate.GetAggsWithNested(coord, "stats", stats)
+	// set the aggregation
+	search.Aggregation("x", aggs["x"])
+
+	// send query
+	res, err := search.Do()
+	if err != nil {
+		return nil, err
+	}
+
+	// get bins
+	bins, err := s.Bivariate.GetBins(coord, &res.Aggregations)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert the extended stats of each bin
+	values := make([]*tile.BinStats, len(bins))
+	for i, bin := range bins {
+		if bin == nil {
+			continue
+		}
+		metric, ok := bin.ExtendedStats("stats")
+		if !ok {
+			return nil, fmt.Errorf("extended stats aggregation `stats` was not found")
+		}
+		values[i] = &tile.BinStats{
+			Count:  uint64(metric.Count),
+			Sum:    getValue(metric.Sum),
+			Mean:   getValue(metric.Avg),
+			Min:    getValue(metric.Min),
+			Max:    getValue(metric.Max),
+			StdDev: getValue(metric.StdDeviation),
+		}
+	}
+
+	// encode the result
+	return s.Stats.Encode(values)
+}
+
+// getValue returns the value of a metric, or zero for bins without any values
+// for the field, whose statistics are discarded when encoded.
+func getValue(value *float64) float64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
@@ -3,6 +3,7 @@ package elastic
 import (
 	"github.com/unchartedsoftware/veldt"
 	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
 	"github.com/unchartedsoftware/veldt/util/json"
 )
 
@@ -12,6 +13,7 @@ type TargetTermCountTile struct {
 	Elastic
 	Bivariate
 	TargetTerms
+	tile.Tabular
 }
 
 // NewTargetTermCountTile instantiates and returns a new tile struct.
@@ -30,7 +32,19 @@ func (t *TargetTermCountTile) Parse(params map[string]interface{}) error {
 	if err != nil {
 		return err
 	}
-	return t.TargetTerms.Parse(params)
+	err = t.TargetTerms.Parse(params)
+	if err != nil {
+		return err
+	}
+	return t.Tabular.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (t *TargetTermCountTile) Params() []json.Param {
+	return json.MergeParams(
+		t.Bivariate.Params(),
+		t.TargetTerms.Params(),
+		t.Tabular.Params())
 }
 
 // Create generates a tile from the provided URI, tile coordinate and query
@@ -69,9 +83,15 @@ func (t *TargetTermCountTile) Create(uri string, coord *binning.TileCoord, query
 	}
 	// encode
 	counts := make(map[string]uint32)
+	table := tile.NewTable("term", "count")
 	for term, bucket := range terms {
 		counts[term] = uint32(bucket.DocCount)
+		table.AddRow(map[string]interface{}{
+			"term":  term,
+			"count": counts[term],
+		})
 	}
+	table.SortBy("term")
 	// marshal results
-	return json.Marshal(counts)
+	return t.Tabular.Encode(table, counts)
 }
@@ -3,6 +3,7 @@ package elastic
 import (
 	"github.com/unchartedsoftware/veldt"
 	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
 	"github.com/unchartedsoftware/veldt/util/json"
 )
 
@@ -13,6 +14,7 @@ type TargetTermFrequencyTile struct {
 	Bivariate
 	TargetTerms
 	Frequency
+	tile.Tabular
 }
 
 // NewTargetTermFrequencyTile instantiates and returns a new tile struct.
@@ -31,7 +33,19 @@ func (t *TargetTermFrequencyTile) Parse(params map[string]interface{}) error {
 	if err != nil {
 		return err
 	}
-	return t.TargetTerms.Parse(params)
+	err = t.TargetTerms.Parse(params)
+	if err != nil {
+		return err
+	}
+	return t.Tabular.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (t *TargetTermFrequencyTile) Params() []json.Param {
+	return json.MergeParams(
+		t.Bivariate.Params(),
+		t.TargetTerms.Params(),
+		t.Tabular.Params())
 }
 
 // Create generates a tile from the provided URI, tile coordinate and query
@@ -71,6 +85,7 @@ func (t *TargetTermFrequencyTile) Create(uri string, coord *binning.TileCoord, q
 	}
 	// encode
 	result := make(map[string][]map[string]interface{})
+	table := tile.NewTable("term", "timestamp", "count")
 	for term, item := range terms {
 		// get buckets
 		buckets, err := t.Frequency.GetBuckets(&item.Aggregations)
@@ -84,9 +99,15 @@ func (t *TargetTermFrequencyTile) Create(uri string, coord *binning.TileCoord, q
 				"timestamp": bucket.Key,
 				"count":     bucket.DocCount,
 			}
+			table.AddRow(map[string]interface{}{
+				"term":      term,
+				"timestamp": bucket.Key,
+				"count":     bucket.DocCount,
+			})
 		}
 		result[term] = frequency
 	}
+	table.SortBy("term")
 	// marshal results
-	return json.Marshal(result)
+	return t.Tabular.Encode(table, result)
 }
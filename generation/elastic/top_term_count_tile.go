@@ -3,6 +3,7 @@ package elastic
 import (
 	"github.com/unchartedsoftware/veldt"
 	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
 	"github.com/unchartedsoftware/veldt/util/json"
 )
 
@@ -12,6 +13,7 @@ type TopTermCountTile struct {
 	Elastic
 	Bivariate
 	TopTerms
+	tile.Tabular
 }
 
 // NewTopTermCountTile instantiates and returns a new tile struct.
@@ -30,7 +32,19 @@ func (t *TopTermCountTile) Parse(params map[string]interface{}) error {
 	if err != nil {
 		return err
 	}
-	return t.TopTerms.Parse(params)
+	err = t.TopTerms.Parse(params)
+	if err != nil {
+		return err
+	}
+	return t.Tabular.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (t *TopTermCountTile) Params() []json.Param {
+	return json.MergeParams(
+		t.Bivariate.Params(),
+		t.TopTerms.Params(),
+		t.Tabular.Params())
 }
 
 // Create generates a tile from the provided URI, tile coordinate and query
@@ -66,9 +80,15 @@ func (t *TopTermCountTile) Create(uri string, coord *binning.TileCoord, query ve
 	}
 	// encode
 	counts := make(map[string]uint32)
+	table := tile.NewTable("term", "count")
 	for term, bucket := range terms {
 		counts[term] = uint32(bucket.DocCount)
+		table.AddRow(map[string]interface{}{
+			"term":  term,
+			"count": counts[term],
+		})
 	}
+	table.SortBy("term")
 	// marshal results
-	return json.Marshal(counts)
+	return t.Tabular.Encode(table, counts)
 }
@@ -3,6 +3,7 @@ package elastic
 import (
 	"github.com/unchartedsoftware/veldt"
 	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
 	"github.com/unchartedsoftware/veldt/util/json"
 )
 
@@ -13,6 +14,7 @@ type TopTermFrequencyTile struct {
 	Bivariate
 	TopTerms
 	Frequency
+	tile.Tabular
 }
 
 // NewTopTermFrequencyTile instantiates and returns a new tile struct.
@@ -31,7 +33,19 @@ func (t *TopTermFrequencyTile) Parse(params map[string]interface{}) error {
 	if err != nil {
 		return err
 	}
-	return t.TopTerms.Parse(params)
+	err = t.TopTerms.Parse(params)
+	if err != nil {
+		return err
+	}
+	return t.Tabular.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (t *TopTermFrequencyTile) Params() []json.Param {
+	return json.MergeParams(
+		t.Bivariate.Params(),
+		t.TopTerms.Params(),
+		t.Tabular.Params())
 }
 
 // Create generates a tile from the provided URI, tile coordinate and query
@@ -70,6 +84,7 @@ func (t *TopTermFrequencyTile) Create(uri string, coord *binning.TileCoord, quer
 	}
 	// encode
 	result := make(map[string][]map[string]interface{})
+	table := tile.NewTable("term", "timestamp", "count")
 	for term, item := range terms {
 		// get buckets
 		buckets, err := t.Frequency.GetBuckets(&item.Aggregations)
@@ -83,9 +98,15 @@ func (t *TopTermFrequencyTile) Create(uri string, coord *binning.TileCoord, quer
 				"timestamp": bucket.Key,
 				"count":     bucket.DocCount,
 			}
+			table.AddRow(map[string]interface{}{
+				"term":      term,
+				"timestamp": bucket.Key,
+				"count":     bucket.DocCount,
+			})
 		}
 		result[term] = frequency
 	}
+	table.SortBy("term")
 	// marshal results
-	return json.Marshal(result)
+	return t.Tabular.Encode(table, result)
 }
@@ -0,0 +1,24 @@
+package file
+
+import (
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/config"
+)
+
+func init() {
+	config.RegisterBackend("file", NewBackend)
+}
+
+// NewBackend instantiates and returns the filesystem tile type. The backend does
+// not accept any parameters.
+func NewBackend(params map[string]interface{}) (*config.Backend, error) {
+	err := config.ValidateParams(params, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &config.Backend{
+		Tiles: map[string]veldt.TileCtor{
+			"file": NewTile(),
+		},
+	}, nil
+}
@@ -47,6 +47,15 @@ func (t *Tile) Parse(params map[string]interface{}) error {
 	return nil
 }
 
+// Params returns the parameters accepted by the tile.
+func (t *Tile) Params() []json.Param {
+	return []json.Param{
+		{Key: "path", Type: json.TypeString, Required: true},
+		{Key: "ext", Type: json.TypeString, Required: true},
+		{Key: "padcoords", Type: json.TypeBoolean, Default: true},
+	}
+}
+
 // Create generates a tile from the provided URI, tile coordinate and query
 // parameters.
 func (t *Tile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
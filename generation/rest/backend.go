@@ -0,0 +1,24 @@
+package rest
+
+import (
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/config"
+)
+
+func init() {
+	config.RegisterBackend("rest", NewBackend)
+}
+
+// NewBackend instantiates and returns the REST tile type. The backend does
+// not accept any parameters.
+func NewBackend(params map[string]interface{}) (*config.Backend, error) {
+	err := config.ValidateParams(params, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &config.Backend{
+		Tiles: map[string]veldt.TileCtor{
+			"rest": NewTile(),
+		},
+	}, nil
+}
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -53,6 +54,15 @@ func (t *Tile) Parse(params map[string]interface{}) error {
 	return nil
 }
 
+// Params returns the parameters accepted by the tile.
+func (t *Tile) Params() []json.Param {
+	return []json.Param{
+		{Key: "endpoint", Type: json.TypeString, Required: true},
+		{Key: "scheme", Type: json.TypeString, Required: true},
+		{Key: "ext", Type: json.TypeString, Required: true},
+	}
+}
+
 // Create generates a tile from the provided URI, tile coordinate and query
 // parameters.
 func (t *Tile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
@@ -91,7 +101,7 @@ func (t *Tile) Create(uri string, coord *binning.TileCoord, query veldt.Query) (
 		if len(str) > maxErrLength {
 			str = str[0:maxErrLength] + "..."
 		}
-		return nil, fmt.Errorf(str)
+		return nil, errors.New(str)
 	}
 	return tile.Decode(t.ext, res.Body)
 }
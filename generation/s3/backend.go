@@ -0,0 +1,24 @@
+package s3
+
+import (
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/config"
+)
+
+func init() {
+	config.RegisterBackend("s3", NewBackend)
+}
+
+// NewBackend instantiates and returns the S3 tile type. The backend does
+// not accept any parameters.
+func NewBackend(params map[string]interface{}) (*config.Backend, error) {
+	err := config.ValidateParams(params, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &config.Backend{
+		Tiles: map[string]veldt.TileCtor{
+			"s3": NewTile(),
+		},
+	}, nil
+}
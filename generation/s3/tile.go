@@ -44,6 +44,14 @@ func (t *Tile) Parse(params map[string]interface{}) error {
 	return nil
 }
 
+// Params returns the parameters accepted by the tile.
+func (t *Tile) Params() []json.Param {
+	return []json.Param{
+		{Key: "ext", Type: json.TypeString, Default: defaultExt},
+		{Key: "padCoords", Type: json.TypeBoolean, Default: defaultPadCoords},
+	}
+}
+
 // Create generates a tile from the provided URI, tile coordinate and query parameters.
 func (t *Tile) Create(s3uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
 	// create s3 client
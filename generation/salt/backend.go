@@ -0,0 +1,54 @@
+package salt
+
+import (
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/config"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+func init() {
+	config.RegisterBackend("salt", NewBackend)
+}
+
+// NewBackend instantiates and returns the salt tile, meta and query types for
+// the provided connection parameters. The `config` parameter is the path of
+// the salt connection configuration file, and `datasets` lists the paths of
+// the dataset configuration files. Any query ID is forwarded to the salt
+// server as a generic query.
+func NewBackend(params map[string]interface{}) (*config.Backend, error) {
+	err := config.ValidateParams(params, []json.Param{
+		{Key: "config", Type: json.TypeString, Required: true},
+		{Key: "datasets", Type: json.TypeArray, Items: json.TypeString},
+	})
+	if err != nil {
+		return nil, err
+	}
+	filename, _ := json.GetString(params, "config")
+	rmqConfig, err := ReadConfig(filename)
+	if err != nil {
+		return nil, err
+	}
+	filenames, _ := json.GetStringArray(params, "datasets")
+	datasets := make([][]byte, len(filenames))
+	for i, filename := range filenames {
+		datasets[i], err = ReadDatasetConfig(filename)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &config.Backend{
+		Binary: NewBinaryExpression,
+		Unary:  NewUnaryExpression,
+		Tiles: map[string]veldt.TileCtor{
+			"heatmap":   NewHeatmapTile(rmqConfig, datasets...),
+			"count":     NewCountTile(rmqConfig, datasets...),
+			"macro":     NewMacroTile(rmqConfig, datasets...),
+			"micro":     NewMicroTile(rmqConfig, datasets...),
+			"macroEdge": NewMacroEdgeTile(rmqConfig, datasets...),
+		},
+		Metas: map[string]veldt.MetaCtor{
+			"default": NewMeta(rmqConfig),
+		},
+		GenericQuery: NewGenericQuery,
+	}, nil
+}
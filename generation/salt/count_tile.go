@@ -59,6 +59,15 @@ func (c *CountTile) Parse(params map[string]interface{}) error {
 	return c.TileData.Parse(params)
 }
 
+// Params returns the parameters accepted by the tile.
+func (c *CountTile) Params() []json.Param {
+	return json.MergeParams(
+		c.Bivariate.Params(),
+		[]json.Param{
+			{Key: "valueField", Type: json.TypeString},
+		})
+}
+
 // parseCountParams actually parses the provided JSON object, and
 // populates the tile attributes.
 func (c *CountTile) parseCountParams(params map[string]interface{}) error {
@@ -1,7 +1,9 @@
 package salt
 
 import (
+	"encoding/binary"
 	"fmt"
+	"math"
 
 	"github.com/unchartedsoftware/veldt"
 	"github.com/unchartedsoftware/veldt/binning"
@@ -13,8 +15,8 @@ import (
 // HeatmapTile represents a Salt implementation of the heatmap tile
 type HeatmapTile struct {
 	tile.Bivariate
+	tile.Heatmap
 	TileData
-	valueField string
 }
 
 // NewHeatmapTile instantiates and returns a new tile struct.
@@ -58,14 +60,19 @@ func (h *HeatmapTile) Parse(params map[string]interface{}) error {
 	return h.TileData.Parse(params)
 }
 
+// Params returns the parameters accepted by the tile.
+func (h *HeatmapTile) Params() []json.Param {
+	return json.MergeParams(
+		h.Bivariate.Params(),
+		h.Heatmap.Params())
+}
+
 // parseHeatmapParams actually parses the provided JSON object, and
 // populates the tile attributes.
 func (h *HeatmapTile) parseHeatmapParams(params map[string]interface{}) error {
-	valueField, ok := json.GetString(params, "valueField")
-	if ok {
-		h.valueField = valueField
-	} else {
-		h.valueField = ""
+	err := h.Heatmap.Parse(params)
+	if err != nil {
+		return err
 	}
 	return h.Bivariate.Parse(params)
 }
@@ -79,13 +86,19 @@ func (h *HeatmapTile) getTileConfig() (map[string]interface{}, error) {
 	}
 	// Bounds are ignored - salt needs the dataset bounds, not the tile bounds
 	// in visualization space
-	return map[string]interface{}{
+	config := map[string]interface{}{
 		"type":       "heatmap",
 		"xField":     h.XField,
 		"yField":     h.YField,
-		"valueField": h.valueField,
+		"valueField": h.ValueField,
 		"resolution": h.Resolution,
-	}, nil
+	}
+	// only override the salt default aggregation of the value field when
+	// requested
+	if json.Exists(*h.parameters, "aggregation") {
+		config["aggregation"] = h.Aggregation
+	}
+	return config, nil
 }
 
 func (h *HeatmapTile) convertTile(coord *binning.TileCoord, input []byte) ([]byte, error) {
@@ -108,7 +121,17 @@ func (h *HeatmapTile) convertTile(coord *binning.TileCoord, input []byte) ([]byt
 		copy(output[(y+0)*stride:(y+1)*stride], input[(res-y-1)*stride:(res-y)*stride])
 	}
 
-	return output, nil
+	return h.encode(output)
+}
+
+// encode re-encodes the little endian float32 bins with the requested
+// encoding and lod.
+func (h *HeatmapTile) encode(input []byte) ([]byte, error) {
+	bins := make([]float32, len(input)/4)
+	for i := range bins {
+		bins[i] = math.Float32frombits(binary.LittleEndian.Uint32(input[i*4 : i*4+4]))
+	}
+	return h.Heatmap.Encode(bins)
 }
 
 func (h *HeatmapTile) buildDefaultTile() ([]byte, error) {
@@ -117,7 +140,5 @@ func (h *HeatmapTile) buildDefaultTile() ([]byte, error) {
 		return nil, err
 	}
 
-	bins := h.Resolution * h.Resolution
-	bits := make([]byte, bins*4)
-	return bits, nil
+	return h.Heatmap.Encode(make([]float32, h.Resolution*h.Resolution))
 }
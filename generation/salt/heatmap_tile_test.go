@@ -0,0 +1,74 @@
+package salt
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+)
+
+var _ = Describe("HeatmapTile", func() {
+
+	coord := &binning.TileCoord{Z: 0, X: 0, Y: 0}
+
+	params := func(lod float64) map[string]interface{} {
+		return map[string]interface{}{
+			"xField":     "x",
+			"yField":     "y",
+			"left":       0.0,
+			"right":      256.0,
+			"bottom":     0.0,
+			"top":        256.0,
+			"resolution": 4.0,
+			"lod":        lod,
+		}
+	}
+
+	// a 4x4 salt tile, whose rows are ordered from the top
+	input := tile.EncodeFloat32([]float32{
+		1, 0, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 0, 2,
+	})
+
+	Describe("convertTile", func() {
+		It("should flip the rows of the tile", func() {
+			h := newHeatmapTile(nil)
+			err := h.Parse(params(0))
+			Expect(err).To(BeNil())
+			data, err := h.convertTile(coord, input)
+			Expect(err).To(BeNil())
+			bins, err := h.Decode(data)
+			Expect(err).To(BeNil())
+			Expect(bins[3]).To(Equal(float32(2)))
+			Expect(bins[12]).To(Equal(float32(1)))
+		})
+
+		It("should encode the `lod` without an `encoding`", func() {
+			h := newHeatmapTile(nil)
+			err := h.Parse(params(2))
+			Expect(err).To(BeNil())
+			data, err := h.convertTile(coord, input)
+			Expect(err).To(BeNil())
+			bins, err := tile.DecodeBins(data, "", 2)
+			Expect(err).To(BeNil())
+			Expect(bins).To(HaveLen(16))
+			Expect(bins[3]).To(Equal(float32(2)))
+		})
+	})
+
+	Describe("buildDefaultTile", func() {
+		It("should encode the `lod` of an empty tile", func() {
+			h := newHeatmapTile(nil)
+			err := h.Parse(params(2))
+			Expect(err).To(BeNil())
+			data, err := h.buildDefaultTile()
+			Expect(err).To(BeNil())
+			bins, err := tile.DecodeBins(data, "", 2)
+			Expect(err).To(BeNil())
+			Expect(bins).To(Equal(make([]float32, 16)))
+		})
+	})
+})
@@ -8,6 +8,7 @@ import (
 	"github.com/unchartedsoftware/veldt/binning"
 	"github.com/unchartedsoftware/veldt/generation/batch"
 	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
 )
 
 // MacroEdgeTile represents a salt implementation of the Edge tile
@@ -59,6 +60,14 @@ func (m *MacroEdgeTile) Parse(params map[string]interface{}) error {
 	return m.TileData.Parse(params)
 }
 
+// Params returns the parameters accepted by the tile.
+func (m *MacroEdgeTile) Params() []json.Param {
+	return json.MergeParams(
+		m.Edge.Params(),
+		m.TopHits.Params(),
+		m.MacroEdge.Params())
+}
+
 // parseEdgeParams actually parses the provided JSON object, and
 // populates the tile attributes.
 func (m *MacroEdgeTile) parseEdgeParams(params map[string]interface{}) error {
@@ -138,9 +147,9 @@ func (m *MacroEdgeTile) convertTile(coord *binning.TileCoord, input []byte) ([]b
 		points[i*6+5] = weight
 	}
 
-	return m.MacroEdge.Encode(points)
+	return m.MacroEdge.Encode(points, m.Edge.Unprojector(coord))
 }
 
 func (m *MacroEdgeTile) buildDefaultTile() ([]byte, error) {
-	return m.MacroEdge.Encode(make([]float32, 0))
+	return m.MacroEdge.Encode(make([]float32, 0), nil)
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/unchartedsoftware/veldt/binning"
 	"github.com/unchartedsoftware/veldt/generation/batch"
 	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
 )
 
 // MacroTile represents a Salt implementation of the macro tile
@@ -57,6 +58,13 @@ func (m *MacroTile) Parse(params map[string]interface{}) error {
 	return m.TileData.Parse(params)
 }
 
+// Params returns the parameters accepted by the tile.
+func (m *MacroTile) Params() []json.Param {
+	return json.MergeParams(
+		m.Bivariate.Params(),
+		m.Macro.Params())
+}
+
 // parseMacroParams actually parses the provided JSON object, and
 // populates the tile attributes.
 func (m *MacroTile) parseMacroParams(params map[string]interface{}) error {
@@ -79,7 +87,7 @@ func (m *MacroTile) getTileConfig() (map[string]interface{}, error) {
 		"type":       "macro",
 		"xField":     m.XField,
 		"yField":     m.YField,
-		"resolution": m.Resolution,
+		"resolution": m.Bivariate.Resolution,
 	}, nil
 }
 
@@ -89,8 +97,8 @@ func (m *MacroTile) convertTile(coord *binning.TileCoord, input []byte) ([]byte,
 		return nil, err
 	}
 
-	// Bin characteristics
-	binSize := binning.MaxTileResolution / float64(m.Resolution)
+	// Bin characteristics, in the pixel space of the tile
+	binSize := float64(m.Macro.Resolution) / float64(m.Bivariate.Resolution)
 	halfSize := float64(binSize / 2)
 
 	// Macro tiles are returned to us as a series of integers which indicate
@@ -105,7 +113,7 @@ func (m *MacroTile) convertTile(coord *binning.TileCoord, input []byte) ([]byte,
 	for i := 0; i < numPoints; i++ {
 		x := binary.LittleEndian.Uint32(input[p : p+4])
 		p = p + 4
-		y := uint32(m.Resolution) - binary.LittleEndian.Uint32(input[p:p+4])
+		y := uint32(m.Bivariate.Resolution) - binary.LittleEndian.Uint32(input[p:p+4])
 		p = p + 4
 
 		// Convert from bin number to location
@@ -115,9 +123,9 @@ func (m *MacroTile) convertTile(coord *binning.TileCoord, input []byte) ([]byte,
 		points[i*2+1] = float32(float64(y)*binSize + halfSize)
 	}
 
-	return m.Macro.Encode(points)
+	return m.Macro.Encode(points, m.Bivariate.Unprojector(coord))
 }
 
 func (m *MacroTile) buildDefaultTile() ([]byte, error) {
-	return m.Macro.Encode(make([]float32, 0))
+	return m.Macro.Encode(make([]float32, 0), nil)
 }
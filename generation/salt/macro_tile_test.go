@@ -0,0 +1,63 @@
+package salt
+
+import (
+	"encoding/binary"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+)
+
+var _ = Describe("MacroTile", func() {
+
+	// two populated bins of a 64x64 salt tile, as x / y bin pairs
+	input := make([]byte, 4+2*8)
+	binary.LittleEndian.PutUint32(input[0:], 2)
+	binary.LittleEndian.PutUint32(input[4:], 0)
+	binary.LittleEndian.PutUint32(input[8:], 64)
+	binary.LittleEndian.PutUint32(input[12:], 63)
+	binary.LittleEndian.PutUint32(input[16:], 1)
+
+	coord := &binning.TileCoord{Z: 0, X: 0, Y: 0}
+
+	Describe("convertTile", func() {
+		It("should position the bins of the `resolution` in the 256 pixel space of the tile", func() {
+			m := newMacroTile(nil)
+			err := m.Parse(map[string]interface{}{
+				"xField":     "x",
+				"yField":     "y",
+				"left":       0.0,
+				"right":      256.0,
+				"bottom":     0.0,
+				"top":        256.0,
+				"resolution": 64.0,
+			})
+			Expect(err).To(BeNil())
+			bytes, err := m.convertTile(coord, input)
+			Expect(err).To(BeNil())
+			Expect(bytes).To(Equal(tile.EncodeFloat32([]float32{2, 2, 254, 254})))
+			Expect(m.Bivariate.Resolution).To(Equal(64))
+			Expect(m.Macro.Resolution).To(Equal(256))
+		})
+
+		It("should position the bins in the `tileResolution` pixel space of the tile", func() {
+			m := newMacroTile(nil)
+			err := m.Parse(map[string]interface{}{
+				"xField":         "x",
+				"yField":         "y",
+				"left":           0.0,
+				"right":          256.0,
+				"bottom":         0.0,
+				"top":            256.0,
+				"resolution":     64.0,
+				"tileResolution": 1024.0,
+			})
+			Expect(err).To(BeNil())
+			bytes, err := m.convertTile(coord, input)
+			Expect(err).To(BeNil())
+			Expect(bytes).To(Equal(tile.EncodeFloat32([]float32{8, 8, 1016, 1016})))
+		})
+	})
+})
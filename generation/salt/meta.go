@@ -2,6 +2,7 @@ package salt
 
 import (
 	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/util/json"
 )
 
 // Meta contains information about how to request metadata from a salt server
@@ -35,3 +36,9 @@ func (meta *Meta) Create(uri string) ([]byte, error) {
 func (meta *Meta) Parse(params map[string]interface{}) error {
 	return nil
 }
+
+// Params returns the parameters accepted by the meta data generator. There are
+// currently none.
+func (meta *Meta) Params() []json.Param {
+	return []json.Param{}
+}
@@ -59,6 +59,14 @@ func (m *MicroTile) Parse(params map[string]interface{}) error {
 	return m.TileData.Parse(params)
 }
 
+// Params returns the parameters accepted by the tile.
+func (m *MicroTile) Params() []json.Param {
+	return json.MergeParams(
+		m.Bivariate.Params(),
+		m.TopHits.Params(),
+		m.Micro.Params())
+}
+
 // parseMicroParams actually parses the provided JSON object, and
 // populates the tile attributes.
 func (m *MicroTile) parseMicroParams(params map[string]interface{}) error {
@@ -111,6 +119,9 @@ func (m *MicroTile) convertTile(coord *binning.TileCoord, input []byte) ([]byte,
 		return nil, err
 	}
 
+	// scale from [0 : 256) into the pixel space of the tile
+	scale := float32(m.Micro.Resolution) / float32(binning.MaxTileResolution)
+
 	numHits := len(rawHits)
 	points := make([]float32, numHits*2)
 	hits := make([]map[string]interface{}, numHits)
@@ -124,8 +135,8 @@ func (m *MicroTile) convertTile(coord *binning.TileCoord, input []byte) ([]byte,
 		if !ok {
 			return nil, fmt.Errorf("could not parse `y` from hit: %v", hit)
 		}
-		points[2*i+0] = float32(x)
-		points[2*i+1] = 256 - float32(y)
+		points[2*i+0] = float32(x) * scale
+		points[2*i+1] = (256 - float32(y)) * scale
 
 		hitMap, ok := json.GetChild(hit, "values")
 		if !ok {
@@ -134,9 +145,9 @@ func (m *MicroTile) convertTile(coord *binning.TileCoord, input []byte) ([]byte,
 		hits[i] = hitMap
 	}
 
-	return m.Micro.Encode(hits, points)
+	return m.Micro.Encode(hits, points, m.Bivariate.Unprojector(coord))
 }
 
 func (m *MicroTile) buildDefaultTile() ([]byte, error) {
-	return m.Micro.Encode(make([]map[string]interface{}, 0), make([]float32, 0))
+	return m.Micro.Encode(make([]map[string]interface{}, 0), make([]float32, 0), nil)
 }
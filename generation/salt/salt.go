@@ -1,6 +1,7 @@
 package salt
 
 import (
+	"errors"
 	"fmt"
 	"runtime"
 	"sync"
@@ -180,7 +181,7 @@ func (rmq *RabbitMQConnection) sendServerMessage(messageType string, message []b
 	response := <-responseChannel
 	Debugf("Response received: \"%s\"", string(response.Body))
 	if "error" == response.Type {
-		return nil, fmt.Errorf(string(response.Body))
+		return nil, errors.New(string(response.Body))
 	}
 
 	return response.Body, nil
@@ -51,6 +51,16 @@ func (b *Bounds) Parse(params map[string]interface{}) error {
 	return nil
 }
 
+// Params returns the parameters accepted by the bounds.
+func (b *Bounds) Params() []jsonUtil.Param {
+	return []jsonUtil.Param{
+		{Key: "left", Type: jsonUtil.TypeNumber, Required: true},
+		{Key: "right", Type: jsonUtil.TypeNumber, Required: true},
+		{Key: "bottom", Type: jsonUtil.TypeNumber, Required: true},
+		{Key: "top", Type: jsonUtil.TypeNumber, Required: true},
+	}
+}
+
 // MinX returns the minimum x value for the bounds.
 func (b Bounds) MinX() float64 {
 	return math.Min(b.Left, b.Right)
@@ -80,3 +90,13 @@ func (b *Bounds) RangeX() float64 {
 func (b *Bounds) RangeY() float64 {
 	return math.Abs(b.Top - b.Bottom)
 }
+
+// CenterX returns the x value halfway between left and right.
+func (b *Bounds) CenterX() float64 {
+	return b.Left + (b.Right-b.Left)/2
+}
+
+// CenterY returns the y value halfway between bottom and top.
+func (b *Bounds) CenterY() float64 {
+	return b.Bottom + (b.Top-b.Bottom)/2
+}
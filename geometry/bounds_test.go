@@ -111,4 +111,18 @@ var _ = Describe("Bounds", func() {
 		})
 	})
 
+	Describe("CenterX", func() {
+		It("should return the x value halfway between left and right", func() {
+			bounds = geometry.NewBounds(1, 3, -1, 1)
+			Expect(bounds.CenterX()).To(Equal(2.0))
+		})
+	})
+
+	Describe("CenterY", func() {
+		It("should return the y value halfway between bottom and top", func() {
+			bounds = geometry.NewBounds(-1, 1, 4, -2)
+			Expect(bounds.CenterY()).To(Equal(1.0))
+		})
+	})
+
 })
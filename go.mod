@@ -1,33 +1,51 @@
 module github.com/unchartedsoftware/veldt
 
+go 1.27.1
+
 require (
 	github.com/aws/aws-sdk-go v1.8.3
 	github.com/coocood/freecache v0.0.0-20170401024559-c7b48416d80a
 	github.com/davecgh/go-spew v1.1.0
-	github.com/fsnotify/fsnotify v1.4.7 // indirect
 	github.com/garyburd/redigo v0.0.0-20170426212818-ac91d6ff49bd
+	github.com/jackc/pgx v0.0.0-20170417134424-c16671e77e8a
+	github.com/liyinhgqw/typesafe-config v0.0.0-20150617052320-c8ba452ab033
+	github.com/mattn/go-isatty v0.0.8
+	github.com/onsi/ginkgo v1.6.0
+	github.com/onsi/gomega v0.0.0-20170408032339-9b8c753e8dfb
+	github.com/streadway/amqp v0.0.0-20170313174848-afe8eee29a74
+	gopkg.in/fsnotify.v1 v1.4.7
+	gopkg.in/olivere/elastic.v3 v3.0.68
+	gopkg.in/yaml.v2 v2.2.2
+)
+
+require (
+	github.com/fsnotify/fsnotify v1.4.7 // indirect
 	github.com/go-ini/ini v1.27.0 // indirect
 	github.com/go-stack/stack v1.8.0 // indirect
 	github.com/golang/protobuf v1.3.2 // indirect
+	github.com/gopherjs/gopherjs v0.0.0-20181017120253-0766667cb4d1 // indirect
 	github.com/hpcloud/tail v1.0.0 // indirect
 	github.com/jackc/fake v0.0.0-20150926172116-812a484cc733 // indirect
-	github.com/jackc/pgx v0.0.0-20170417134424-c16671e77e8a
 	github.com/jmespath/go-jmespath v0.0.0-20160803190731-bd40a432e4c7 // indirect
+	github.com/jtolds/gls v4.20.0+incompatible // indirect
 	github.com/kr/pretty v0.1.0 // indirect
-	github.com/liyinhgqw/typesafe-config v0.0.0-20150617052320-c8ba452ab033
+	github.com/kr/pty v1.1.1 // indirect
+	github.com/kr/text v0.1.0 // indirect
 	github.com/mattn/go-colorable v0.1.2 // indirect
-	github.com/mattn/go-isatty v0.0.8
-	github.com/onsi/ginkgo v1.6.0
-	github.com/onsi/gomega v0.0.0-20170408032339-9b8c753e8dfb
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/shopspring/decimal v0.0.0-20190905144223-a36b5d85f337 // indirect
+	github.com/smartystreets/assertions v0.0.0-20180927180507-b2de0cb4f26d // indirect
 	github.com/smartystreets/goconvey v0.0.0-20190731233626-505e41936337 // indirect
 	github.com/spaolacci/murmur3 v0.0.0-20150829172844-0d12bf811670 // indirect
-	github.com/streadway/amqp v0.0.0-20170313174848-afe8eee29a74
+	github.com/stretchr/objx v0.1.0 // indirect
 	github.com/stretchr/testify v1.4.0 // indirect
+	golang.org/x/crypto v0.0.0-20190308221718-c2843e01d9a2 // indirect
+	golang.org/x/net v0.0.0-20190311183353-d8887717615a // indirect
 	golang.org/x/sync v0.0.0-20190911185100-cd5d95a43a6e // indirect
+	golang.org/x/sys v0.0.0-20190222072716-a9d3bda3a223 // indirect
+	golang.org/x/text v0.3.0 // indirect
+	golang.org/x/tools v0.0.0-20190328211700-ab21143f2384 // indirect
 	gopkg.in/check.v1 v1.0.0-20190902080502-41f04d3bba15 // indirect
-	gopkg.in/fsnotify.v1 v1.4.7 // indirect
 	gopkg.in/inconshreveable/log15.v2 v2.0.0-20180818164646-67afb5ed74ec // indirect
-	gopkg.in/olivere/elastic.v3 v3.0.68
 	gopkg.in/tomb.v1 v1.0.0-20141024135613-dd632973f1e7 // indirect
 )
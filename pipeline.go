@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"sync"
 
 	"github.com/unchartedsoftware/veldt/util/json"
 	"github.com/unchartedsoftware/veldt/util/promise"
@@ -22,8 +23,12 @@ type Pipeline struct {
 	tiles       map[string]TileCtor
 	metas       map[string]MetaCtor
 	store       StoreCtor
+	storeID     string
 	promises    *promise.Map
 	compression string
+	mu          *sync.Mutex
+	active      *sync.WaitGroup
+	closed      bool
 }
 
 // NewPipeline instantiates and returns a new pipeline struct.
@@ -35,6 +40,8 @@ func NewPipeline() *Pipeline {
 		metas:       make(map[string]MetaCtor),
 		promises:    promise.NewMap(),
 		compression: "gzip",
+		mu:          &sync.Mutex{},
+		active:      &sync.WaitGroup{},
 	}
 }
 
@@ -48,6 +55,12 @@ func (p *Pipeline) SetQueueLength(length int) {
 	p.queue.SetLength(length)
 }
 
+// SetCompression sets the compression applied to generated data before it is
+// stored. Supported values are "gzip", "zlib" and "none".
+func (p *Pipeline) SetCompression(compression string) {
+	p.compression = compression
+}
+
 // Query registers a query type under the provided ID string.
 func (p *Pipeline) Query(id string, ctor QueryCtor) {
 	p.queries[id] = ctor
@@ -73,25 +86,37 @@ func (p *Pipeline) Meta(id string, ctor MetaCtor) {
 	p.metas[id] = ctor
 }
 
-// Store registers the storage system used to cache generated data.
-func (p *Pipeline) Store(ctor StoreCtor) {
+// Store registers the storage system used to cache generated data under the
+// provided ID string.
+func (p *Pipeline) Store(id string, ctor StoreCtor) {
 	p.store = ctor
+	p.storeID = id
 }
 
 // GetQuery returns the instantiated query struct from the provided ID and JSON.
 func (p *Pipeline) GetQuery(id string, args interface{}) (Query, error) {
 	params, ok := args.(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("`%s` is not of correct type", id)
+		return nil, &json.CodedError{
+			Code:    json.CodeType,
+			Message: fmt.Sprintf("`%s` is not of correct type", id),
+		}
 	}
 	ctor, ok := p.queries[id]
 	if !ok {
-		return nil, fmt.Errorf("unrecognized query type `%v`", id)
+		return nil, &json.CodedError{
+			Code:    json.CodeUnrecognized,
+			Message: fmt.Sprintf("unrecognized query type `%v`", id),
+		}
 	}
 	query, err := ctor()
 	if err != nil {
 		return nil, err
 	}
+	err = validateParams(query, params)
+	if err != nil {
+		return nil, err
+	}
 	err = query.Parse(params)
 	if err != nil {
 		return nil, err
@@ -122,16 +147,26 @@ func (p *Pipeline) GetUnary() (Query, error) {
 func (p *Pipeline) GetTile(id string, args interface{}) (Tile, error) {
 	params, ok := args.(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("`%s` is not of correct type", id)
+		return nil, &json.CodedError{
+			Code:    json.CodeType,
+			Message: fmt.Sprintf("`%s` is not of correct type", id),
+		}
 	}
 	ctor, ok := p.tiles[id]
 	if !ok {
-		return nil, fmt.Errorf("unrecognized tile type `%v`", id)
+		return nil, &json.CodedError{
+			Code:    json.CodeUnrecognized,
+			Message: fmt.Sprintf("unrecognized tile type `%v`", id),
+		}
 	}
 	tile, err := ctor()
 	if err != nil {
 		return nil, err
 	}
+	err = validateParams(tile, params)
+	if err != nil {
+		return nil, err
+	}
 	err = tile.Parse(params)
 	if err != nil {
 		return nil, err
@@ -144,16 +179,26 @@ func (p *Pipeline) GetTile(id string, args interface{}) (Tile, error) {
 func (p *Pipeline) GetMeta(id string, args interface{}) (Meta, error) {
 	params, ok := args.(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("`%s` is not of correct type", id)
+		return nil, &json.CodedError{
+			Code:    json.CodeType,
+			Message: fmt.Sprintf("`%s` is not of correct type", id),
+		}
 	}
 	ctor, ok := p.metas[id]
 	if !ok {
-		return nil, fmt.Errorf("unrecognized meta type `%v`", id)
+		return nil, &json.CodedError{
+			Code:    json.CodeUnrecognized,
+			Message: fmt.Sprintf("unrecognized meta type `%v`", id),
+		}
 	}
 	meta, err := ctor()
 	if err != nil {
 		return nil, err
 	}
+	err = validateParams(meta, params)
+	if err != nil {
+		return nil, err
+	}
 	err = meta.Parse(params)
 	if err != nil {
 		return nil, err
@@ -175,7 +220,7 @@ func (p *Pipeline) GetHash() string {
 }
 
 // NewTileRequest instantiates and returns a tile request struct from the
-// provided JSON.
+// provided JSON. If the request is invalid, a *ValidationError is returned.
 func (p *Pipeline) NewTileRequest(args map[string]interface{}) (*TileRequest, error) {
 	// params are modified in place during validation, so create a copy
 	copy, err := json.Copy(args)
@@ -185,13 +230,13 @@ func (p *Pipeline) NewTileRequest(args map[string]interface{}) (*TileRequest, er
 	// validate request
 	req, err := newValidator(p).validateTileRequest(copy)
 	if err != nil {
-		return nil, fmt.Errorf("invalid tile request:\n%s", err)
+		return nil, err
 	}
 	return req, nil
 }
 
 // NewMetaRequest instantiates and returns a metadata request struct from the
-// provided JSON.
+// provided JSON. If the request is invalid, a *ValidationError is returned.
 func (p *Pipeline) NewMetaRequest(args map[string]interface{}) (*MetaRequest, error) {
 	// params are modified in place during validation, so create a copy
 	copy, err := json.Copy(args)
@@ -201,13 +246,77 @@ func (p *Pipeline) NewMetaRequest(args map[string]interface{}) (*MetaRequest, er
 	// validate request
 	req, err := newValidator(p).validateMetaRequest(copy)
 	if err != nil {
-		return nil, fmt.Errorf("invalid meta request:\n%s", err)
+		return nil, err
 	}
 	return req, nil
 }
 
 // Generate generates data for the provided request.
 func (p *Pipeline) Generate(req Request) error {
+	err := p.begin()
+	if err != nil {
+		return err
+	}
+	defer p.end()
+	return p.generate(req)
+}
+
+// Get retrieves the generated data from the store.
+func (p *Pipeline) Get(req Request) ([]byte, error) {
+	err := p.begin()
+	if err != nil {
+		return nil, err
+	}
+	defer p.end()
+	return p.get(req)
+}
+
+// GenerateAndGet retrieves the generated data from the store, if it
+// does not exist, generate it before retrieval.
+func (p *Pipeline) GenerateAndGet(req Request) ([]byte, error) {
+	err := p.begin()
+	if err != nil {
+		return nil, err
+	}
+	defer p.end()
+	return p.generateAndGet(req)
+}
+
+// Close stops the pipeline from accepting new requests, waits for the
+// requests in flight to finish, then closes its queue. Store connections are
+// opened and closed per request, so none remain open once the pipeline is
+// drained.
+func (p *Pipeline) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	p.mu.Unlock()
+	// wait for in-flight requests
+	p.active.Wait()
+	p.queue.Close()
+}
+
+// begin tracks a request in flight, returning an error if the pipeline is
+// closed.
+func (p *Pipeline) begin() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return fmt.Errorf("pipeline is closed and is no longer accepting requests")
+	}
+	p.active.Add(1)
+	return nil
+}
+
+// end marks a request tracked by begin as finished.
+func (p *Pipeline) end() {
+	p.active.Done()
+}
+
+func (p *Pipeline) generate(req Request) error {
 	// get hash
 	hash := p.getHash(req)
 	// get store
@@ -229,8 +338,7 @@ func (p *Pipeline) Generate(req Request) error {
 	return p.getPromise(hash, req)
 }
 
-// Get retrieves the generated data from the store.
-func (p *Pipeline) Get(req Request) ([]byte, error) {
+func (p *Pipeline) get(req Request) ([]byte, error) {
 	// get hash
 	hash := p.getHash(req)
 	// get store
@@ -247,9 +355,7 @@ func (p *Pipeline) Get(req Request) ([]byte, error) {
 	return p.decompress(res)
 }
 
-// GenerateAndGet retrieves the generated data from the store, if it
-// does not exist, generate it before retrieval.
-func (p *Pipeline) GenerateAndGet(req Request) ([]byte, error) {
+func (p *Pipeline) generateAndGet(req Request) ([]byte, error) {
 	// get hash
 	hash := p.getHash(req)
 	// get store
@@ -0,0 +1,92 @@
+package veldt_test
+
+import (
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/binning"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+type blockingTile struct {
+	started chan bool
+	release chan bool
+}
+
+func (t *blockingTile) Parse(params map[string]interface{}) error {
+	return nil
+}
+
+func (t *blockingTile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
+	t.started <- true
+	<-t.release
+	return []byte{}, nil
+}
+
+func newBlockingPipeline(tile *blockingTile) *veldt.Pipeline {
+	pipeline := veldt.NewPipeline()
+	pipeline.Tile("block", func() (veldt.Tile, error) {
+		return tile, nil
+	})
+	pipeline.Store("test", func() (veldt.Store, error) {
+		return &testStore{}, nil
+	})
+	return pipeline
+}
+
+func newBlockingRequest(pipeline *veldt.Pipeline) *veldt.TileRequest {
+	req, err := pipeline.NewTileRequest(JSON(
+		`{
+			"uri": "test",
+			"coord": {
+				"x": 0,
+				"y": 0,
+				"z": 0
+			},
+			"tile": {
+				"block": {}
+			}
+		}`))
+	Expect(err).To(BeNil())
+	return req
+}
+
+var _ = Describe("Pipeline", func() {
+
+	var tile *blockingTile
+	var pipeline *veldt.Pipeline
+
+	BeforeEach(func() {
+		tile = &blockingTile{
+			started: make(chan bool),
+			release: make(chan bool),
+		}
+		pipeline = newBlockingPipeline(tile)
+	})
+
+	Describe("Close", func() {
+		It("should wait for requests in flight to finish", func() {
+			req := newBlockingRequest(pipeline)
+			generated := make(chan error)
+			go func() {
+				generated <- pipeline.Generate(req)
+			}()
+			<-tile.started
+			closed := make(chan bool)
+			go func() {
+				pipeline.Close()
+				closed <- true
+			}()
+			Consistently(closed).ShouldNot(Receive())
+			tile.release <- true
+			Expect(<-generated).To(BeNil())
+			Eventually(closed).Should(Receive())
+		})
+		It("should reject requests once closed", func() {
+			req := newBlockingRequest(pipeline)
+			pipeline.Close()
+			Expect(pipeline.Generate(req)).NotTo(BeNil())
+		})
+	})
+})
@@ -27,3 +27,11 @@ func (q *Equals) Parse(params map[string]interface{}) error {
 	q.Value = value
 	return nil
 }
+
+// Params returns the parameters accepted by the query.
+func (q *Equals) Params() []json.Param {
+	return []json.Param{
+		{Key: "field", Type: json.TypeString, Required: true},
+		{Key: "value", Type: json.TypeAny, Required: true},
+	}
+}
@@ -20,3 +20,10 @@ func (q *Exists) Parse(params map[string]interface{}) error {
 	q.Field = field
 	return nil
 }
+
+// Params returns the parameters accepted by the query.
+func (q *Exists) Params() []json.Param {
+	return []json.Param{
+		{Key: "field", Type: json.TypeString, Required: true},
+	}
+}
@@ -27,3 +27,11 @@ func (q *Has) Parse(params map[string]interface{}) error {
 	q.Values = values
 	return nil
 }
+
+// Params returns the parameters accepted by the query.
+func (q *Has) Params() []json.Param {
+	return []json.Param{
+		{Key: "field", Type: json.TypeString, Required: true},
+		{Key: "values", Type: json.TypeArray, Required: true},
+	}
+}
@@ -28,3 +28,11 @@ func (q *MatchesString) Parse(params map[string]interface{}) error {
 	q.Match = match
 	return nil
 }
+
+// Params returns the parameters accepted by the query.
+func (q *MatchesString) Params() []json.Param {
+	return []json.Param{
+		{Key: "match", Type: json.TypeString, Required: true},
+		{Key: "fields", Type: json.TypeArray, Items: json.TypeString, Required: true},
+	}
+}
@@ -42,3 +42,14 @@ func (q *Range) Parse(params map[string]interface{}) error {
 	q.LT = lt
 	return nil
 }
+
+// Params returns the parameters accepted by the query.
+func (q *Range) Params() []json.Param {
+	return []json.Param{
+		{Key: "field", Type: json.TypeString, Required: true},
+		{Key: "gte", Type: json.TypeAny},
+		{Key: "gt", Type: json.TypeAny},
+		{Key: "lte", Type: json.TypeAny},
+		{Key: "lt", Type: json.TypeAny},
+	}
+}
@@ -2,23 +2,95 @@ package veldt
 
 import (
 	"fmt"
+	"sync"
 )
 
 var (
 	// registry contains all registered tile generator constructors.
 	registry = make(map[string]*Pipeline)
+	mutex    = sync.RWMutex{}
 )
 
 // Register registers a pipeline under the provided ID string.
 func Register(typeID string, p *Pipeline) {
+	mutex.Lock()
 	registry[typeID] = p
+	mutex.Unlock()
+}
+
+// Unregister removes the pipeline registered under the provided ID string.
+func Unregister(typeID string) {
+	mutex.Lock()
+	delete(registry, typeID)
+	mutex.Unlock()
+}
+
+// Replace atomically registers the pipeline under the provided ID string,
+// replacing any previously registered pipeline. Requests in flight on the
+// previous pipeline finish on it, after which it is closed, unless it remains
+// registered under another ID. If the provided pipeline is nil, the ID is
+// unregistered. Replace blocks until the previous pipeline is closed.
+func Replace(typeID string, p *Pipeline) {
+	mutex.Lock()
+	prev, ok := registry[typeID]
+	if p != nil {
+		registry[typeID] = p
+	} else {
+		delete(registry, typeID)
+	}
+	shared := ok && isRegistered(prev)
+	mutex.Unlock()
+	if ok && !shared {
+		prev.Close()
+	}
+}
+
+// isRegistered returns whether the pipeline is registered under any ID. The
+// registry mutex must be held by the caller.
+func isRegistered(p *Pipeline) bool {
+	for _, registered := range registry {
+		if registered == p {
+			return true
+		}
+	}
+	return false
 }
 
 // GetPipeline retrieves the pipeline registered under the provided ID string.
 func GetPipeline(id string) (*Pipeline, error) {
+	mutex.RLock()
+	p, ok := registry[id]
+	mutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("Pipeline ID of '%s' is not recognized", id)
+	}
+	return p, nil
+}
+
+// acquirePipeline retrieves the pipeline registered under the provided ID
+// string and tracks a request in flight on it. The request must be ended once
+// finished.
+func acquirePipeline(id string) (*Pipeline, error) {
+	mutex.RLock()
+	defer mutex.RUnlock()
 	p, ok := registry[id]
 	if !ok {
 		return nil, fmt.Errorf("Pipeline ID of '%s' is not recognized", id)
 	}
+	err := p.begin()
+	if err != nil {
+		return nil, err
+	}
 	return p, nil
 }
+
+// Pipelines returns all registered pipelines by their ID string.
+func Pipelines() map[string]*Pipeline {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	pipelines := make(map[string]*Pipeline, len(registry))
+	for id, p := range registry {
+		pipelines[id] = p
+	}
+	return pipelines
+}
@@ -0,0 +1,80 @@
+package veldt_test
+
+import (
+	"github.com/unchartedsoftware/veldt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("registry", func() {
+
+	AfterEach(func() {
+		veldt.Unregister("test")
+	})
+
+	Describe("Register", func() {
+		It("should register the pipeline under the provided ID", func() {
+			pipeline := newTestPipeline()
+			veldt.Register("test", pipeline)
+			p, err := veldt.GetPipeline("test")
+			Expect(err).To(BeNil())
+			Expect(p).To(Equal(pipeline))
+		})
+	})
+
+	Describe("Unregister", func() {
+		It("should remove the pipeline registered under the provided ID", func() {
+			veldt.Register("test", newTestPipeline())
+			veldt.Unregister("test")
+			_, err := veldt.GetPipeline("test")
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Pipelines", func() {
+		It("should return all registered pipelines", func() {
+			pipeline := newTestPipeline()
+			veldt.Register("test", pipeline)
+			pipelines := veldt.Pipelines()
+			Expect(pipelines).To(HaveKeyWithValue("test", pipeline))
+		})
+	})
+	Describe("Replace", func() {
+		It("should register the pipeline and close the replaced one", func() {
+			prev := newTestPipeline()
+			next := newTestPipeline()
+			veldt.Register("test", prev)
+			veldt.Replace("test", next)
+			p, err := veldt.GetPipeline("test")
+			Expect(err).To(BeNil())
+			Expect(p).To(BeIdenticalTo(next))
+			Expect(prev.Generate(&veldt.TileRequest{})).NotTo(BeNil())
+		})
+		It("should not close the replaced pipeline if registered under another ID", func() {
+			tile := &blockingTile{
+				started: make(chan bool),
+				release: make(chan bool),
+			}
+			prev := newBlockingPipeline(tile)
+			veldt.Register("test", prev)
+			veldt.Register("other", prev)
+			defer veldt.Unregister("other")
+			veldt.Replace("test", newTestPipeline())
+			req := newBlockingRequest(prev)
+			generated := make(chan error)
+			go func() {
+				generated <- prev.Generate(req)
+			}()
+			<-tile.started
+			tile.release <- true
+			Expect(<-generated).To(BeNil())
+		})
+		It("should unregister the ID if the pipeline is nil", func() {
+			veldt.Register("test", newTestPipeline())
+			veldt.Replace("test", nil)
+			_, err := veldt.GetPipeline("test")
+			Expect(err).NotTo(BeNil())
+		})
+	})
+})
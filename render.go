@@ -0,0 +1,179 @@
+package veldt
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+// RenderTile represents a tile type which renders the bins of another
+// heatmap tile type of the pipeline into a PNG image.
+//
+// Ex:
+//     {
+//         "tile": {
+//             "png": {
+//                 "tile": {
+//                     "heatmap": { ... }
+//                 },
+//                 "ramp": "viridis",
+//                 "transform": "log",
+//                 "meta": {
+//                     "default": {}
+//                 },
+//                 "field": "price"
+//             }
+//         }
+//     }
+//
+// The value range is taken from the explicit `min` and `max`, then from the
+// extrema of the `field` returned by the `meta` request, and otherwise from
+// the bins of the tile.
+type RenderTile struct {
+	tile.Render
+	Tile    Tile
+	Meta    Meta
+	Field   string
+	getTile func(string, interface{}) (Tile, error)
+	getMeta func(string, interface{}) (Meta, error)
+}
+
+// NewRenderTile instantiates and returns a new render tile type for the
+// provided pipeline.
+func NewRenderTile(p *Pipeline) TileCtor {
+	return func() (Tile, error) {
+		return &RenderTile{
+			getTile: p.GetTile,
+			getMeta: p.GetMeta,
+		}, nil
+	}
+}
+
+// Parse parses the provided JSON object and populates the tiles attributes.
+func (r *RenderTile) Parse(params map[string]interface{}) error {
+	// get the wrapped tile
+	id, args, ok := json.GetRandomChild(params, "tile")
+	if !ok {
+		return fmt.Errorf("`tile` parameter missing from tile")
+	}
+	wrapped, err := r.getTile(id, args)
+	if err != nil {
+		return err
+	}
+	if _, ok := wrapped.(tile.BinDecoder); !ok {
+		return fmt.Errorf("`%s` tile does not produce bins", id)
+	}
+	// get the meta to take the value range from
+	var meta Meta
+	field, hasField := json.GetString(params, "field")
+	if json.Exists(params, "meta") {
+		id, args, ok := json.GetRandomChild(params, "meta")
+		if !ok {
+			return fmt.Errorf("`meta` parameter does not contain a meta type")
+		}
+		if !hasField {
+			return fmt.Errorf("`field` parameter missing from tile")
+		}
+		meta, err = r.getMeta(id, args)
+		if err != nil {
+			return err
+		}
+	}
+	err = r.Render.Parse(params)
+	if err != nil {
+		return err
+	}
+	r.Tile = wrapped
+	r.Meta = meta
+	r.Field = field
+	return nil
+}
+
+// Params returns the parameters accepted by the tile.
+func (r *RenderTile) Params() []json.Param {
+	return append([]json.Param{
+		{Key: "tile", Type: json.TypeObject, Required: true},
+		{Key: "meta", Type: json.TypeObject},
+		{Key: "field", Type: json.TypeString},
+	}, r.Render.Params()...)
+}
+
+// Create generates a tile from the provided URI, tile coordinate and query
+// parameters.
+func (r *RenderTile) Create(uri string, coord *binning.TileCoord, query Query) ([]byte, error) {
+	// generate the wrapped tile
+	data, err := r.Tile.Create(uri, coord, query)
+	if err != nil {
+		return nil, err
+	}
+	bins, err := r.Tile.(tile.BinDecoder).Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	// get the value range
+	min, max := r.Extrema(bins)
+	if r.Meta != nil {
+		extrema, err := r.getExtrema(uri)
+		if err != nil {
+			return nil, err
+		}
+		if r.Min == nil {
+			min = extrema.Min
+		}
+		if r.Max == nil {
+			max = extrema.Max
+		}
+	}
+	return r.EncodePNG(bins, min, max)
+}
+
+func (r *RenderTile) getExtrema(uri string) (*binning.Extrema, error) {
+	data, err := r.Meta.Create(uri)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := json.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	field, ok := getFieldMeta(meta, r.Field)
+	if !ok {
+		return nil, fmt.Errorf("meta data has no extrema for `%s`", r.Field)
+	}
+	min, ok := json.GetFloat(field, "extrema", "min")
+	if !ok {
+		return nil, fmt.Errorf("meta data has no extrema for `%s`", r.Field)
+	}
+	max, ok := json.GetFloat(field, "extrema", "max")
+	if !ok {
+		return nil, fmt.Errorf("meta data has no extrema for `%s`", r.Field)
+	}
+	return &binning.Extrema{
+		Min: min,
+		Max: max,
+	}, nil
+}
+
+// getFieldMeta returns the meta data of the field, which is either keyed by
+// the field, or nested under a mapping type as by the elasticsearch meta.
+// Mapping types are searched in sorted order.
+func getFieldMeta(meta map[string]interface{}, field string) (map[string]interface{}, bool) {
+	if props, ok := json.GetChild(meta, field); ok && json.Exists(props, "extrema") {
+		return props, true
+	}
+	types := make([]string, 0, len(meta))
+	for typ := range meta {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	for _, typ := range types {
+		props, ok := json.GetChild(meta, typ, field)
+		if ok && json.Exists(props, "extrema") {
+			return props, true
+		}
+	}
+	return nil, false
+}
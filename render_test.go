@@ -0,0 +1,222 @@
+package veldt_test
+
+import (
+	"bytes"
+	"image/png"
+
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+type binTile struct {
+	tile.Heatmap
+	bins []float32
+}
+
+func (t *binTile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
+	return t.Heatmap.Encode(t.bins)
+}
+
+type plainTile struct{}
+
+func (t *plainTile) Parse(params map[string]interface{}) error {
+	return nil
+}
+
+func (t *plainTile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
+	return []byte{}, nil
+}
+
+type extremaMeta struct {
+	data string
+}
+
+func (m *extremaMeta) Parse(params map[string]interface{}) error {
+	return nil
+}
+
+func (m *extremaMeta) Create(uri string) ([]byte, error) {
+	return []byte(m.data), nil
+}
+
+var _ = Describe("RenderTile", func() {
+
+	var pipeline *veldt.Pipeline
+
+	BeforeEach(func() {
+		pipeline = veldt.NewPipeline()
+		pipeline.Tile("heatmap", func() (veldt.Tile, error) {
+			return &binTile{
+				bins: []float32{1, 2, 0, 4},
+			}, nil
+		})
+		pipeline.Tile("plain", func() (veldt.Tile, error) {
+			return &plainTile{}, nil
+		})
+		pipeline.Tile("png", veldt.NewRenderTile(pipeline))
+		pipeline.Meta("default", func() (veldt.Meta, error) {
+			return &extremaMeta{
+				data: `{"count":{"type":"long","extrema":{"min":0,"max":4}}}`,
+			}, nil
+		})
+		// the elasticsearch meta nests the fields under the mapping type
+		pipeline.Meta("elastic", func() (veldt.Meta, error) {
+			return &extremaMeta{
+				data: `{"datum":{"name":{"type":"string"},"count":{"type":"long","extrema":{"min":0,"max":4}}}}`,
+			}, nil
+		})
+	})
+
+	newRequest := func(params string) (*veldt.TileRequest, error) {
+		return pipeline.NewTileRequest(JSON(
+			`{
+				"uri": "test",
+				"coord": {
+					"x": 0,
+					"y": 0,
+					"z": 0
+				},
+				"tile": {
+					"png": ` + params + `
+				}
+			}`))
+	}
+
+	It("should render the wrapped tile into a PNG", func() {
+		req, err := newRequest(
+			`{
+				"tile": {
+					"heatmap": {}
+				},
+				"ramp": "greyscale"
+			}`)
+		Expect(err).To(BeNil())
+		data, err := req.Create()
+		Expect(err).To(BeNil())
+		img, err := png.Decode(bytes.NewReader(data))
+		Expect(err).To(BeNil())
+		Expect(img.Bounds().Dx()).To(Equal(2))
+		// the bins range from 1 to 4
+		r, _, _, _ := img.At(0, 1).RGBA()
+		Expect(r >> 8).To(Equal(uint32(0)))
+		r, _, _, _ = img.At(1, 0).RGBA()
+		Expect(r >> 8).To(Equal(uint32(255)))
+	})
+
+	It("should take the value range from the meta", func() {
+		req, err := newRequest(
+			`{
+				"tile": {
+					"heatmap": {}
+				},
+				"ramp": "greyscale",
+				"meta": {
+					"default": {}
+				},
+				"field": "count"
+			}`)
+		Expect(err).To(BeNil())
+		data, err := req.Create()
+		Expect(err).To(BeNil())
+		img, err := png.Decode(bytes.NewReader(data))
+		Expect(err).To(BeNil())
+		// the meta ranges from 0 to 4
+		r, _, _, _ := img.At(0, 1).RGBA()
+		Expect(r >> 8).To(Equal(uint32(64)))
+	})
+
+	It("should take the value range from the fields of a mapping type", func() {
+		req, err := newRequest(
+			`{
+				"tile": {
+					"heatmap": {}
+				},
+				"ramp": "greyscale",
+				"meta": {
+					"elastic": {}
+				},
+				"field": "count"
+			}`)
+		Expect(err).To(BeNil())
+		data, err := req.Create()
+		Expect(err).To(BeNil())
+		img, err := png.Decode(bytes.NewReader(data))
+		Expect(err).To(BeNil())
+		r, _, _, _ := img.At(0, 1).RGBA()
+		Expect(r >> 8).To(Equal(uint32(64)))
+	})
+
+	It("should return an error if the field has no extrema", func() {
+		req, err := newRequest(
+			`{
+				"tile": {
+					"heatmap": {}
+				},
+				"meta": {
+					"elastic": {}
+				},
+				"field": "name"
+			}`)
+		Expect(err).To(BeNil())
+		_, err = req.Create()
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should return an error if the wrapped tile is not recognized", func() {
+		_, err := newRequest(
+			`{
+				"tile": {
+					"unknown": {}
+				}
+			}`)
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should decode the bins with the encoding of the wrapped tile", func() {
+		req, err := newRequest(
+			`{
+				"tile": {
+					"heatmap": {
+						"encoding": "uint32"
+					}
+				},
+				"ramp": "greyscale"
+			}`)
+		Expect(err).To(BeNil())
+		data, err := req.Create()
+		Expect(err).To(BeNil())
+		img, err := png.Decode(bytes.NewReader(data))
+		Expect(err).To(BeNil())
+		r, _, _, _ := img.At(1, 0).RGBA()
+		Expect(r >> 8).To(Equal(uint32(255)))
+	})
+
+	It("should return an error if the wrapped tile does not produce bins", func() {
+		_, err := newRequest(
+			`{
+				"tile": {
+					"plain": {}
+				}
+			}`)
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should return an error if the `field` is missing for the meta", func() {
+		_, err := newRequest(
+			`{
+				"tile": {
+					"heatmap": {}
+				},
+				"meta": {
+					"default": {}
+				}
+			}`)
+		Expect(err).NotTo(BeNil())
+	})
+
+})
@@ -0,0 +1,185 @@
+package veldt
+
+import (
+	"sort"
+
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+const (
+	schemaVersion = "http://json-schema.org/draft-07/schema#"
+)
+
+// Describer represents an interface for tile, meta and query types that can
+// describe the parameters they accept. Types implementing it have their
+// parameters validated before parsing and are included in the pipeline
+// schema.
+type Describer interface {
+	Params() []json.Param
+}
+
+// Schema returns a JSON schema describing the tile and meta request language
+// supported by the pipeline, covering every registered tile, meta and query
+// type.
+func (p *Pipeline) Schema() map[string]interface{} {
+	definitions := map[string]interface{}{
+		"coord": json.ObjectSchema([]json.Param{
+			{Key: "x", Type: json.TypeInteger, Required: true},
+			{Key: "y", Type: json.TypeInteger, Required: true},
+			{Key: "z", Type: json.TypeInteger, Required: true},
+		}),
+		"tile":  typeSchema(p.tileSchemas()),
+		"meta":  typeSchema(p.metaSchemas()),
+		"query": typeSchema(p.querySchemas()),
+		"tileRequest": map[string]interface{}{
+			"type": json.TypeObject,
+			"properties": map[string]interface{}{
+				"uri":   map[string]interface{}{"type": json.TypeString},
+				"coord": map[string]interface{}{"$ref": "#/definitions/coord"},
+				"tile":  map[string]interface{}{"$ref": "#/definitions/tile"},
+				"query": p.expressionRef(),
+			},
+			"required": []interface{}{"uri", "coord", "tile"},
+		},
+		"metaRequest": map[string]interface{}{
+			"type": json.TypeObject,
+			"properties": map[string]interface{}{
+				"uri":  map[string]interface{}{"type": json.TypeString},
+				"meta": map[string]interface{}{"$ref": "#/definitions/meta"},
+			},
+			"required": []interface{}{"uri", "meta"},
+		},
+	}
+	// only add boolean expressions if operators are supported
+	operators := p.operators()
+	if len(operators) > 0 {
+		definitions["operator"] = map[string]interface{}{
+			"type": json.TypeString,
+			"enum": operators,
+		}
+		definitions["expression"] = map[string]interface{}{
+			"type": json.TypeArray,
+			"items": map[string]interface{}{
+				"anyOf": []interface{}{
+					map[string]interface{}{"$ref": "#/definitions/query"},
+					map[string]interface{}{"$ref": "#/definitions/operator"},
+					map[string]interface{}{"$ref": "#/definitions/expression"},
+				},
+			},
+		}
+	}
+	return map[string]interface{}{
+		"$schema":     schemaVersion,
+		"definitions": definitions,
+		"oneOf": []interface{}{
+			map[string]interface{}{"$ref": "#/definitions/tileRequest"},
+			map[string]interface{}{"$ref": "#/definitions/metaRequest"},
+		},
+	}
+}
+
+func (p *Pipeline) operators() []interface{} {
+	var operators []interface{}
+	if p.binary != nil {
+		operators = append(operators, And, Or)
+	}
+	if p.unary != nil {
+		operators = append(operators, Not)
+	}
+	return operators
+}
+
+func (p *Pipeline) expressionRef() map[string]interface{} {
+	if len(p.operators()) == 0 {
+		return map[string]interface{}{"$ref": "#/definitions/query"}
+	}
+	return map[string]interface{}{
+		"anyOf": []interface{}{
+			map[string]interface{}{"$ref": "#/definitions/query"},
+			map[string]interface{}{"$ref": "#/definitions/expression"},
+		},
+	}
+}
+
+func (p *Pipeline) tileSchemas() map[string]map[string]interface{} {
+	schemas := make(map[string]map[string]interface{}, len(p.tiles))
+	for id, ctor := range p.tiles {
+		tile, err := ctor()
+		if err != nil {
+			schemas[id] = paramsSchema(nil)
+			continue
+		}
+		schemas[id] = paramsSchema(tile)
+	}
+	return schemas
+}
+
+func (p *Pipeline) metaSchemas() map[string]map[string]interface{} {
+	schemas := make(map[string]map[string]interface{}, len(p.metas))
+	for id, ctor := range p.metas {
+		meta, err := ctor()
+		if err != nil {
+			schemas[id] = paramsSchema(nil)
+			continue
+		}
+		schemas[id] = paramsSchema(meta)
+	}
+	return schemas
+}
+
+func (p *Pipeline) querySchemas() map[string]map[string]interface{} {
+	schemas := make(map[string]map[string]interface{}, len(p.queries))
+	for id, ctor := range p.queries {
+		query, err := ctor()
+		if err != nil {
+			schemas[id] = paramsSchema(nil)
+			continue
+		}
+		schemas[id] = paramsSchema(query)
+	}
+	return schemas
+}
+
+// typeSchema returns a schema that accepts an object with exactly one of the
+// provided type IDs as its key.
+func typeSchema(schemas map[string]map[string]interface{}) map[string]interface{} {
+	// sort ids so the schema is deterministic
+	ids := make([]string, 0, len(schemas))
+	for id := range schemas {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	options := make([]interface{}, len(ids))
+	for i, id := range ids {
+		options[i] = map[string]interface{}{
+			"type": json.TypeObject,
+			"properties": map[string]interface{}{
+				id: schemas[id],
+			},
+			"required":             []interface{}{id},
+			"additionalProperties": false,
+		}
+	}
+	return map[string]interface{}{
+		"oneOf": options,
+	}
+}
+
+func paramsSchema(typ interface{}) map[string]interface{} {
+	describer, ok := typ.(Describer)
+	if !ok {
+		// parameters are unknown, accept any object
+		return map[string]interface{}{
+			"type": json.TypeObject,
+		}
+	}
+	return json.ObjectSchema(describer.Params())
+}
+
+func validateParams(typ interface{}, params map[string]interface{}) error {
+	describer, ok := typ.(Describer)
+	if !ok {
+		return nil
+	}
+	return json.ValidateParams(params, describer.Params())
+}
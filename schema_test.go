@@ -0,0 +1,96 @@
+package veldt_test
+
+import (
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/query"
+	"github.com/unchartedsoftware/veldt/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+type testTile struct {
+	tile.Bivariate
+}
+
+func (t *testTile) Create(uri string, coord *binning.TileCoord, query veldt.Query) ([]byte, error) {
+	return nil, nil
+}
+
+func newTestPipeline() *veldt.Pipeline {
+	pipeline := veldt.NewPipeline()
+	pipeline.Tile("heatmap", func() (veldt.Tile, error) {
+		return &testTile{}, nil
+	})
+	pipeline.Query("exists", func() (veldt.Query, error) {
+		return &query.Exists{}, nil
+	})
+	return pipeline
+}
+
+var _ = Describe("Schema", func() {
+
+	var pipeline *veldt.Pipeline
+
+	BeforeEach(func() {
+		pipeline = newTestPipeline()
+	})
+
+	Describe("Schema", func() {
+		It("should describe the parameters of each registered type", func() {
+			schema := pipeline.Schema()
+			definitions := schema["definitions"].(map[string]interface{})
+			tiles := definitions["tile"].(map[string]interface{})["oneOf"].([]interface{})
+			Expect(len(tiles)).To(Equal(1))
+			heatmap := tiles[0].(map[string]interface{})["properties"].(map[string]interface{})["heatmap"].(map[string]interface{})
+			Expect(heatmap["required"]).To(ContainElement("xField"))
+			Expect(heatmap["required"]).To(ContainElement("yField"))
+			Expect(heatmap["properties"]).To(HaveKey("left"))
+			Expect(heatmap["properties"]).To(HaveKey("projection"))
+			queries := definitions["query"].(map[string]interface{})["oneOf"].([]interface{})
+			Expect(len(queries)).To(Equal(1))
+		})
+		It("should only describe boolean expressions if operators are registered", func() {
+			definitions := pipeline.Schema()["definitions"].(map[string]interface{})
+			Expect(definitions).NotTo(HaveKey("expression"))
+			pipeline.Binary(func() (veldt.Query, error) {
+				return &veldt.BinaryExpression{}, nil
+			})
+			definitions = pipeline.Schema()["definitions"].(map[string]interface{})
+			Expect(definitions).To(HaveKey("expression"))
+			Expect(definitions["operator"].(map[string]interface{})["enum"]).To(Equal([]interface{}{"AND", "OR"}))
+		})
+	})
+
+	Describe("GetTile", func() {
+		It("should validate the parameters against their description", func() {
+			_, err := pipeline.GetTile("heatmap", JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"left": 0,
+					"right": 256,
+					"bottom": 0,
+					"top": 256,
+					"resolution": "256"
+				}`))
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("`resolution`"))
+		})
+		It("should return the parsed tile if the parameters are valid", func() {
+			t, err := pipeline.GetTile("heatmap", JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"left": 0,
+					"right": 256,
+					"bottom": 0,
+					"top": 256
+				}`))
+			Expect(err).To(BeNil())
+			Expect(t.(*testTile).Resolution).To(Equal(256))
+		})
+	})
+})
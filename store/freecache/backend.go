@@ -0,0 +1,26 @@
+package freecache
+
+import (
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/config"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+func init() {
+	config.RegisterStore("freecache", NewConnectionFromParams)
+}
+
+// NewConnectionFromParams instantiates and returns a new freecache store
+// connection from the provided parameters.
+func NewConnectionFromParams(params map[string]interface{}) (veldt.StoreCtor, error) {
+	err := config.ValidateParams(params, []json.Param{
+		{Key: "size", Type: json.TypeInteger, Required: true},
+		{Key: "expiry", Type: json.TypeInteger, Default: 0},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewConnection(
+		json.GetIntDefault(params, 0, "size"),
+		json.GetIntDefault(params, 0, "expiry")), nil
+}
@@ -0,0 +1,30 @@
+package redis
+
+import (
+	"strconv"
+
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/config"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+func init() {
+	config.RegisterStore("redis", NewStoreFromParams)
+}
+
+// NewStoreFromParams instantiates and returns a new redis store connection
+// from the provided connection parameters.
+func NewStoreFromParams(params map[string]interface{}) (veldt.StoreCtor, error) {
+	err := config.ValidateParams(params, []json.Param{
+		{Key: "host", Type: json.TypeString, Default: "localhost"},
+		{Key: "port", Type: json.TypeInteger, Default: 6379},
+		{Key: "expiry", Type: json.TypeInteger, Default: -1},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(
+		json.GetStringDefault(params, "localhost", "host"),
+		strconv.Itoa(json.GetIntDefault(params, 6379, "port")),
+		json.GetIntDefault(params, -1, "expiry")), nil
+}
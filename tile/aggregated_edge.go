@@ -0,0 +1,70 @@
+package tile
+
+import (
+	"fmt"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+const (
+	defaultFlowResolution = 64
+)
+
+// Flow represents the summed weight of the edges from a source bin to a
+// destination bin. Bins are relative to the tile, so the destination bin of
+// an edge leaving the tile may fall outside of the range [0 : resolution).
+type Flow struct {
+	SrcX   int
+	SrcY   int
+	DstX   int
+	DstY   int
+	Weight float64
+}
+
+// AggregatedEdge represents a tile which groups edges by their source and
+// destination bins and returns a flow with the summed weight of each group.
+type AggregatedEdge struct {
+	MacroEdge
+	Resolution int
+}
+
+// Parse parses the provided JSON object and populates the structs attributes.
+func (e *AggregatedEdge) Parse(params map[string]interface{}) error {
+	// get resolution
+	resolution := json.GetIntDefault(params, defaultFlowResolution, "resolution")
+	if resolution <= 0 || resolution > int(binning.MaxTileResolution) {
+		return fmt.Errorf("`resolution` parameter must be within the range [1 : %d]", int(binning.MaxTileResolution))
+	}
+	e.Resolution = resolution
+	return e.MacroEdge.Parse(params)
+}
+
+// Params returns the parameters accepted by the tile.
+func (e *AggregatedEdge) Params() []json.Param {
+	params := []json.Param{
+		{Key: "resolution", Type: json.TypeInteger, Default: defaultFlowResolution},
+	}
+	return append(params, e.MacroEdge.Params()...)
+}
+
+// Encode will encode the flows in the same layout as a macro edge tile, with
+// each flow positioned between the centers of its source and destination
+// bins. The unprojector converts flows back into data coordinates for the
+// GeoJSON encoding.
+func (e *AggregatedEdge) Encode(flows []*Flow, unproject Unprojector) ([]byte, error) {
+	binSize := binning.MaxTileResolution / float64(e.Resolution)
+	center := func(bin int) float32 {
+		return float32(float64(bin)*binSize + binSize/2)
+	}
+	edges := make([]float32, len(flows)*6)
+	for i, flow := range flows {
+		edges[i*6] = center(flow.SrcX)
+		edges[i*6+1] = center(flow.SrcY)
+		edges[i*6+2] = float32(flow.Weight)
+		edges[i*6+3] = center(flow.DstX)
+		edges[i*6+4] = center(flow.DstY)
+		edges[i*6+5] = float32(flow.Weight)
+	}
+	return e.MacroEdge.Encode(edges, unproject)
+}
@@ -0,0 +1,85 @@
+package tile_test
+
+import (
+	"github.com/unchartedsoftware/veldt/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+var _ = Describe("AggregatedEdge", func() {
+
+	var edge *tile.AggregatedEdge
+
+	BeforeEach(func() {
+		edge = &tile.AggregatedEdge{}
+	})
+
+	Describe("Parse", func() {
+		It("should parse properties from the params argument", func() {
+			params := JSON(
+				`{
+					"resolution": 16,
+					"lod": 2
+				}`)
+			err := edge.Parse(params)
+			Expect(err).To(BeNil())
+			Expect(edge.Resolution).To(Equal(16))
+			Expect(edge.LOD).To(Equal(2))
+		})
+
+		It("should default the `resolution` to 64", func() {
+			err := edge.Parse(JSON(`{}`))
+			Expect(err).To(BeNil())
+			Expect(edge.Resolution).To(Equal(64))
+		})
+
+		It("should return an error if the `resolution` is out of range", func() {
+			err := edge.Parse(JSON(`{ "resolution": 0 }`))
+			Expect(err).NotTo(BeNil())
+			err = edge.Parse(JSON(`{ "resolution": 512 }`))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Encode", func() {
+		It("should encode each flow between the centers of its bins", func() {
+			err := edge.Parse(JSON(`{ "resolution": 4 }`))
+			Expect(err).To(BeNil())
+			bytes, err := edge.Encode([]*tile.Flow{
+				{SrcX: 0, SrcY: 1, DstX: 3, DstY: 2, Weight: 5},
+				{SrcX: 2, SrcY: 2, DstX: -1, DstY: 4, Weight: 1.5},
+			}, nil)
+			Expect(err).To(BeNil())
+			Expect(bytes).To(Equal(tile.EncodeFloat32([]float32{
+				32, 96, 5, 224, 160, 5,
+				160, 160, 1.5, -32, 288, 1.5,
+			})))
+		})
+
+		It("should encode the flows with the `encoding` parameter", func() {
+			err := edge.Parse(JSON(
+				`{
+					"resolution": 2,
+					"encoding": "geojson"
+				}`))
+			Expect(err).To(BeNil())
+			bytes, err := edge.Encode([]*tile.Flow{
+				{SrcX: 0, SrcY: 0, DstX: 1, DstY: 1, Weight: 2},
+			}, func(x, y float64) (float64, float64) {
+				return x, y
+			})
+			Expect(err).To(BeNil())
+			Expect(bytes).To(MatchJSON(`{
+				"type": "FeatureCollection",
+				"features": [{
+					"type": "Feature",
+					"geometry": {"type": "LineString", "coordinates": [[64, 64], [192, 192]]},
+					"properties": {"weight": 2}
+				}]
+			}`))
+		})
+	})
+
+})
@@ -0,0 +1,320 @@
+package tile
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+const (
+	// ArrowEncoding encodes tabular tile results as an Apache Arrow IPC
+	// stream.
+	ArrowEncoding = "arrow"
+
+	// arrow metadata version V5
+	arrowVersion = 4
+
+	// arrow message header types
+	arrowSchemaHeader      = 1
+	arrowRecordBatchHeader = 3
+
+	// arrow column types
+	arrowInt           = 2
+	arrowFloatingPoint = 3
+	arrowUtf8          = 5
+	arrowBool          = 6
+
+	// arrowJSON is encoded as a utf8 column of JSON values
+	arrowJSON = -1
+
+	arrowDoublePrecision = 2
+	arrowContinuation    = 0xFFFFFFFF
+)
+
+// Tabular represents the encoding of a tile whose results are tabular. The
+// results are encoded as JSON by default.
+type Tabular struct {
+	Encoding string
+}
+
+// Parse parses the provided JSON object and populates the tiles attributes.
+func (t *Tabular) Parse(params map[string]interface{}) error {
+	encoding := json.GetStringDefault(params, JSONEncoding, "encoding")
+	if encoding != JSONEncoding && encoding != ArrowEncoding {
+		return fmt.Errorf("`encoding` parameter `%s` is not recognized", encoding)
+	}
+	t.Encoding = encoding
+	return nil
+}
+
+// Params returns the parameters accepted by the tile.
+func (t *Tabular) Params() []json.Param {
+	return []json.Param{
+		{
+			Key:     "encoding",
+			Type:    json.TypeString,
+			Default: JSONEncoding,
+			Enum:    []interface{}{JSONEncoding, ArrowEncoding},
+		},
+	}
+}
+
+// Encode encodes the table as an Arrow IPC stream if requested, otherwise it
+// encodes the provided JSON result.
+func (t *Tabular) Encode(table *Table, result interface{}) ([]byte, error) {
+	if t.Encoding == ArrowEncoding {
+		return table.EncodeArrow()
+	}
+	return json.Marshal(result)
+}
+
+// Table represents rows of tile results whose schema is derived from the
+// fields of the rows. Numeric fields are encoded as int64 columns if every
+// value is an integer type, otherwise as float64 columns. String and boolean
+// fields are encoded as utf8 and bool columns, while objects, arrays and
+// fields of mixed types are encoded as utf8 columns of JSON.
+type Table struct {
+	columns []string
+	rows    []map[string]interface{}
+}
+
+// NewTable instantiates and returns a new table whose leading columns are
+// provided. Any other fields of the rows follow in sorted order.
+func NewTable(columns ...string) *Table {
+	return &Table{
+		columns: columns,
+	}
+}
+
+// AddRow adds a row to the table.
+func (t *Table) AddRow(row map[string]interface{}) {
+	t.rows = append(t.rows, row)
+}
+
+// SortBy stably sorts the rows by the string values of the provided column,
+// such that rows added by ranging over a map are encoded deterministically.
+func (t *Table) SortBy(column string) {
+	sort.SliceStable(t.rows, func(i, j int) bool {
+		a, _ := t.rows[i][column].(string)
+		b, _ := t.rows[j][column].(string)
+		return a < b
+	})
+}
+
+// Columns returns the columns of the table.
+func (t *Table) Columns() []string {
+	seen := make(map[string]bool)
+	columns := make([]string, 0, len(t.columns))
+	for _, column := range t.columns {
+		if !seen[column] {
+			seen[column] = true
+			columns = append(columns, column)
+		}
+	}
+	var rest []string
+	for _, row := range t.rows {
+		for field := range row {
+			if !seen[field] {
+				seen[field] = true
+				rest = append(rest, field)
+			}
+		}
+	}
+	sort.Strings(rest)
+	return append(columns, rest...)
+}
+
+// EncodeArrow encodes the table as an Arrow IPC stream, consisting of the
+// schema, a single record batch and the end of stream marker.
+func (t *Table) EncodeArrow() ([]byte, error) {
+	columns := t.Columns()
+	fields := make(fbTables, len(columns))
+	var nodes []byte
+	var buffers []byte
+	var body []byte
+	addBuffer := func(data []byte) {
+		buffers = appendUint64(buffers, uint64(len(body)))
+		buffers = appendUint64(buffers, uint64(len(data)))
+		body = append(body, data...)
+		for len(body)%8 != 0 {
+			body = append(body, 0)
+		}
+	}
+	for i, column := range columns {
+		typ := t.getColumnType(column)
+		fields[i] = arrowField(column, typ)
+		// write the validity bitmap
+		validity := make([]byte, bitmapSize(len(t.rows)))
+		nulls := 0
+		for j, row := range t.rows {
+			if row[column] != nil {
+				validity[j/8] |= 1 << uint(j%8)
+			} else {
+				nulls++
+			}
+		}
+		nodes = appendUint64(nodes, uint64(len(t.rows)))
+		nodes = appendUint64(nodes, uint64(nulls))
+		addBuffer(validity)
+		// write the values
+		switch typ {
+		case arrowInt:
+			values := make([]byte, 0, len(t.rows)*8)
+			for _, row := range t.rows {
+				val, _ := toInt64(row[column])
+				values = appendUint64(values, uint64(val))
+			}
+			addBuffer(values)
+		case arrowFloatingPoint:
+			values := make([]byte, 0, len(t.rows)*8)
+			for _, row := range t.rows {
+				val, _ := toFloat64(row[column])
+				values = appendUint64(values, math.Float64bits(val))
+			}
+			addBuffer(values)
+		case arrowBool:
+			values := make([]byte, bitmapSize(len(t.rows)))
+			for j, row := range t.rows {
+				if val, ok := row[column].(bool); ok && val {
+					values[j/8] |= 1 << uint(j%8)
+				}
+			}
+			addBuffer(values)
+		default:
+			offsets := make([]byte, 0, (len(t.rows)+1)*4)
+			var data []byte
+			offsets = appendUint32(offsets, 0)
+			for _, row := range t.rows {
+				val := row[column]
+				if val != nil {
+					str, ok := val.(string)
+					if !ok || typ != arrowUtf8 {
+						marshalled, err := json.Marshal(val)
+						if err != nil {
+							return nil, err
+						}
+						str = string(marshalled)
+					}
+					data = append(data, str...)
+				}
+				offsets = appendUint32(offsets, uint32(len(data)))
+			}
+			addBuffer(offsets)
+			addBuffer(data)
+		}
+	}
+	// write the schema message
+	schema := fbTable{
+		fbShort(0),
+		fbRef(fields),
+	}
+	bytes := appendArrowMessage(nil, arrowSchemaHeader, schema, nil)
+	// write the record batch message
+	batch := fbTable{
+		fbLong(int64(len(t.rows))),
+		fbRef(fbStructs{data: nodes, count: len(columns)}),
+		fbRef(fbStructs{data: buffers, count: len(buffers) / 16}),
+	}
+	bytes = appendArrowMessage(bytes, arrowRecordBatchHeader, batch, body)
+	// write the end of stream marker
+	bytes = appendUint32(bytes, arrowContinuation)
+	return appendUint32(bytes, 0), nil
+}
+
+// getColumnType returns the arrow type of the column, or arrowJSON if the
+// values are objects, arrays or of mixed types.
+func (t *Table) getColumnType(column string) int {
+	typ := 0
+	for _, row := range t.rows {
+		val := row[column]
+		if val == nil {
+			continue
+		}
+		var next int
+		if _, ok := toInt64(val); ok {
+			next = arrowInt
+		} else if _, ok := toFloat64(val); ok {
+			next = arrowFloatingPoint
+		} else if _, ok := val.(bool); ok {
+			next = arrowBool
+		} else if _, ok := val.(string); ok {
+			next = arrowUtf8
+		} else {
+			return arrowJSON
+		}
+		switch {
+		case typ == 0 || typ == next:
+			typ = next
+		case isArrowNumber(typ) && isArrowNumber(next):
+			typ = arrowFloatingPoint
+		default:
+			return arrowJSON
+		}
+	}
+	if typ == 0 {
+		return arrowUtf8
+	}
+	return typ
+}
+
+func isArrowNumber(typ int) bool {
+	return typ == arrowInt || typ == arrowFloatingPoint
+}
+
+func arrowField(name string, typ int) fbTable {
+	var typeTable fbTable
+	switch typ {
+	case arrowInt:
+		typeTable = fbTable{fbInt(64), fbBool(true)}
+	case arrowFloatingPoint:
+		typeTable = fbTable{fbShort(arrowDoublePrecision)}
+	default:
+		typeTable = fbTable{}
+	}
+	if typ == arrowJSON {
+		typ = arrowUtf8
+	}
+	return fbTable{
+		fbRef(name),
+		fbBool(true),
+		fbByte(uint8(typ)),
+		fbRef(typeTable),
+		{},
+		fbRef(fbTables{}),
+	}
+}
+
+// appendArrowMessage appends an encapsulated message, consisting of the
+// continuation marker, the length of the flatbuffer metadata, the metadata
+// and the body.
+func appendArrowMessage(bytes []byte, header uint8, table fbTable, body []byte) []byte {
+	message := fbTable{
+		fbShort(arrowVersion),
+		fbByte(header),
+		fbRef(table),
+		fbLong(int64(len(body))),
+	}
+	metadata := (&fbBuilder{}).finish(message)
+	bytes = appendUint32(bytes, arrowContinuation)
+	bytes = appendUint32(bytes, uint32(len(metadata)))
+	bytes = append(bytes, metadata...)
+	return append(bytes, body...)
+}
+
+func toInt64(val interface{}) (int64, bool) {
+	switch v := val.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint32:
+		return int64(v), true
+	case uint64:
+		return int64(v), true
+	}
+	return 0, false
+}
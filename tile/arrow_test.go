@@ -0,0 +1,339 @@
+package tile_test
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"math"
+
+	"github.com/unchartedsoftware/veldt/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+// arrowColumn represents a column read back from an Arrow IPC stream.
+type arrowColumn struct {
+	name      string
+	typ       byte
+	bitWidth  int32
+	precision int16
+	nulls     int64
+	values    []interface{}
+}
+
+// fbReader reads the fields of little endian flatbuffer tables.
+type fbReader []byte
+
+func (b fbReader) uint16(pos int) int {
+	return int(binary.LittleEndian.Uint16(b[pos:]))
+}
+
+func (b fbReader) uint32(pos int) int {
+	return int(binary.LittleEndian.Uint32(b[pos:]))
+}
+
+func (b fbReader) root() int {
+	return b.uint32(0)
+}
+
+func (b fbReader) field(table int, id int) int {
+	vtable := table - int(int32(binary.LittleEndian.Uint32(b[table:])))
+	if 4+2*id >= b.uint16(vtable) {
+		return 0
+	}
+	offset := b.uint16(vtable + 4 + 2*id)
+	if offset == 0 {
+		return 0
+	}
+	return table + offset
+}
+
+func (b fbReader) ref(table int, id int) int {
+	pos := b.field(table, id)
+	Expect(pos).NotTo(Equal(0))
+	return pos + b.uint32(pos)
+}
+
+func (b fbReader) string(table int, id int) string {
+	pos := b.ref(table, id)
+	return string(b[pos+4 : pos+4+b.uint32(pos)])
+}
+
+func (b fbReader) byte(table int, id int) byte {
+	pos := b.field(table, id)
+	if pos == 0 {
+		return 0
+	}
+	return b[pos]
+}
+
+func (b fbReader) int64(table int, id int) int64 {
+	pos := b.field(table, id)
+	if pos == 0 {
+		return 0
+	}
+	Expect(pos % 8).To(Equal(0))
+	return int64(binary.LittleEndian.Uint64(b[pos:]))
+}
+
+// readArrowMessage reads an encapsulated message from the stream, returning
+// the metadata, the body and the remaining stream.
+func readArrowMessage(bytes []byte) (fbReader, []byte, []byte) {
+	Expect(binary.LittleEndian.Uint32(bytes[0:4])).To(Equal(uint32(0xFFFFFFFF)))
+	length := int(binary.LittleEndian.Uint32(bytes[4:8]))
+	Expect(length % 8).To(Equal(0))
+	metadata := fbReader(bytes[8 : 8+length])
+	message := metadata.root()
+	Expect(metadata.uint16(metadata.field(message, 0))).To(Equal(4))
+	bodyLength := int(metadata.int64(message, 3))
+	Expect(bodyLength % 8).To(Equal(0))
+	body := bytes[8+length : 8+length+bodyLength]
+	return metadata, body, bytes[8+length+bodyLength:]
+}
+
+// readArrow reads the columns of an Arrow IPC stream containing a schema and
+// a single record batch.
+func readArrow(bytes []byte) ([]arrowColumn, int64) {
+	// read the schema
+	metadata, body, rest := readArrowMessage(bytes)
+	message := metadata.root()
+	Expect(metadata.byte(message, 1)).To(Equal(byte(1)))
+	Expect(body).To(BeEmpty())
+	schema := metadata.ref(message, 2)
+	fields := metadata.ref(schema, 1)
+	columns := make([]arrowColumn, metadata.uint32(fields))
+	for i := range columns {
+		pos := fields + 4 + 4*i
+		field := pos + metadata.uint32(pos)
+		columns[i].name = metadata.string(field, 0)
+		columns[i].typ = metadata.byte(field, 2)
+		typ := metadata.ref(field, 3)
+		switch columns[i].typ {
+		case 2:
+			columns[i].bitWidth = int32(metadata.uint32(metadata.field(typ, 0)))
+		case 3:
+			columns[i].precision = int16(metadata.uint16(metadata.field(typ, 0)))
+		}
+	}
+	// read the record batch
+	metadata, body, rest = readArrowMessage(rest)
+	message = metadata.root()
+	Expect(metadata.byte(message, 1)).To(Equal(byte(3)))
+	batch := metadata.ref(message, 2)
+	length := metadata.int64(batch, 0)
+	nodes := metadata.ref(batch, 1)
+	Expect(metadata.uint32(nodes)).To(Equal(len(columns)))
+	Expect((nodes + 4) % 8).To(Equal(0))
+	buffers := metadata.ref(batch, 2)
+	Expect((buffers + 4) % 8).To(Equal(0))
+	buffer := func(index int) []byte {
+		pos := buffers + 4 + 16*index
+		offset := binary.LittleEndian.Uint64(metadata[pos:])
+		size := binary.LittleEndian.Uint64(metadata[pos+8:])
+		Expect(offset % 8).To(Equal(uint64(0)))
+		return body[offset : offset+size]
+	}
+	index := 0
+	for i := range columns {
+		node := nodes + 4 + 16*i
+		Expect(int64(binary.LittleEndian.Uint64(metadata[node:]))).To(Equal(length))
+		columns[i].nulls = int64(binary.LittleEndian.Uint64(metadata[node+8:]))
+		validity := buffer(index)
+		values := buffer(index + 1)
+		index += 2
+		var offsets []byte
+		if columns[i].typ == 5 {
+			offsets = values
+			values = buffer(index)
+			index++
+		}
+		columns[i].values = make([]interface{}, length)
+		for j := range columns[i].values {
+			if validity[j/8]&(1<<uint(j%8)) == 0 {
+				continue
+			}
+			switch columns[i].typ {
+			case 2:
+				columns[i].values[j] = int64(binary.LittleEndian.Uint64(values[j*8:]))
+			case 3:
+				columns[i].values[j] = math.Float64frombits(binary.LittleEndian.Uint64(values[j*8:]))
+			case 5:
+				start := binary.LittleEndian.Uint32(offsets[j*4:])
+				end := binary.LittleEndian.Uint32(offsets[j*4+4:])
+				columns[i].values[j] = string(values[start:end])
+			case 6:
+				columns[i].values[j] = values[j/8]&(1<<uint(j%8)) != 0
+			}
+		}
+	}
+	Expect(index).To(Equal(metadata.uint32(buffers)))
+	// read the end of stream marker
+	Expect(rest).To(Equal([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0}))
+	return columns, length
+}
+
+var _ = Describe("Table", func() {
+
+	It("should encode rows as an arrow stream", func() {
+		table := tile.NewTable("term", "count")
+		table.AddRow(map[string]interface{}{
+			"term":  "a",
+			"count": uint32(3),
+			"score": 0.5,
+			"flag":  true,
+		})
+		table.AddRow(map[string]interface{}{
+			"term":  "bb",
+			"count": uint32(4),
+			"score": 2,
+			"tags":  []interface{}{"x"},
+		})
+		bytes, err := table.EncodeArrow()
+		Expect(err).To(BeNil())
+		columns, length := readArrow(bytes)
+		Expect(length).To(Equal(int64(2)))
+		Expect(columns).To(Equal([]arrowColumn{
+			{name: "term", typ: 5, values: []interface{}{"a", "bb"}},
+			{name: "count", typ: 2, bitWidth: 64, values: []interface{}{int64(3), int64(4)}},
+			{name: "flag", typ: 6, nulls: 1, values: []interface{}{true, nil}},
+			{name: "score", typ: 3, precision: 2, values: []interface{}{0.5, 2.0}},
+			{name: "tags", typ: 5, nulls: 1, values: []interface{}{nil, `["x"]`}},
+		}))
+	})
+
+	It("should encode mixed types as JSON", func() {
+		table := tile.NewTable()
+		table.AddRow(map[string]interface{}{"value": "a"})
+		table.AddRow(map[string]interface{}{"value": 1})
+		bytes, err := table.EncodeArrow()
+		Expect(err).To(BeNil())
+		columns, _ := readArrow(bytes)
+		Expect(columns).To(Equal([]arrowColumn{
+			{name: "value", typ: 5, values: []interface{}{`"a"`, "1"}},
+		}))
+	})
+
+	It("should sort the rows by a column", func() {
+		table := tile.NewTable("term", "timestamp")
+		table.AddRow(map[string]interface{}{"term": "b", "timestamp": 1})
+		table.AddRow(map[string]interface{}{"term": "a", "timestamp": 2})
+		table.AddRow(map[string]interface{}{"term": "b", "timestamp": 0})
+		table.AddRow(map[string]interface{}{"term": "a", "timestamp": 1})
+		table.SortBy("term")
+		bytes, err := table.EncodeArrow()
+		Expect(err).To(BeNil())
+		columns, _ := readArrow(bytes)
+		Expect(columns[0].values).To(Equal([]interface{}{"a", "a", "b", "b"}))
+		Expect(columns[1].values).To(Equal([]interface{}{int64(2), int64(1), int64(1), int64(0)}))
+	})
+
+	It("should match the golden arrow stream", func() {
+		// should the encoding change, regenerate the fixture and check that
+		// it reads with pyarrow.ipc.open_stream before committing it
+		counts := map[string]uint32{
+			"cat":   3,
+			"dog":   12,
+			"horse": 1,
+		}
+		table := tile.NewTable("term", "count")
+		for term, count := range counts {
+			table.AddRow(map[string]interface{}{
+				"term":  term,
+				"count": count,
+			})
+		}
+		table.SortBy("term")
+		bytes, err := table.EncodeArrow()
+		Expect(err).To(BeNil())
+		golden, err := ioutil.ReadFile("testdata/term_count.arrows")
+		Expect(err).To(BeNil())
+		Expect(bytes).To(Equal(golden))
+	})
+
+	It("should encode an empty table", func() {
+		bytes, err := tile.NewTable("term", "count").EncodeArrow()
+		Expect(err).To(BeNil())
+		columns, length := readArrow(bytes)
+		Expect(length).To(Equal(int64(0)))
+		Expect(columns).To(HaveLen(2))
+		Expect(columns[0].name).To(Equal("term"))
+		Expect(columns[1].name).To(Equal("count"))
+	})
+
+})
+
+var _ = Describe("Tabular", func() {
+
+	var table *tile.Table
+	var result map[string]interface{}
+
+	BeforeEach(func() {
+		table = tile.NewTable("term", "count")
+		table.AddRow(map[string]interface{}{"term": "a", "count": 1})
+		result = map[string]interface{}{"a": 1}
+	})
+
+	It("should encode JSON by default", func() {
+		t := &tile.Tabular{}
+		err := t.Parse(map[string]interface{}{})
+		Expect(err).To(BeNil())
+		bytes, err := t.Encode(table, result)
+		Expect(err).To(BeNil())
+		Expect(bytes).To(MatchJSON(`{"a":1}`))
+	})
+
+	It("should encode an arrow stream if requested", func() {
+		t := &tile.Tabular{}
+		err := t.Parse(map[string]interface{}{
+			"encoding": "arrow",
+		})
+		Expect(err).To(BeNil())
+		bytes, err := t.Encode(table, result)
+		Expect(err).To(BeNil())
+		columns, _ := readArrow(bytes)
+		Expect(columns[0].values).To(Equal([]interface{}{"a"}))
+	})
+
+	It("should error on an unrecognized encoding", func() {
+		t := &tile.Tabular{}
+		err := t.Parse(map[string]interface{}{
+			"encoding": "csv",
+		})
+		Expect(err).NotTo(BeNil())
+	})
+
+})
+
+var _ = Describe("Micro", func() {
+
+	It("should encode points and hits as an arrow stream", func() {
+		micro := &tile.Micro{}
+		err := micro.Parse(map[string]interface{}{
+			"encoding": "arrow",
+		})
+		Expect(err).To(BeNil())
+		bytes, err := micro.Encode([]map[string]interface{}{
+			{"name": "a"},
+			{"name": "b"},
+		}, []float32{1, 2, 3, 4}, nil)
+		Expect(err).To(BeNil())
+		columns, _ := readArrow(bytes)
+		Expect(columns).To(Equal([]arrowColumn{
+			{name: "x", typ: 3, precision: 2, values: []interface{}{1.0, 3.0}},
+			{name: "y", typ: 3, precision: 2, values: []interface{}{2.0, 4.0}},
+			{name: "name", typ: 5, values: []interface{}{"a", "b"}},
+		}))
+	})
+
+	It("should error if the arrow encoding is requested with a lod", func() {
+		micro := &tile.Micro{}
+		err := micro.Parse(JSON(
+			`{
+				"encoding": "arrow",
+				"lod": 2
+			}`))
+		Expect(err).NotTo(BeNil())
+	})
+
+})
@@ -2,6 +2,7 @@ package tile
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/unchartedsoftware/veldt/binning"
@@ -15,9 +16,14 @@ const (
 
 // Bivariate represents the parameters required for any bivariate tile.
 type Bivariate struct {
-	XField       string
-	YField       string
-	Resolution   int
+	XField     string
+	YField     string
+	Resolution int
+	// Padding is the number of bins around the tile which are included by
+	// the tiling query and aggregations, such that the bins span
+	// PaddedResolution across each axis.
+	Padding      int
+	Projection   binning.Projection
 	tileBounds   *geometry.Bounds
 	globalBounds *geometry.Bounds
 }
@@ -41,19 +47,74 @@ func (b *Bivariate) Parse(params map[string]interface{}) error {
 	b.Resolution = resolution
 	// clear tile bounds
 	b.tileBounds = nil
-	// get the global bounds
-	b.globalBounds = &geometry.Bounds{}
-	return b.globalBounds.Parse(params)
+	// get the projection and global bounds
+	projection, bounds, err := parseProjection(params)
+	if err != nil {
+		return err
+	}
+	b.Projection = projection
+	b.globalBounds = bounds
+	return nil
+}
+
+// Params returns the parameters accepted by the tile.
+func (b *Bivariate) Params() []json.Param {
+	params := []json.Param{
+		{Key: "xField", Type: json.TypeString, Required: true},
+		{Key: "yField", Type: json.TypeString, Required: true},
+		{Key: "resolution", Type: json.TypeInteger, Default: 256},
+	}
+	return append(params, projectionParams()...)
+}
+
+// GetProjection returns the projection of the tile.
+func (b *Bivariate) GetProjection() binning.Projection {
+	return b.Projection
 }
 
 // TileBounds computes and returns the tile bounds for the provided tile coord.
 func (b *Bivariate) TileBounds(coord *binning.TileCoord) *geometry.Bounds {
 	if b.tileBounds == nil {
-		b.tileBounds = binning.GetTileBounds(coord, b.globalBounds)
+		b.tileBounds = b.Projection.TileBounds(coord)
 	}
 	return b.tileBounds
 }
 
+// PaddedResolution returns the number of bins across each axis of the tile,
+// including the padding.
+func (b *Bivariate) PaddedResolution() int {
+	return b.Resolution + 2*b.Padding
+}
+
+// QueryBounds returns the tile bounds for the provided tile coord padded by
+// the padding bins.
+func (b *Bivariate) QueryBounds(coord *binning.TileCoord) *geometry.Bounds {
+	if b.Padding == 0 {
+		return b.TileBounds(coord)
+	}
+	pad := b.PaddingFraction()
+	bottomLeft := b.Projection.Unproject(&binning.FractionalTileCoord{
+		X: float64(coord.X) - pad,
+		Y: float64(coord.Y) - pad,
+		Z: coord.Z,
+	})
+	topRight := b.Projection.Unproject(&binning.FractionalTileCoord{
+		X: float64(coord.X+1) + pad,
+		Y: float64(coord.Y+1) + pad,
+		Z: coord.Z,
+	})
+	return geometry.NewBounds(
+		bottomLeft.X,
+		topRight.X,
+		bottomLeft.Y,
+		topRight.Y)
+}
+
+// PaddingFraction returns the padding as a fraction of the tile.
+func (b *Bivariate) PaddingFraction() float64 {
+	return float64(b.Padding) / float64(b.Resolution)
+}
+
 // BinSizeX computes and returns the size of a bin across the x axis for the
 // provided tile coord.
 func (b *Bivariate) BinSizeX(coord *binning.TileCoord) float64 {
@@ -68,6 +129,9 @@ func (b *Bivariate) BinSizeY(coord *binning.TileCoord) float64 {
 
 // GetXBin given an x value, returns the corresponding bin.
 func (b *Bivariate) GetXBin(coord *binning.TileCoord, x float64) int {
+	if !isLinear(b.Projection) {
+		return b.getProjectedBin(b.GetX(coord, x))
+	}
 	bounds := b.TileBounds(coord)
 	binSize := b.BinSizeX(coord)
 	var bin int64
@@ -83,6 +147,10 @@ func (b *Bivariate) GetXBin(coord *binning.TileCoord, x float64) int {
 // [0 : 255) for the tile.
 func (b *Bivariate) GetX(coord *binning.TileCoord, x float64) float64 {
 	bounds := b.TileBounds(coord)
+	if !isLinear(b.Projection) {
+		tx, _ := project(b.Projection, coord, x, bounds.CenterY())
+		return tx
+	}
 	rang := bounds.RangeX()
 	if bounds.Left > bounds.Right {
 		return binning.MaxTileResolution - (((x - bounds.Right) / rang) * binning.MaxTileResolution)
@@ -92,6 +160,9 @@ func (b *Bivariate) GetX(coord *binning.TileCoord, x float64) float64 {
 
 // GetYBin given a y value, returns the corresponding bin.
 func (b *Bivariate) GetYBin(coord *binning.TileCoord, y float64) int {
+	if !isLinear(b.Projection) {
+		return b.getProjectedBin(b.GetY(coord, y))
+	}
 	bounds := b.TileBounds(coord)
 	binSize := b.BinSizeY(coord)
 	var bin int64
@@ -107,6 +178,10 @@ func (b *Bivariate) GetYBin(coord *binning.TileCoord, y float64) int {
 // [0 : 256) for the tile.
 func (b *Bivariate) GetY(coord *binning.TileCoord, y float64) float64 {
 	bounds := b.TileBounds(coord)
+	if !isLinear(b.Projection) {
+		_, ty := project(b.Projection, coord, bounds.CenterX(), y)
+		return ty
+	}
 	rang := bounds.RangeY()
 	if bounds.Bottom > bounds.Top {
 		return binning.MaxTileResolution - (((y - bounds.Top) / rang) * binning.MaxTileResolution)
@@ -123,12 +198,29 @@ func (b *Bivariate) GetXY(coord *binning.TileCoord, hit map[string]interface{})
 		return 0, 0, false
 	}
 	// convert to tile pixel coords in the range [0 - 256)
+	if !isLinear(b.Projection) {
+		tx, ty := project(b.Projection, coord, x, y)
+		return tx, ty, true
+	}
 	tx := b.GetX(coord, x)
 	ty := b.GetY(coord, y)
 	// return position in tile coords
 	return tx, ty, true
 }
 
+// Unprojector returns a function which converts a coord within the range of
+// [0 : 256) for the tile back into the corresponding data coordinate.
+func (b *Bivariate) Unprojector(coord *binning.TileCoord) Unprojector {
+	return func(x float64, y float64) (float64, float64) {
+		return unproject(b.Projection, coord, x, y)
+	}
+}
+
+func (b *Bivariate) getProjectedBin(pixel float64) int {
+	bin := math.Floor(pixel / binning.MaxTileResolution * float64(b.Resolution))
+	return b.clampBin(int64(bin))
+}
+
 func (b *Bivariate) clampBin(bin int64) int {
 	if bin > int64(b.Resolution)-1 {
 		return b.Resolution - 1
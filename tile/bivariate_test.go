@@ -101,6 +101,29 @@ var _ = Describe("Bivariate", func() {
 			err := bivariate.Parse(params)
 			Expect(err).NotTo(BeNil())
 		})
+
+		It("should not require bounds for a geographic `projection`", func() {
+			params := JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"projection": "mercator"
+				}`)
+			err := bivariate.Parse(params)
+			Expect(err).To(BeNil())
+			Expect(bivariate.Projection).To(BeAssignableToTypeOf(&binning.Mercator{}))
+		})
+
+		It("should return an error if the `projection` is not recognized", func() {
+			params := JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"projection": "unknown"
+				}`)
+			err := bivariate.Parse(params)
+			Expect(err).NotTo(BeNil())
+		})
 	})
 
 	Describe("TileBounds", func() {
@@ -128,6 +151,83 @@ var _ = Describe("Bivariate", func() {
 			Expect(bounds.Bottom).To(Equal(-1.0))
 			Expect(bounds.Top).To(Equal(1.0))
 		})
+
+		It("should return the tile bounds of the `projection`", func() {
+			params := JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"projection": "platecarree",
+					"resolution": 256
+				}`)
+			coord := &binning.TileCoord{
+				Z: 0,
+				X: 1,
+				Y: 0,
+			}
+			err := bivariate.Parse(params)
+			Expect(err).To(BeNil())
+			bounds := bivariate.TileBounds(coord)
+			Expect(bounds.Left).To(Equal(0.0))
+			Expect(bounds.Right).To(Equal(180.0))
+			Expect(bounds.Bottom).To(Equal(-90.0))
+			Expect(bounds.Top).To(Equal(90.0))
+		})
+	})
+
+	Describe("QueryBounds", func() {
+		It("should return the tile bounds if there is no padding", func() {
+			params := JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"left": -1.0,
+					"right": 1.0,
+					"bottom": -1.0,
+					"top": 1.0,
+					"resolution": 256
+				}`)
+			coord := &binning.TileCoord{
+				Z: 1,
+				X: 1,
+				Y: 0,
+			}
+			err := bivariate.Parse(params)
+			Expect(err).To(BeNil())
+			Expect(bivariate.PaddedResolution()).To(Equal(256))
+			Expect(bivariate.QueryBounds(coord)).To(Equal(bivariate.TileBounds(coord)))
+		})
+
+		It("should pad the tile bounds by the padding bins", func() {
+			params := JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"left": -1.0,
+					"right": 1.0,
+					"bottom": -1.0,
+					"top": 1.0,
+					"resolution": 8
+				}`)
+			coord := &binning.TileCoord{
+				Z: 1,
+				X: 1,
+				Y: 0,
+			}
+			err := bivariate.Parse(params)
+			Expect(err).To(BeNil())
+			bivariate.Padding = 2
+			Expect(bivariate.PaddedResolution()).To(Equal(12))
+			bounds := bivariate.QueryBounds(coord)
+			Expect(bounds.Left).To(BeNumerically("~", -0.25, 0.000001))
+			Expect(bounds.Right).To(BeNumerically("~", 1.25, 0.000001))
+			Expect(bounds.Bottom).To(BeNumerically("~", -1.25, 0.000001))
+			Expect(bounds.Top).To(BeNumerically("~", 0.25, 0.000001))
+			// the tile bounds are unchanged
+			tileBounds := bivariate.TileBounds(coord)
+			Expect(tileBounds.Left).To(Equal(0.0))
+			Expect(tileBounds.Right).To(Equal(1.0))
+		})
 	})
 
 	Describe("BinSizeX", func() {
@@ -238,6 +338,57 @@ var _ = Describe("Bivariate", func() {
 			Expect(binD).To(Equal(191))
 			Expect(binE).To(Equal(255))
 		})
+
+		It("should return the x and y bins of the `projection`", func() {
+			params := JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"projection": "mercator",
+					"resolution": 256
+				}`)
+			coord := &binning.TileCoord{
+				Z: 1,
+				X: 0,
+				Y: 1,
+			}
+			err := bivariate.Parse(params)
+			Expect(err).To(BeNil())
+			Expect(bivariate.GetXBin(coord, -180.0)).To(Equal(0))
+			Expect(bivariate.GetXBin(coord, -90.0)).To(Equal(128))
+			Expect(bivariate.GetYBin(coord, 0.0)).To(Equal(0))
+			Expect(bivariate.GetYBin(coord, 85.0)).To(Equal(255))
+		})
+
+		It("should preserve precision at zoom 20 for unit range bounds", func() {
+			params := JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"left": 0.0,
+					"right": 1.0,
+					"bottom": 0.0,
+					"top": 1.0,
+					"resolution": 256
+				}`)
+			coord := &binning.TileCoord{
+				Z: 20,
+				X: 524288,
+				Y: 524288,
+			}
+			err := bivariate.Parse(params)
+			Expect(err).To(BeNil())
+			bounds := bivariate.TileBounds(coord)
+			Expect(bounds.Left).To(Equal(0.5))
+			Expect(bounds.Right).To(Equal(0.5 + 1.0/1048576.0))
+			binSize := bivariate.BinSizeX(coord)
+			Expect(binSize).To(Equal(1.0 / 268435456.0))
+			Expect(bivariate.GetXBin(coord, 0.5)).To(Equal(0))
+			Expect(bivariate.GetXBin(coord, 0.5+1.5*binSize)).To(Equal(1))
+			Expect(bivariate.GetXBin(coord, 0.5+128.5*binSize)).To(Equal(128))
+			Expect(bivariate.GetXBin(coord, 0.5+255.5*binSize)).To(Equal(255))
+			Expect(bivariate.GetYBin(coord, 0.5+64.5*binSize)).To(Equal(64))
+		})
 	})
 
 	Describe("GetYBin", func() {
@@ -530,4 +681,52 @@ var _ = Describe("Bivariate", func() {
 			Expect(ok).To(Equal(false))
 		})
 	})
+
+	Describe("Unprojector", func() {
+		It("should return the data coordinate of the tile coord for left > right", func() {
+			params := JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"left": 1.0,
+					"right": -1.0,
+					"bottom": -1.0,
+					"top": 1.0
+				}`)
+			coord := &binning.TileCoord{
+				Z: 1,
+				X: 1,
+				Y: 0,
+			}
+			hit := JSON(`{ "x": -0.25, "y": -0.75 }`)
+			err := bivariate.Parse(params)
+			Expect(err).To(BeNil())
+			tx, ty, ok := bivariate.GetXY(coord, hit)
+			Expect(ok).To(Equal(true))
+			x, y := bivariate.Unprojector(coord)(tx, ty)
+			Expect(x).To(BeNumerically("~", -0.25, 0.000001))
+			Expect(y).To(BeNumerically("~", -0.75, 0.000001))
+		})
+		It("should return the data coordinate of the `projection`", func() {
+			params := JSON(
+				`{
+					"xField": "lon",
+					"yField": "lat",
+					"projection": "mercator"
+				}`)
+			coord := &binning.TileCoord{
+				Z: 4,
+				X: 3,
+				Y: 10,
+			}
+			hit := JSON(`{ "lon": -100.5, "lat": 40.25 }`)
+			err := bivariate.Parse(params)
+			Expect(err).To(BeNil())
+			tx, ty, ok := bivariate.GetXY(coord, hit)
+			Expect(ok).To(Equal(true))
+			lon, lat := bivariate.Unprojector(coord)(tx, ty)
+			Expect(lon).To(BeNumerically("~", -100.5, 0.000001))
+			Expect(lat).To(BeNumerically("~", 40.25, 0.000001))
+		})
+	})
 })
@@ -0,0 +1,105 @@
+package tile
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+const (
+	defaultClusterRadius = 32
+)
+
+// ClusterCell represents the data points of a single cell of a cluster tile.
+// The cell is the bin of the point within the tile, while the centroid is the
+// mean position of the points within the range of [0 : 256) for the tile.
+type ClusterCell struct {
+	X         int
+	Y         int
+	CentroidX float64
+	CentroidY float64
+	Count     uint64
+	Hits      []map[string]interface{}
+}
+
+// Cluster represents a tile which groups the data points into clusters,
+// returning the centroid and count of each cluster along with optional
+// representative hits. Clusters are the cells of a grid of `radius` pixels
+// aligned to the global pixel space, so that each cluster at a zoom level is
+// the union of the clusters of the next zoom level within it. Clusters are not
+// merged by distance, as supercluster does, so points either side of a cell
+// boundary fall into separate clusters however close they are, and the
+// centroids of neighbouring clusters may be nearer than `radius` pixels.
+type Cluster struct {
+	Radius int
+}
+
+// Parse parses the provided JSON object and populates the structs attributes.
+// The `resolution` parameter is rejected, as the cells are sized by the
+// `radius`.
+func (c *Cluster) Parse(params map[string]interface{}) error {
+	if json.Exists(params, "resolution") {
+		return fmt.Errorf("`resolution` parameter is not supported, cluster cells are sized by `radius`")
+	}
+	radius := json.GetIntDefault(params, defaultClusterRadius, "radius")
+	if radius <= 0 || radius > int(binning.MaxTileResolution) || radius&(radius-1) != 0 {
+		return fmt.Errorf("`radius` parameter must be a power of two no greater than %d", int(binning.MaxTileResolution))
+	}
+	c.Radius = radius
+	return nil
+}
+
+// Params returns the parameters accepted by the tile.
+func (c *Cluster) Params() []json.Param {
+	return []json.Param{
+		{Key: "radius", Type: json.TypeInteger, Default: defaultClusterRadius},
+		// representative hits are optional for clusters
+		{Key: "hitsCount", Type: json.TypeInteger, Default: 0},
+	}
+}
+
+// Resolution returns the number of cluster cells across each axis of the tile.
+func (c *Cluster) Resolution() int {
+	return int(binning.MaxTileResolution) / c.Radius
+}
+
+// Encode will encode the cells as an array of clusters, ordered by descending
+// count. Each cluster is identified by the zoom level and global position of
+// its cell, along with the cell containing it at the previous zoom level, so
+// that clients may animate clusters between zoom levels.
+func (c *Cluster) Encode(coord *binning.TileCoord, cells []*ClusterCell) ([]byte, error) {
+	// order the clusters so that the result is deterministic
+	sorted := make([]*ClusterCell, len(cells))
+	copy(sorted, cells)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		if sorted[i].Y != sorted[j].Y {
+			return sorted[i].Y < sorted[j].Y
+		}
+		return sorted[i].X < sorted[j].X
+	})
+	resolution := c.Resolution()
+	clusters := make([]map[string]interface{}, len(sorted))
+	for i, cell := range sorted {
+		x := int64(coord.X)*int64(resolution) + int64(cell.X)
+		y := int64(coord.Y)*int64(resolution) + int64(cell.Y)
+		cluster := map[string]interface{}{
+			"id":    fmt.Sprintf("%d/%d/%d", coord.Z, x, y),
+			"x":     float32(cell.CentroidX),
+			"y":     float32(cell.CentroidY),
+			"count": cell.Count,
+		}
+		if coord.Z > 0 {
+			cluster["parent"] = fmt.Sprintf("%d/%d/%d", coord.Z-1, x/2, y/2)
+		}
+		if cell.Hits != nil {
+			cluster["hits"] = cell.Hits
+		}
+		clusters[i] = cluster
+	}
+	return json.Marshal(clusters)
+}
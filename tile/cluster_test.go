@@ -0,0 +1,98 @@
+package tile_test
+
+import (
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+var _ = Describe("Cluster", func() {
+
+	var cluster *tile.Cluster
+
+	BeforeEach(func() {
+		cluster = &tile.Cluster{}
+	})
+
+	Describe("Parse", func() {
+		It("should parse properties from the params argument", func() {
+			params := JSON(
+				`{
+					"radius": 64
+				}`)
+			err := cluster.Parse(params)
+			Expect(err).To(BeNil())
+			Expect(cluster.Radius).To(Equal(64))
+			Expect(cluster.Resolution()).To(Equal(4))
+		})
+
+		It("should default the `radius` to 32", func() {
+			err := cluster.Parse(JSON(`{}`))
+			Expect(err).To(BeNil())
+			Expect(cluster.Radius).To(Equal(32))
+			Expect(cluster.Resolution()).To(Equal(8))
+		})
+
+		It("should return an error if the `radius` is not a power of two", func() {
+			err := cluster.Parse(JSON(`{ "radius": 40 }`))
+			Expect(err).NotTo(BeNil())
+			err = cluster.Parse(JSON(`{ "radius": 512 }`))
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should return an error if the `resolution` is provided", func() {
+			err := cluster.Parse(JSON(`{ "resolution": 64 }`))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Encode", func() {
+		It("should encode the cells as clusters ordered by count", func() {
+			err := cluster.Parse(JSON(`{ "radius": 128 }`))
+			Expect(err).To(BeNil())
+			bytes, err := cluster.Encode(&binning.TileCoord{Z: 2, X: 1, Y: 3}, []*tile.ClusterCell{
+				{X: 0, Y: 1, CentroidX: 10, CentroidY: 200, Count: 2},
+				{X: 1, Y: 0, CentroidX: 140.5, CentroidY: 60, Count: 5, Hits: []map[string]interface{}{
+					{"name": "a"},
+				}},
+			})
+			Expect(err).To(BeNil())
+			Expect(bytes).To(MatchJSON(`[
+				{"id": "2/3/6", "parent": "1/1/3", "x": 140.5, "y": 60, "count": 5, "hits": [{"name": "a"}]},
+				{"id": "2/2/7", "parent": "1/1/3", "x": 10, "y": 200, "count": 2}
+			]`))
+		})
+
+		It("should nest the clusters of each zoom level within the previous level", func() {
+			err := cluster.Parse(JSON(`{ "radius": 32 }`))
+			Expect(err).To(BeNil())
+			bytes, err := cluster.Encode(&binning.TileCoord{Z: 0, X: 0, Y: 0}, []*tile.ClusterCell{
+				{X: 7, Y: 7, CentroidX: 250, CentroidY: 250, Count: 1},
+			})
+			Expect(err).To(BeNil())
+			Expect(bytes).To(MatchJSON(`[
+				{"id": "0/7/7", "x": 250, "y": 250, "count": 1}
+			]`))
+			// the same position at the next zoom level
+			bytes, err = cluster.Encode(&binning.TileCoord{Z: 1, X: 1, Y: 1}, []*tile.ClusterCell{
+				{X: 7, Y: 7, CentroidX: 244, CentroidY: 244, Count: 1},
+			})
+			Expect(err).To(BeNil())
+			Expect(bytes).To(MatchJSON(`[
+				{"id": "1/15/15", "parent": "0/7/7", "x": 244, "y": 244, "count": 1}
+			]`))
+		})
+
+		It("should encode an empty array if there are no cells", func() {
+			err := cluster.Parse(JSON(`{}`))
+			Expect(err).To(BeNil())
+			bytes, err := cluster.Encode(&binning.TileCoord{}, nil)
+			Expect(err).To(BeNil())
+			Expect(bytes).To(MatchJSON(`[]`))
+		})
+	})
+
+})
@@ -0,0 +1,148 @@
+package tile
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	cubeVersion     = 1
+	cubeHeaderSize  = 16
+	cubeEntryStride = 8
+)
+
+// CubeBin represents the count of a single bin of a single time bucket of a
+// cube tile.
+type CubeBin struct {
+	X     int
+	Y     int
+	Time  int
+	Count float64
+}
+
+// CubeData represents the decoded frames of a cube tile, with the bins of
+// each time bucket in order of the timestamps.
+type CubeData struct {
+	Resolution int
+	Timestamps []float64
+	Frames     [][]float32
+}
+
+// Cube represents a tile which bins the data points by both position and time
+// bucket, returning a heatmap for each time bucket of the frequency.
+type Cube struct{}
+
+// Encode will encode the non-empty bins of each time bucket in a sparse
+// layout, prefixed by a header which describes it:
+//
+//     byte 0:       version
+//     bytes 1-3:    reserved
+//     bytes 4-7:    uint32 resolution
+//     bytes 8-11:   uint32 number of time buckets
+//     bytes 12-15:  uint32 number of entries
+//
+// The header is followed by the float64 timestamp of each time bucket, the
+// uint32 index of the first entry of each time bucket, then the entries of
+// each time bucket in order, as a uint32 bin index and a float32 count. All
+// values are little endian. Counts of the same bin and time bucket are summed.
+func (c *Cube) Encode(resolution int, timestamps []float64, bins []*CubeBin) ([]byte, error) {
+	// sum the counts of each bin of each time bucket
+	numBins := resolution * resolution
+	counts := make(map[int64]float64)
+	for _, bin := range bins {
+		if bin.Time < 0 || bin.Time >= len(timestamps) {
+			return nil, fmt.Errorf("cube time bucket %d is out of range", bin.Time)
+		}
+		if bin.X < 0 || bin.X >= resolution || bin.Y < 0 || bin.Y >= resolution {
+			return nil, fmt.Errorf("cube bin %d, %d is out of range", bin.X, bin.Y)
+		}
+		counts[int64(bin.Time)*int64(numBins)+int64(bin.X+bin.Y*resolution)] += bin.Count
+	}
+	// order the entries by time bucket then bin
+	keys := make([]int64, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	// write the header
+	numTimes := len(timestamps)
+	offset := cubeHeaderSize + numTimes*12
+	bytes := make([]byte, offset+len(keys)*cubeEntryStride)
+	bytes[0] = cubeVersion
+	binary.LittleEndian.PutUint32(bytes[4:8], uint32(resolution))
+	binary.LittleEndian.PutUint32(bytes[8:12], uint32(numTimes))
+	binary.LittleEndian.PutUint32(bytes[12:16], uint32(len(keys)))
+	for i, timestamp := range timestamps {
+		start := cubeHeaderSize + i*8
+		binary.LittleEndian.PutUint64(bytes[start:start+8], math.Float64bits(timestamp))
+	}
+	// write the entries, along with the first entry of each time bucket
+	starts := bytes[cubeHeaderSize+numTimes*8 : offset]
+	next := 0
+	for i, key := range keys {
+		time := int(key / int64(numBins))
+		for ; next <= time; next++ {
+			binary.LittleEndian.PutUint32(starts[next*4:next*4+4], uint32(i))
+		}
+		binary.LittleEndian.PutUint32(bytes[offset:offset+4], uint32(key%int64(numBins)))
+		binary.LittleEndian.PutUint32(bytes[offset+4:offset+8], math.Float32bits(float32(counts[key])))
+		offset += cubeEntryStride
+	}
+	for ; next < numTimes; next++ {
+		binary.LittleEndian.PutUint32(starts[next*4:next*4+4], uint32(len(keys)))
+	}
+	return bytes, nil
+}
+
+// DecodeCube decodes the frames encoded by a cube tile.
+func DecodeCube(bytes []byte) (*CubeData, error) {
+	if len(bytes) < cubeHeaderSize {
+		return nil, fmt.Errorf("cube header is truncated")
+	}
+	if bytes[0] != cubeVersion {
+		return nil, fmt.Errorf("unsupported cube version `%d`", bytes[0])
+	}
+	resolution := int(binary.LittleEndian.Uint32(bytes[4:8]))
+	numTimes := int(binary.LittleEndian.Uint32(bytes[8:12]))
+	numEntries := int(binary.LittleEndian.Uint32(bytes[12:16]))
+	offset := cubeHeaderSize + numTimes*12
+	if len(bytes) != offset+numEntries*cubeEntryStride {
+		return nil, fmt.Errorf("cube is %d bytes, expected %d", len(bytes), offset+numEntries*cubeEntryStride)
+	}
+	numBins := resolution * resolution
+	timestamps := make([]float64, numTimes)
+	frames := make([][]float32, numTimes)
+	starts := bytes[cubeHeaderSize+numTimes*8 : offset]
+	for i := range timestamps {
+		start := cubeHeaderSize + i*8
+		timestamps[i] = math.Float64frombits(binary.LittleEndian.Uint64(bytes[start : start+8]))
+		// get the entries of the time bucket
+		first := int(binary.LittleEndian.Uint32(starts[i*4 : i*4+4]))
+		last := numEntries
+		if i < numTimes-1 {
+			last = int(binary.LittleEndian.Uint32(starts[i*4+4 : i*4+8]))
+		}
+		if first > last || last > numEntries {
+			return nil, fmt.Errorf("cube entries of time bucket %d are out of range", i)
+		}
+		frame := make([]float32, numBins)
+		for j := first; j < last; j++ {
+			entry := offset + j*cubeEntryStride
+			index := int(binary.LittleEndian.Uint32(bytes[entry : entry+4]))
+			if index >= numBins {
+				return nil, fmt.Errorf("cube bin index %d is out of range", index)
+			}
+			frame[index] = math.Float32frombits(binary.LittleEndian.Uint32(bytes[entry+4 : entry+8]))
+		}
+		frames[i] = frame
+	}
+	return &CubeData{
+		Resolution: resolution,
+		Timestamps: timestamps,
+		Frames:     frames,
+	}, nil
+}
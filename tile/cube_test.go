@@ -0,0 +1,108 @@
+package tile_test
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/unchartedsoftware/veldt/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cube", func() {
+
+	var cube *tile.Cube
+
+	BeforeEach(func() {
+		cube = &tile.Cube{}
+	})
+
+	Describe("Encode", func() {
+		It("should encode the entries of each time bucket in order", func() {
+			timestamps := []float64{1000, 2000, 3000}
+			data, err := cube.Encode(4, timestamps, []*tile.CubeBin{
+				{X: 1, Y: 1, Time: 2, Count: 3},
+				{X: 0, Y: 0, Time: 0, Count: 1},
+				{X: 3, Y: 0, Time: 0, Count: 2},
+			})
+			Expect(err).To(BeNil())
+			// header, timestamps, entry offsets then 3 entries
+			Expect(data).To(HaveLen(16 + 3*8 + 3*4 + 3*8))
+			Expect(binary.LittleEndian.Uint32(data[4:8])).To(Equal(uint32(4)))
+			Expect(binary.LittleEndian.Uint32(data[8:12])).To(Equal(uint32(3)))
+			Expect(binary.LittleEndian.Uint32(data[12:16])).To(Equal(uint32(3)))
+			Expect(math.Float64frombits(binary.LittleEndian.Uint64(data[24:32]))).To(Equal(2000.0))
+			// the second time bucket is empty
+			offsets := data[40:52]
+			Expect(binary.LittleEndian.Uint32(offsets[0:4])).To(Equal(uint32(0)))
+			Expect(binary.LittleEndian.Uint32(offsets[4:8])).To(Equal(uint32(2)))
+			Expect(binary.LittleEndian.Uint32(offsets[8:12])).To(Equal(uint32(2)))
+			// the last entry is bin 5 of the last time bucket
+			Expect(binary.LittleEndian.Uint32(data[68:72])).To(Equal(uint32(5)))
+			Expect(math.Float32frombits(binary.LittleEndian.Uint32(data[72:76]))).To(Equal(float32(3)))
+		})
+
+		It("should sum the counts of the same bin and time bucket", func() {
+			data, err := cube.Encode(2, []float64{0}, []*tile.CubeBin{
+				{X: 1, Y: 0, Count: 1},
+				{X: 1, Y: 0, Count: 2},
+			})
+			Expect(err).To(BeNil())
+			decoded, err := tile.DecodeCube(data)
+			Expect(err).To(BeNil())
+			Expect(decoded.Frames).To(Equal([][]float32{{0, 3, 0, 0}}))
+		})
+
+		It("should return an error if a bin is out of range", func() {
+			_, err := cube.Encode(2, []float64{0}, []*tile.CubeBin{
+				{X: 2, Y: 0, Count: 1},
+			})
+			Expect(err).NotTo(BeNil())
+			_, err = cube.Encode(2, []float64{0}, []*tile.CubeBin{
+				{X: 0, Y: 0, Time: 1, Count: 1},
+			})
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("DecodeCube", func() {
+		It("should decode a frame for each time bucket", func() {
+			timestamps := []float64{0, 10, 20}
+			data, err := cube.Encode(2, timestamps, []*tile.CubeBin{
+				{X: 0, Y: 1, Time: 0, Count: 4},
+				{X: 1, Y: 1, Time: 2, Count: 1},
+				{X: 0, Y: 0, Time: 2, Count: 2},
+			})
+			Expect(err).To(BeNil())
+			decoded, err := tile.DecodeCube(data)
+			Expect(err).To(BeNil())
+			Expect(decoded.Resolution).To(Equal(2))
+			Expect(decoded.Timestamps).To(Equal(timestamps))
+			Expect(decoded.Frames).To(Equal([][]float32{
+				{0, 0, 4, 0},
+				{0, 0, 0, 0},
+				{2, 0, 0, 1},
+			}))
+		})
+
+		It("should decode a cube without time buckets", func() {
+			data, err := cube.Encode(2, []float64{}, nil)
+			Expect(err).To(BeNil())
+			decoded, err := tile.DecodeCube(data)
+			Expect(err).To(BeNil())
+			Expect(decoded.Frames).To(HaveLen(0))
+		})
+
+		It("should return an error if the entries are truncated", func() {
+			data, err := cube.Encode(2, []float64{0}, []*tile.CubeBin{
+				{X: 0, Y: 0, Count: 1},
+			})
+			Expect(err).To(BeNil())
+			_, err = tile.DecodeCube(data[:len(data)-4])
+			Expect(err).NotTo(BeNil())
+			_, err = tile.DecodeCube(data[:8])
+			Expect(err).NotTo(BeNil())
+		})
+	})
+})
@@ -0,0 +1,368 @@
+package decode
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+const (
+	bytesPerComponent = 4
+	pointStride       = 2
+	edgeStride        = 6
+	lodHeaderSize     = 8
+	hexCellStride     = 12
+)
+
+// Points represents the decoded points of a macro tile as consecutive x and y
+// components. Offsets holds the byte offset into the points of each LOD
+// partition, and is nil if the tile was not encoded with LOD.
+type Points struct {
+	Points  []float32
+	Offsets []int
+}
+
+// Edges represents the decoded edges of a macro edge tile as the source x, y
+// and weight followed by the destination x, y and weight. Offsets holds the
+// byte offset into the edges of each LOD partition, and is nil if the tile was
+// not encoded with LOD.
+type Edges struct {
+	Edges   []float32
+	Offsets []int
+}
+
+// Bucket represents a single bucket of a frequency tile.
+type Bucket struct {
+	Timestamp float64
+	Count     float64
+}
+
+// Percentile represents a single percentile of a distribution tile. The value
+// is nil if there were no values within the tile.
+type Percentile struct {
+	Percentile float64
+	Value      *float64
+}
+
+// DistributionData represents the decoded histogram and percentiles of a
+// distribution tile.
+type DistributionData struct {
+	Histogram   []*tile.DistributionBucket
+	Percentiles []*Percentile
+}
+
+// Heatmap decodes the bins of a heatmap tile with the `encoding` parameter of
+// the tile. If the encoding is empty the bins are decoded as little endian
+// float32 values.
+func Heatmap(data []byte, encoding string) ([]float32, error) {
+	return tile.DecodeBins(data, encoding, 0)
+}
+
+// HeatmapLOD decodes the levels of a heatmap tile encoded with LOD and the
+// `encoding` parameter of the tile, ordered from the full resolution bins to
+// the lowest resolution.
+func HeatmapLOD(data []byte, encoding string) ([][]float32, error) {
+	return tile.DecodeHeatmapLOD(data, encoding)
+}
+
+// Stats decodes the channels of a stats tile, keyed by statistic.
+func Stats(data []byte) (map[string][]float32, error) {
+	return tile.DecodeStats(data)
+}
+
+// Cube decodes the frame of bins of each time bucket of a cube tile.
+func Cube(data []byte) (*tile.CubeData, error) {
+	return tile.DecodeCube(data)
+}
+
+// Macro decodes the points of a macro tile encoded without LOD.
+func Macro(data []byte) (*Points, error) {
+	points, err := decodeFloat32(data, pointStride)
+	if err != nil {
+		return nil, err
+	}
+	return &Points{
+		Points: points,
+	}, nil
+}
+
+// MacroLOD decodes the points and LOD offsets of a macro tile encoded with
+// LOD.
+func MacroLOD(data []byte) (*Points, error) {
+	points, offsets, err := decodeLOD(data, pointStride)
+	if err != nil {
+		return nil, err
+	}
+	return &Points{
+		Points:  points,
+		Offsets: offsets,
+	}, nil
+}
+
+// MacroEdge decodes the edges of a macro edge tile encoded without LOD.
+func MacroEdge(data []byte) (*Edges, error) {
+	edges, err := decodeFloat32(data, edgeStride)
+	if err != nil {
+		return nil, err
+	}
+	return &Edges{
+		Edges: edges,
+	}, nil
+}
+
+// MacroEdgeLOD decodes the edges and LOD offsets of a macro edge tile encoded
+// with LOD.
+func MacroEdgeLOD(data []byte) (*Edges, error) {
+	edges, offsets, err := decodeLOD(data, edgeStride)
+	if err != nil {
+		return nil, err
+	}
+	return &Edges{
+		Edges:   edges,
+		Offsets: offsets,
+	}, nil
+}
+
+// Micro decodes the points, LOD offsets and hits of a micro tile, encoded
+// either as JSON or with the columnar encoding.
+func Micro(data []byte) (*tile.MicroData, error) {
+	if len(data) == 0 || data[0] != '{' {
+		return tile.DecodeMicro(data)
+	}
+	res, err := json.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if !json.Exists(res, "points") {
+		return nil, fmt.Errorf("`points` missing from micro tile")
+	}
+	var points []float32
+	if val, _ := json.Get(res, "points"); val != nil {
+		floats, ok := json.GetFloatArray(res, "points")
+		if !ok {
+			return nil, fmt.Errorf("`points` of micro tile are not numeric")
+		}
+		points = make([]float32, len(floats))
+		for i, val := range floats {
+			points[i] = float32(val)
+		}
+	}
+	var ok bool
+	var offsets []int
+	if json.Exists(res, "offsets") {
+		offsets, ok = json.GetIntArray(res, "offsets")
+		if !ok {
+			return nil, fmt.Errorf("`offsets` of micro tile are not integers")
+		}
+	}
+	var hits []map[string]interface{}
+	if val, _ := json.Get(res, "hits"); val != nil {
+		hits, ok = json.GetChildArray(res, "hits")
+		if !ok {
+			return nil, fmt.Errorf("`hits` of micro tile are not objects")
+		}
+		if len(hits) != len(points)/pointStride {
+			return nil, fmt.Errorf("micro tile has %d hits for %d points", len(hits), len(points)/pointStride)
+		}
+	}
+	return &tile.MicroData{
+		Points:  points,
+		Offsets: offsets,
+		Hits:    hits,
+	}, nil
+}
+
+// Hexbin decodes the cells of a hexbin tile.
+func Hexbin(data []byte) ([]*tile.HexCell, error) {
+	if len(data)%hexCellStride != 0 {
+		return nil, fmt.Errorf("hexbin tile of %d bytes is not a sequence of cells", len(data))
+	}
+	cells := make([]*tile.HexCell, len(data)/hexCellStride)
+	for i := range cells {
+		offset := i * hexCellStride
+		cells[i] = &tile.HexCell{
+			HexCoord: binning.HexCoord{
+				Q: int64(int32(binary.LittleEndian.Uint32(data[offset : offset+4]))),
+				R: int64(int32(binary.LittleEndian.Uint32(data[offset+4 : offset+8]))),
+			},
+			Count: float64(binary.LittleEndian.Uint32(data[offset+8 : offset+12])),
+		}
+	}
+	return cells, nil
+}
+
+// Count decodes the count of a count tile.
+func Count(data []byte) (uint64, error) {
+	res, err := json.Unmarshal(data)
+	if err != nil {
+		return 0, err
+	}
+	count, ok := json.GetFloat(res, "count")
+	if !ok {
+		return 0, fmt.Errorf("`count` missing from count tile")
+	}
+	return uint64(count), nil
+}
+
+// Terms decodes the count of each term of a top or target term count tile.
+func Terms(data []byte) (map[string]uint32, error) {
+	res, err := json.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]uint32, len(res))
+	for term := range res {
+		count, ok := json.GetFloat(res, term)
+		if !ok {
+			return nil, fmt.Errorf("count of term `%s` is not numeric", term)
+		}
+		counts[term] = uint32(count)
+	}
+	return counts, nil
+}
+
+// Frequency decodes the buckets of a frequency tile.
+func Frequency(data []byte) ([]*Bucket, error) {
+	res, err := json.UnmarshalArray(data)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBuckets(res)
+}
+
+// TermsFrequency decodes the frequency buckets of each term of a top or
+// target term frequency tile.
+func TermsFrequency(data []byte) (map[string][]*Bucket, error) {
+	res, err := json.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	// decode the terms in order so errors are deterministic
+	terms := make([]string, 0, len(res))
+	for term := range res {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+	frequencies := make(map[string][]*Bucket, len(res))
+	for _, term := range terms {
+		children, ok := json.GetChildArray(res, term)
+		if !ok {
+			return nil, fmt.Errorf("frequency of term `%s` is not an array of buckets", term)
+		}
+		buckets, err := decodeBuckets(children)
+		if err != nil {
+			return nil, err
+		}
+		frequencies[term] = buckets
+	}
+	return frequencies, nil
+}
+
+// Distribution decodes the histogram and percentiles of a distribution tile.
+func Distribution(data []byte) (*DistributionData, error) {
+	res, err := json.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	buckets, ok := json.GetChildArray(res, "histogram")
+	if !ok {
+		return nil, fmt.Errorf("`histogram` missing from distribution tile")
+	}
+	percentiles, ok := json.GetChildArray(res, "percentiles")
+	if !ok {
+		return nil, fmt.Errorf("`percentiles` missing from distribution tile")
+	}
+	distribution := &DistributionData{
+		Histogram:   make([]*tile.DistributionBucket, len(buckets)),
+		Percentiles: make([]*Percentile, len(percentiles)),
+	}
+	for i, bucket := range buckets {
+		value, ok := json.GetFloat(bucket, "bucket")
+		if !ok {
+			return nil, fmt.Errorf("`bucket` missing from distribution bucket")
+		}
+		count, ok := json.GetFloat(bucket, "count")
+		if !ok {
+			return nil, fmt.Errorf("`count` missing from distribution bucket")
+		}
+		distribution.Histogram[i] = &tile.DistributionBucket{
+			Bucket: value,
+			Count:  uint64(count),
+		}
+	}
+	for i, percentile := range percentiles {
+		p, ok := json.GetFloat(percentile, "percentile")
+		if !ok {
+			return nil, fmt.Errorf("`percentile` missing from distribution percentile")
+		}
+		distribution.Percentiles[i] = &Percentile{
+			Percentile: p,
+		}
+		if value, ok := json.GetFloat(percentile, "value"); ok {
+			distribution.Percentiles[i].Value = &value
+		}
+	}
+	return distribution, nil
+}
+
+func decodeBuckets(children []map[string]interface{}) ([]*Bucket, error) {
+	buckets := make([]*Bucket, len(children))
+	for i, child := range children {
+		timestamp, ok := json.GetFloat(child, "timestamp")
+		if !ok {
+			return nil, fmt.Errorf("`timestamp` missing from frequency bucket")
+		}
+		count, ok := json.GetFloat(child, "count")
+		if !ok {
+			return nil, fmt.Errorf("`count` missing from frequency bucket")
+		}
+		buckets[i] = &Bucket{
+			Timestamp: timestamp,
+			Count:     count,
+		}
+	}
+	return buckets, nil
+}
+
+func decodeFloat32(data []byte, stride int) ([]float32, error) {
+	if len(data)%(bytesPerComponent*stride) != 0 {
+		return nil, fmt.Errorf("tile of %d bytes is not a sequence of %d component elements", len(data), stride)
+	}
+	vals := make([]float32, len(data)/bytesPerComponent)
+	for i := range vals {
+		vals[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[i*4 : i*4+4]))
+	}
+	return vals, nil
+}
+
+func decodeLOD(data []byte, stride int) ([]float32, []int, error) {
+	if len(data) < lodHeaderSize {
+		return nil, nil, fmt.Errorf("LOD header is truncated")
+	}
+	dataLength := int(binary.LittleEndian.Uint32(data[0:4]))
+	offsetLength := int(binary.LittleEndian.Uint32(data[4:8]))
+	if len(data) != lodHeaderSize+dataLength+offsetLength {
+		return nil, nil, fmt.Errorf("LOD tile of %d bytes does not match its header", len(data))
+	}
+	if offsetLength%bytesPerComponent != 0 {
+		return nil, nil, fmt.Errorf("LOD offsets of %d bytes are not a sequence of uint32", offsetLength)
+	}
+	vals, err := decodeFloat32(data[lodHeaderSize:lodHeaderSize+dataLength], stride)
+	if err != nil {
+		return nil, nil, err
+	}
+	offsetBytes := data[lodHeaderSize+dataLength:]
+	offsets := make([]int, offsetLength/bytesPerComponent)
+	for i := range offsets {
+		offsets[i] = int(binary.LittleEndian.Uint32(offsetBytes[i*4 : i*4+4]))
+		if offsets[i] > dataLength {
+			return nil, nil, fmt.Errorf("LOD offset %d is out of range", offsets[i])
+		}
+	}
+	return vals, offsets, nil
+}
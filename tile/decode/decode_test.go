@@ -0,0 +1,461 @@
+package decode_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/tile/decode"
+	"github.com/unchartedsoftware/veldt/util/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+const (
+	iterations = 50
+)
+
+// randomPixels returns n random positions within the tile, with 2 decimals so
+// they are unchanged by the fixed precision float32 encoding.
+func randomPixels(r *rand.Rand, n int) []float32 {
+	vals := make([]float32, n)
+	for i := range vals {
+		vals[i] = float32(r.Intn(25600)) / 100
+	}
+	return vals
+}
+
+func randomEdges(r *rand.Rand, n int) []float32 {
+	edges := make([]float32, n*6)
+	for i := 0; i < n; i++ {
+		pixels := randomPixels(r, 4)
+		weight := float32(r.Intn(1000)) / 100
+		copy(edges[i*6:], []float32{pixels[0], pixels[1], weight, pixels[2], pixels[3], weight})
+	}
+	return edges
+}
+
+func randomHits(r *rand.Rand, n int) []map[string]interface{} {
+	hits := make([]map[string]interface{}, n)
+	for i := range hits {
+		hits[i] = map[string]interface{}{
+			"id":    fmt.Sprintf("hit-%d", r.Intn(100)),
+			"value": float64(r.Intn(1000)),
+			"flag":  r.Intn(2) == 0,
+		}
+	}
+	return hits
+}
+
+var _ = Describe("decode", func() {
+
+	var r *rand.Rand
+
+	BeforeEach(func() {
+		r = rand.New(rand.NewSource(1))
+	})
+
+	Describe("Heatmap", func() {
+		It("should round trip float32 bins", func() {
+			for i := 0; i < iterations; i++ {
+				bins := make([]float32, 64)
+				for j := range bins {
+					bins[j] = r.Float32() * 1000
+				}
+				data, err := (&tile.Heatmap{}).Encode(bins)
+				Expect(err).To(BeNil())
+				decoded, err := decode.Heatmap(data, "")
+				Expect(err).To(BeNil())
+				Expect(decoded).To(Equal(bins))
+			}
+		})
+
+		It("should round trip bins encoded with a header", func() {
+			for i := 0; i < iterations; i++ {
+				bins := make([]float32, 64)
+				for j := range bins {
+					if r.Intn(4) == 0 {
+						bins[j] = float32(r.Intn(1000))
+					}
+				}
+				for _, encoding := range []string{"uint32", "float32"} {
+					data, err := tile.EncodeHeatmap(bins, encoding)
+					Expect(err).To(BeNil())
+					decoded, err := decode.Heatmap(data, encoding)
+					Expect(err).To(BeNil())
+					Expect(decoded).To(Equal(bins))
+				}
+			}
+		})
+	})
+
+	Describe("HeatmapLOD", func() {
+		It("should round trip each level", func() {
+			for i := 0; i < iterations; i++ {
+				bins := make([]float32, 16*16)
+				for j := range bins {
+					bins[j] = float32(r.Intn(100))
+				}
+				heatmap := &tile.Heatmap{}
+				err := heatmap.Parse(JSON(`{ "lod": 2, "lodReduce": "max" }`))
+				Expect(err).To(BeNil())
+				data, err := heatmap.Encode(bins)
+				Expect(err).To(BeNil())
+				levels, err := decode.HeatmapLOD(data, "")
+				Expect(err).To(BeNil())
+				Expect(levels).To(HaveLen(3))
+				Expect(levels[0]).To(Equal(bins))
+				Expect(levels[1]).To(HaveLen(8 * 8))
+				Expect(levels[2]).To(HaveLen(4 * 4))
+			}
+		})
+	})
+
+	Describe("Stats", func() {
+		It("should round trip the channels of each statistic", func() {
+			for i := 0; i < iterations; i++ {
+				bins := make([]*tile.BinStats, 16)
+				for j := range bins {
+					if r.Intn(2) == 0 {
+						bins[j] = &tile.BinStats{
+							Count: uint64(r.Intn(100)),
+							Sum:   float64(r.Intn(1000)),
+							Max:   float64(r.Intn(10)),
+						}
+					}
+				}
+				stats := &tile.Stats{}
+				err := stats.Parse(JSON(`{ "valueField": "v", "statistics": ["max", "count"] }`))
+				Expect(err).To(BeNil())
+				data, err := stats.Encode(bins)
+				Expect(err).To(BeNil())
+				decoded, err := decode.Stats(data)
+				Expect(err).To(BeNil())
+				Expect(decoded).To(HaveLen(2))
+				for j, bin := range bins {
+					if bin == nil || bin.Count == 0 {
+						Expect(decoded["count"][j]).To(Equal(float32(0)))
+						Expect(math.IsNaN(float64(decoded["max"][j]))).To(BeTrue())
+						continue
+					}
+					Expect(decoded["count"][j]).To(Equal(float32(bin.Count)))
+					Expect(decoded["max"][j]).To(Equal(float32(bin.Max)))
+				}
+			}
+		})
+	})
+
+	Describe("Cube", func() {
+		It("should round trip the frame of each time bucket", func() {
+			for i := 0; i < iterations; i++ {
+				timestamps := make([]float64, 1+r.Intn(8))
+				frames := make([][]float32, len(timestamps))
+				var bins []*tile.CubeBin
+				for t := range timestamps {
+					timestamps[t] = float64(1500000000000 + t*3600000)
+					frames[t] = make([]float32, 8*8)
+					for j := range frames[t] {
+						if r.Intn(8) == 0 {
+							frames[t][j] = float32(1 + r.Intn(100))
+							bins = append(bins, &tile.CubeBin{
+								X:     j % 8,
+								Y:     j / 8,
+								Time:  t,
+								Count: float64(frames[t][j]),
+							})
+						}
+					}
+				}
+				data, err := (&tile.Cube{}).Encode(8, timestamps, bins)
+				Expect(err).To(BeNil())
+				decoded, err := decode.Cube(data)
+				Expect(err).To(BeNil())
+				Expect(decoded.Resolution).To(Equal(8))
+				Expect(decoded.Timestamps).To(Equal(timestamps))
+				Expect(decoded.Frames).To(Equal(frames))
+			}
+		})
+	})
+
+	Describe("Macro", func() {
+		It("should round trip points", func() {
+			for i := 0; i < iterations; i++ {
+				points := randomPixels(r, r.Intn(32)*2)
+				data, err := (&tile.Macro{}).Encode(points, nil)
+				Expect(err).To(BeNil())
+				decoded, err := decode.Macro(data)
+				Expect(err).To(BeNil())
+				Expect(decoded.Points).To(Equal(points))
+				Expect(decoded.Offsets).To(BeNil())
+			}
+		})
+
+		It("should return an error if the points are truncated", func() {
+			data := tile.EncodeFloat32([]float32{1, 2})
+			_, err := decode.Macro(data[:6])
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("MacroLOD", func() {
+		It("should round trip points and offsets", func() {
+			for i := 0; i < iterations; i++ {
+				lod := 1 + r.Intn(3)
+				input := randomPixels(r, r.Intn(32)*2)
+				points, offsets := tile.LOD(input, lod)
+				decoded, err := decode.MacroLOD(tile.EncodeLOD(input, lod))
+				Expect(err).To(BeNil())
+				Expect(decoded.Points).To(Equal(points))
+				Expect(decoded.Offsets).To(Equal(offsets))
+			}
+		})
+
+		It("should return an error if the header does not match the data", func() {
+			data := tile.EncodeLOD([]float32{1, 2, 3, 4}, 1)
+			_, err := decode.MacroLOD(data[:len(data)-4])
+			Expect(err).NotTo(BeNil())
+			_, err = decode.MacroLOD(data[:4])
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("MacroEdge", func() {
+		It("should round trip edges", func() {
+			for i := 0; i < iterations; i++ {
+				edges := randomEdges(r, r.Intn(16))
+				data, err := (&tile.MacroEdge{}).Encode(edges, nil)
+				Expect(err).To(BeNil())
+				decoded, err := decode.MacroEdge(data)
+				Expect(err).To(BeNil())
+				Expect(decoded.Edges).To(Equal(edges))
+			}
+		})
+
+		It("should return an error if an edge is truncated", func() {
+			_, err := decode.MacroEdge(tile.EncodeFloat32([]float32{1, 2, 3, 4}))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("MacroEdgeLOD", func() {
+		It("should round trip edges and offsets", func() {
+			for i := 0; i < iterations; i++ {
+				lod := 1 + r.Intn(3)
+				input := randomEdges(r, r.Intn(16))
+				edges, offsets := tile.EdgeLOD(input, lod)
+				decoded, err := decode.MacroEdgeLOD(tile.EncodeEdgeLOD(input, lod))
+				Expect(err).To(BeNil())
+				Expect(decoded.Edges).To(Equal(edges))
+				Expect(decoded.Offsets).To(Equal(offsets))
+			}
+		})
+	})
+
+	Describe("Micro", func() {
+		It("should round trip JSON points and hits", func() {
+			for i := 0; i < iterations; i++ {
+				n := 1 + r.Intn(16)
+				points := randomPixels(r, n*2)
+				hits := randomHits(r, n)
+				data, err := (&tile.Micro{}).Encode(hits, points, nil)
+				Expect(err).To(BeNil())
+				decoded, err := decode.Micro(data)
+				Expect(err).To(BeNil())
+				Expect(decoded.Points).To(Equal(points))
+				Expect(decoded.Offsets).To(BeNil())
+				Expect(decoded.Hits).To(Equal(hits))
+			}
+		})
+
+		It("should round trip JSON points and hits with LOD", func() {
+			for i := 0; i < iterations; i++ {
+				n := 1 + r.Intn(16)
+				input := randomPixels(r, n*2)
+				points, offsets := tile.LOD(input, 2)
+				hits := randomHits(r, n)
+				micro := &tile.Micro{}
+				err := micro.Parse(JSON(`{ "lod": 2 }`))
+				Expect(err).To(BeNil())
+				// hits are sorted in place to align with the points
+				data, err := micro.Encode(hits, input, nil)
+				Expect(err).To(BeNil())
+				decoded, err := decode.Micro(data)
+				Expect(err).To(BeNil())
+				Expect(decoded.Points).To(Equal(points))
+				Expect(decoded.Offsets).To(Equal(offsets))
+				Expect(decoded.Hits).To(Equal(hits))
+			}
+		})
+
+		It("should round trip columnar points and hits", func() {
+			for i := 0; i < iterations; i++ {
+				n := 1 + r.Intn(16)
+				points := randomPixels(r, n*2)
+				hits := randomHits(r, n)
+				micro := &tile.Micro{}
+				err := micro.Parse(JSON(`{ "encoding": "columnar" }`))
+				Expect(err).To(BeNil())
+				data, err := micro.Encode(hits, points, nil)
+				Expect(err).To(BeNil())
+				decoded, err := decode.Micro(data)
+				Expect(err).To(BeNil())
+				Expect(decoded.Points).To(Equal(points))
+				Expect(decoded.Hits).To(Equal(hits))
+			}
+		})
+
+		It("should decode a micro tile without hits", func() {
+			decoded, err := decode.Micro([]byte(`{"points":[1,2],"hits":null}`))
+			Expect(err).To(BeNil())
+			Expect(decoded.Points).To(Equal([]float32{1, 2}))
+			Expect(decoded.Hits).To(BeNil())
+		})
+
+		It("should return an error if the hits do not align with the points", func() {
+			_, err := decode.Micro([]byte(`{"points":[1,2],"hits":[{},{}]}`))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Hexbin", func() {
+		It("should round trip cells", func() {
+			for i := 0; i < iterations; i++ {
+				cells := make([]*tile.HexCell, r.Intn(16))
+				for j := range cells {
+					// cells are encoded in order of r then q
+					cells[j] = &tile.HexCell{
+						HexCoord: binning.HexCoord{
+							Q: int64(r.Intn(100) - 50),
+							R: int64(j - 8),
+						},
+						Count: float64(r.Intn(1000)),
+					}
+				}
+				decoded, err := decode.Hexbin((&tile.Hexbin{}).Encode(cells))
+				Expect(err).To(BeNil())
+				Expect(decoded).To(Equal(cells))
+			}
+		})
+	})
+
+	Describe("Count", func() {
+		It("should decode the count", func() {
+			count, err := decode.Count([]byte(`{"count":1024}`))
+			Expect(err).To(BeNil())
+			Expect(count).To(Equal(uint64(1024)))
+		})
+
+		It("should return an error if the count is missing", func() {
+			_, err := decode.Count([]byte(`{}`))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Terms", func() {
+		It("should round trip term counts", func() {
+			for i := 0; i < iterations; i++ {
+				counts := make(map[string]uint32)
+				for j := 0; j < r.Intn(16); j++ {
+					counts[fmt.Sprintf("term-%d", r.Intn(100))] = r.Uint32()
+				}
+				data, err := json.Marshal(counts)
+				Expect(err).To(BeNil())
+				decoded, err := decode.Terms(data)
+				Expect(err).To(BeNil())
+				Expect(decoded).To(Equal(counts))
+			}
+		})
+	})
+
+	Describe("Frequency", func() {
+		It("should round trip buckets", func() {
+			for i := 0; i < iterations; i++ {
+				buckets := make([]map[string]interface{}, r.Intn(16))
+				expected := make([]*decode.Bucket, len(buckets))
+				for j := range buckets {
+					timestamp := float64(1500000000000 + j*86400000)
+					count := float64(r.Intn(1000))
+					buckets[j] = map[string]interface{}{
+						"timestamp": timestamp,
+						"count":     count,
+					}
+					expected[j] = &decode.Bucket{
+						Timestamp: timestamp,
+						Count:     count,
+					}
+				}
+				data, err := json.Marshal(buckets)
+				Expect(err).To(BeNil())
+				decoded, err := decode.Frequency(data)
+				Expect(err).To(BeNil())
+				Expect(decoded).To(Equal(expected))
+			}
+		})
+	})
+
+	Describe("Distribution", func() {
+		It("should round trip the histogram and percentiles", func() {
+			for i := 0; i < iterations; i++ {
+				buckets := make([]*tile.DistributionBucket, r.Intn(16))
+				for j := range buckets {
+					buckets[j] = &tile.DistributionBucket{
+						Bucket: float64(j) * 0.5,
+						Count:  uint64(r.Intn(1000)),
+					}
+				}
+				distribution := &tile.Distribution{}
+				err := distribution.Parse(JSON(`{ "valueField": "v", "interval": 0.5, "percentiles": [5, 95] }`))
+				Expect(err).To(BeNil())
+				values := []float64{float64(r.Intn(100)), float64(r.Intn(100))}
+				data, err := distribution.Encode(buckets, values)
+				Expect(err).To(BeNil())
+				decoded, err := decode.Distribution(data)
+				Expect(err).To(BeNil())
+				Expect(decoded.Histogram).To(Equal(buckets))
+				Expect(decoded.Percentiles).To(Equal([]*decode.Percentile{
+					{Percentile: 5, Value: &values[0]},
+					{Percentile: 95, Value: &values[1]},
+				}))
+			}
+		})
+
+		It("should decode undefined percentiles as nil", func() {
+			decoded, err := decode.Distribution([]byte(`{"histogram":[],"percentiles":[{"percentile":50,"value":null}]}`))
+			Expect(err).To(BeNil())
+			Expect(decoded.Histogram).To(HaveLen(0))
+			Expect(decoded.Percentiles[0].Value).To(BeNil())
+		})
+
+		It("should return an error if the histogram is missing", func() {
+			_, err := decode.Distribution([]byte(`{"percentiles":[]}`))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("TermsFrequency", func() {
+		It("should decode the buckets of each term", func() {
+			decoded, err := decode.TermsFrequency([]byte(`{
+				"a": [{"timestamp": 0, "count": 2}, {"timestamp": 10, "count": 3}],
+				"b": []
+			}`))
+			Expect(err).To(BeNil())
+			Expect(decoded).To(Equal(map[string][]*decode.Bucket{
+				"a": {
+					{Timestamp: 0, Count: 2},
+					{Timestamp: 10, Count: 3},
+				},
+				"b": {},
+			}))
+		})
+
+		It("should return an error if a bucket is missing its count", func() {
+			_, err := decode.TermsFrequency([]byte(`{"a": [{"timestamp": 0}]}`))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+})
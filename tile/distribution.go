@@ -0,0 +1,217 @@
+package tile
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+const (
+	// MaxDistributionBuckets is the maximum number of buckets of the histogram
+	// of a distribution tile.
+	MaxDistributionBuckets = 10000
+)
+
+var (
+	defaultPercentiles = []float64{25, 50, 75}
+)
+
+// DistributionBucket represents a single bucket of a distribution tile.
+type DistributionBucket struct {
+	Bucket float64
+	Count  uint64
+}
+
+// Distribution represents a tile which describes the distribution of a value
+// field within the tile, as a histogram of the values along with the requested
+// percentiles. The histogram is defined by either an `interval` or a number
+// of `buckets` between a `min` and `max`.
+type Distribution struct {
+	ValueField  string
+	Interval    float64
+	Min         float64
+	Max         float64
+	Bounded     bool
+	Percentiles []float64
+}
+
+// Parse parses the provided JSON object and populates the structs attributes.
+func (d *Distribution) Parse(params map[string]interface{}) error {
+	valueField, ok := json.GetString(params, "valueField")
+	if !ok {
+		return fmt.Errorf("`valueField` parameter missing from tile")
+	}
+	// get the bounds of the histogram
+	min, minOk := json.GetFloat(params, "min")
+	max, maxOk := json.GetFloat(params, "max")
+	if minOk != maxOk {
+		return fmt.Errorf("both `min` and `max` must be provided to bound the histogram")
+	}
+	if minOk && max <= min {
+		return fmt.Errorf("`max` parameter must be greater than `min`")
+	}
+	// get the interval of the histogram
+	interval, intervalOk := json.GetFloat(params, "interval")
+	buckets, bucketsOk := json.GetInt(params, "buckets")
+	if intervalOk == bucketsOk {
+		return fmt.Errorf("exactly one of `interval` and `buckets` must be provided")
+	}
+	if intervalOk && interval <= 0 {
+		return fmt.Errorf("`interval` parameter must be greater than zero")
+	}
+	if bucketsOk {
+		if buckets <= 0 {
+			return fmt.Errorf("`buckets` parameter must be greater than zero")
+		}
+		if !minOk {
+			return fmt.Errorf("`min` and `max` parameters are required with `buckets`")
+		}
+		if buckets > MaxDistributionBuckets {
+			return fmt.Errorf("`buckets` parameter must not exceed %d", MaxDistributionBuckets)
+		}
+		interval = (max - min) / float64(buckets)
+	}
+	if minOk && numBuckets(min, max, interval) > MaxDistributionBuckets {
+		return fmt.Errorf("`interval` parameter must not divide the range of `min` and `max` into more than %d buckets", MaxDistributionBuckets)
+	}
+	// get the percentiles
+	percentiles := defaultPercentiles
+	if json.Exists(params, "percentiles") {
+		percentiles, ok = json.GetFloatArray(params, "percentiles")
+		if !ok {
+			return fmt.Errorf("`percentiles` parameter is not an array of numbers")
+		}
+		for _, percentile := range percentiles {
+			if percentile < 0 || percentile > 100 {
+				return fmt.Errorf("`percentiles` parameter `%v` is not within the range [0 : 100]", percentile)
+			}
+		}
+	}
+	d.ValueField = valueField
+	d.Interval = interval
+	d.Min = min
+	d.Max = max
+	d.Bounded = minOk
+	d.Percentiles = percentiles
+	return nil
+}
+
+// Params returns the parameters accepted by the tile.
+func (d *Distribution) Params() []json.Param {
+	percentiles := make([]interface{}, len(defaultPercentiles))
+	for i, percentile := range defaultPercentiles {
+		percentiles[i] = percentile
+	}
+	return []json.Param{
+		{Key: "valueField", Type: json.TypeString, Required: true},
+		{Key: "interval", Type: json.TypeNumber},
+		{Key: "buckets", Type: json.TypeInteger},
+		{Key: "min", Type: json.TypeNumber},
+		{Key: "max", Type: json.TypeNumber},
+		{
+			Key:     "percentiles",
+			Type:    json.TypeArray,
+			Items:   json.TypeNumber,
+			Default: percentiles,
+		},
+	}
+}
+
+// Origin returns the value of the start of the first bucket, from which the
+// bucket indices are computed.
+func (d *Distribution) Origin() float64 {
+	if d.Bounded {
+		return d.Min
+	}
+	return 0
+}
+
+// NumBuckets returns the number of buckets between the bounds of the
+// histogram, or zero if the histogram is unbounded.
+func (d *Distribution) NumBuckets() int64 {
+	if !d.Bounded {
+		return 0
+	}
+	return numBuckets(d.Min, d.Max, d.Interval)
+}
+
+func numBuckets(min float64, max float64, interval float64) int64 {
+	// round to tolerate the imprecision of an interval derived from `buckets`
+	return int64(math.Ceil((max-min)/interval - 1e-9))
+}
+
+// CreateBuckets creates the histogram buckets from the counts keyed by bucket
+// index, including the empty buckets. Bounded histograms span the bounds,
+// with values at the `max` counted in the last bucket, while unbounded
+// histograms span the buckets with counts. An error is returned if there are
+// more than MaxDistributionBuckets buckets.
+func (d *Distribution) CreateBuckets(counts map[int64]uint64) ([]*DistributionBucket, error) {
+	var start, end int64
+	if d.Bounded {
+		end = d.NumBuckets()
+	} else {
+		if len(counts) == 0 {
+			return []*DistributionBucket{}, nil
+		}
+		start, end = int64(math.MaxInt64), int64(math.MinInt64)
+		for index := range counts {
+			if index < start {
+				start = index
+			}
+			if index >= end {
+				end = index + 1
+			}
+		}
+	}
+	if end-start > MaxDistributionBuckets {
+		return nil, fmt.Errorf("histogram of %d buckets exceeds the maximum of %d buckets", end-start, MaxDistributionBuckets)
+	}
+	buckets := make([]*DistributionBucket, end-start)
+	origin := d.Origin()
+	for i := range buckets {
+		buckets[i] = &DistributionBucket{
+			Bucket: origin + float64(start+int64(i))*d.Interval,
+		}
+	}
+	for index, count := range counts {
+		// clamp the values at the bounds into the first and last buckets
+		i := index - start
+		if i < 0 {
+			i = 0
+		}
+		if i > end-start-1 {
+			i = end - start - 1
+		}
+		buckets[i].Count += count
+	}
+	return buckets, nil
+}
+
+// Encode will encode the buckets and the values of the percentiles, in the
+// order of the requested percentiles, as JSON. The values are nil if there
+// are no values within the tile.
+func (d *Distribution) Encode(buckets []*DistributionBucket, values []float64) ([]byte, error) {
+	histogram := make([]map[string]interface{}, len(buckets))
+	for i, bucket := range buckets {
+		histogram[i] = map[string]interface{}{
+			"bucket": bucket.Bucket,
+			"count":  bucket.Count,
+		}
+	}
+	percentiles := make([]map[string]interface{}, len(d.Percentiles))
+	for i, percentile := range d.Percentiles {
+		var value interface{}
+		if values != nil {
+			value = values[i]
+		}
+		percentiles[i] = map[string]interface{}{
+			"percentile": percentile,
+			"value":      value,
+		}
+	}
+	return json.Marshal(map[string]interface{}{
+		"histogram":   histogram,
+		"percentiles": percentiles,
+	})
+}
@@ -0,0 +1,192 @@
+package tile_test
+
+import (
+	"github.com/unchartedsoftware/veldt/tile"
+	"github.com/unchartedsoftware/veldt/util/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+var _ = Describe("Distribution", func() {
+
+	var distribution *tile.Distribution
+
+	BeforeEach(func() {
+		distribution = &tile.Distribution{}
+	})
+
+	Describe("Parse", func() {
+		It("should parse properties from the params argument", func() {
+			params := JSON(
+				`{
+					"valueField": "value",
+					"interval": 2.5,
+					"percentiles": [10, 90]
+				}`)
+			err := distribution.Parse(params)
+			Expect(err).To(BeNil())
+			Expect(distribution.ValueField).To(Equal("value"))
+			Expect(distribution.Interval).To(Equal(2.5))
+			Expect(distribution.Bounded).To(Equal(false))
+			Expect(distribution.Percentiles).To(Equal([]float64{10, 90}))
+		})
+
+		It("should derive the interval from the `buckets` between `min` and `max`", func() {
+			params := JSON(
+				`{
+					"valueField": "value",
+					"buckets": 4,
+					"min": -1,
+					"max": 1
+				}`)
+			err := distribution.Parse(params)
+			Expect(err).To(BeNil())
+			Expect(distribution.Interval).To(Equal(0.5))
+			Expect(distribution.Bounded).To(Equal(true))
+			Expect(distribution.NumBuckets()).To(Equal(int64(4)))
+			Expect(distribution.Origin()).To(Equal(-1.0))
+		})
+
+		It("should default the percentiles to the quartiles", func() {
+			err := distribution.Parse(JSON(`{ "valueField": "value", "interval": 1 }`))
+			Expect(err).To(BeNil())
+			Expect(distribution.Percentiles).To(Equal([]float64{25, 50, 75}))
+		})
+
+		It("should return an error if `valueField` property is not specified", func() {
+			err := distribution.Parse(JSON(`{ "interval": 1 }`))
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should return an error if there are too many buckets", func() {
+			err := distribution.Parse(JSON(`{ "valueField": "value", "buckets": 10001, "min": 0, "max": 1 }`))
+			Expect(err).NotTo(BeNil())
+			err = distribution.Parse(JSON(`{ "valueField": "value", "interval": 0.00001, "min": 0, "max": 1 }`))
+			Expect(err).NotTo(BeNil())
+			err = distribution.Parse(JSON(`{ "valueField": "value", "interval": 0.0001, "min": 0, "max": 1 }`))
+			Expect(err).To(BeNil())
+		})
+
+		It("should return an error if neither or both of `interval` and `buckets` are specified", func() {
+			err := distribution.Parse(JSON(`{ "valueField": "value" }`))
+			Expect(err).NotTo(BeNil())
+			err = distribution.Parse(JSON(`{ "valueField": "value", "interval": 1, "buckets": 4, "min": 0, "max": 1 }`))
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should return an error if `buckets` are specified without bounds", func() {
+			err := distribution.Parse(JSON(`{ "valueField": "value", "buckets": 4 }`))
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should return an error if only one bound is specified", func() {
+			err := distribution.Parse(JSON(`{ "valueField": "value", "interval": 1, "min": 0 }`))
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should return an error if `max` is not greater than `min`", func() {
+			err := distribution.Parse(JSON(`{ "valueField": "value", "interval": 1, "min": 1, "max": 1 }`))
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should return an error if a percentile is out of range", func() {
+			err := distribution.Parse(JSON(`{ "valueField": "value", "interval": 1, "percentiles": [101] }`))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("CreateBuckets", func() {
+		It("should fill the empty buckets between the buckets with counts", func() {
+			err := distribution.Parse(JSON(`{ "valueField": "value", "interval": 2 }`))
+			Expect(err).To(BeNil())
+			buckets, err := distribution.CreateBuckets(map[int64]uint64{
+				-1: 3,
+				2:  1,
+			})
+			Expect(err).To(BeNil())
+			Expect(buckets).To(Equal([]*tile.DistributionBucket{
+				{Bucket: -2, Count: 3},
+				{Bucket: 0, Count: 0},
+				{Bucket: 2, Count: 0},
+				{Bucket: 4, Count: 1},
+			}))
+		})
+
+		It("should return no buckets for an unbounded empty tile", func() {
+			err := distribution.Parse(JSON(`{ "valueField": "value", "interval": 2 }`))
+			Expect(err).To(BeNil())
+			buckets, err := distribution.CreateBuckets(map[int64]uint64{})
+			Expect(err).To(BeNil())
+			Expect(buckets).To(HaveLen(0))
+		})
+
+		It("should span the bounds and count values at the `max` in the last bucket", func() {
+			err := distribution.Parse(JSON(`{ "valueField": "value", "buckets": 4, "min": 10, "max": 20 }`))
+			Expect(err).To(BeNil())
+			buckets, err := distribution.CreateBuckets(map[int64]uint64{
+				1: 2,
+				4: 1,
+			})
+			Expect(err).To(BeNil())
+			Expect(buckets).To(Equal([]*tile.DistributionBucket{
+				{Bucket: 10, Count: 0},
+				{Bucket: 12.5, Count: 2},
+				{Bucket: 15, Count: 0},
+				{Bucket: 17.5, Count: 1},
+			}))
+		})
+
+		It("should return an error if the buckets with counts span too many buckets", func() {
+			err := distribution.Parse(JSON(`{ "valueField": "value", "interval": 1 }`))
+			Expect(err).To(BeNil())
+			_, err = distribution.CreateBuckets(map[int64]uint64{
+				-1:    1,
+				10000: 1,
+			})
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should extend the last bucket past the `max` for uneven intervals", func() {
+			err := distribution.Parse(JSON(`{ "valueField": "value", "interval": 3, "min": 0, "max": 10 }`))
+			Expect(err).To(BeNil())
+			buckets, err := distribution.CreateBuckets(map[int64]uint64{})
+			Expect(err).To(BeNil())
+			Expect(buckets).To(HaveLen(4))
+			Expect(buckets[3].Bucket).To(Equal(9.0))
+		})
+	})
+
+	Describe("Encode", func() {
+		It("should encode the histogram and the percentiles in order", func() {
+			err := distribution.Parse(JSON(`{ "valueField": "value", "interval": 1, "percentiles": [90, 50] }`))
+			Expect(err).To(BeNil())
+			data, err := distribution.Encode([]*tile.DistributionBucket{
+				{Bucket: 0, Count: 2},
+				{Bucket: 1, Count: 5},
+			}, []float64{1.75, 1.25})
+			Expect(err).To(BeNil())
+			res, err := json.Unmarshal(data)
+			Expect(err).To(BeNil())
+			Expect(res).To(Equal(JSON(`{
+				"histogram": [
+					{ "bucket": 0, "count": 2 },
+					{ "bucket": 1, "count": 5 }
+				],
+				"percentiles": [
+					{ "percentile": 90, "value": 1.75 },
+					{ "percentile": 50, "value": 1.25 }
+				]
+			}`)))
+		})
+
+		It("should encode undefined percentiles as null", func() {
+			err := distribution.Parse(JSON(`{ "valueField": "value", "interval": 1, "percentiles": [50] }`))
+			Expect(err).To(BeNil())
+			data, err := distribution.Encode([]*tile.DistributionBucket{}, nil)
+			Expect(err).To(BeNil())
+			Expect(string(data)).To(Equal(`{"histogram":[],"percentiles":[{"percentile":50,"value":null}]}`))
+		})
+	})
+})
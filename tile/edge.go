@@ -23,6 +23,8 @@ type Edge struct {
 	RequireDst bool
 	// weight
 	WeightField string
+	// projection
+	Projection binning.Projection
 	// Bounds
 	tileBounds   *geometry.Bounds
 	globalBounds *geometry.Bounds
@@ -69,15 +71,39 @@ func (e *Edge) Parse(params map[string]interface{}) error {
 	e.WeightField = weightField
 	// clear tile bounds
 	e.tileBounds = nil
-	// get the global bounds
-	e.globalBounds = &geometry.Bounds{}
-	return e.globalBounds.Parse(params)
+	// get the projection and global bounds
+	projection, bounds, err := parseProjection(params)
+	if err != nil {
+		return err
+	}
+	e.Projection = projection
+	e.globalBounds = bounds
+	return nil
+}
+
+// Params returns the parameters accepted by the tile.
+func (e *Edge) Params() []json.Param {
+	params := []json.Param{
+		{Key: "srcXField", Type: json.TypeString, Required: true},
+		{Key: "srcYField", Type: json.TypeString, Required: true},
+		{Key: "dstXField", Type: json.TypeString, Required: true},
+		{Key: "dstYField", Type: json.TypeString, Required: true},
+		{Key: "requireSrc", Type: json.TypeBoolean, Default: true},
+		{Key: "requireDst", Type: json.TypeBoolean, Default: false},
+		{Key: "weightField", Type: json.TypeString, Required: true},
+	}
+	return append(params, projectionParams()...)
+}
+
+// GetProjection returns the projection of the tile.
+func (e *Edge) GetProjection() binning.Projection {
+	return e.Projection
 }
 
 // TileBounds computes and returns the tile bounds for the provided tile coord.
 func (e *Edge) TileBounds(coord *binning.TileCoord) *geometry.Bounds {
 	if e.tileBounds == nil {
-		e.tileBounds = binning.GetTileBounds(coord, e.globalBounds)
+		e.tileBounds = e.Projection.TileBounds(coord)
 	}
 	return e.tileBounds
 }
@@ -86,6 +112,10 @@ func (e *Edge) TileBounds(coord *binning.TileCoord) *geometry.Bounds {
 // [0 : 256) for the tile.
 func (e *Edge) GetX(coord *binning.TileCoord, x float64) float64 {
 	bounds := e.TileBounds(coord)
+	if !isLinear(e.Projection) {
+		tx, _ := project(e.Projection, coord, x, bounds.CenterY())
+		return tx
+	}
 	rang := bounds.RangeX()
 	if bounds.Left > bounds.Right {
 		return binning.MaxTileResolution - (((x - bounds.Right) / rang) * binning.MaxTileResolution)
@@ -97,6 +127,10 @@ func (e *Edge) GetX(coord *binning.TileCoord, x float64) float64 {
 // [0 : 256) for the tile.
 func (e *Edge) GetY(coord *binning.TileCoord, y float64) float64 {
 	bounds := e.TileBounds(coord)
+	if !isLinear(e.Projection) {
+		_, ty := project(e.Projection, coord, bounds.CenterX(), y)
+		return ty
+	}
 	rang := bounds.RangeY()
 	if bounds.Bottom > bounds.Top {
 		return binning.MaxTileResolution - (((y - bounds.Top) / rang) * binning.MaxTileResolution)
@@ -122,6 +156,14 @@ func (e *Edge) GetWeight(hit map[string]interface{}) (float64, bool) {
 	return getFloat64(hit, weightPath...)
 }
 
+// Unprojector returns a function which converts a coord within the range of
+// [0 : 256) for the tile back into the corresponding data coordinate.
+func (e *Edge) Unprojector(coord *binning.TileCoord) Unprojector {
+	return func(x float64, y float64) (float64, float64) {
+		return unproject(e.Projection, coord, x, y)
+	}
+}
+
 // GetSrcXY given a data hit, returns the corresponding coord within the range of
 // [0 : 256) for the tile.
 func (e *Edge) getXY(coord *binning.TileCoord, hit map[string]interface{}, xField string, yField string) (float64, float64, bool) {
@@ -131,6 +173,10 @@ func (e *Edge) getXY(coord *binning.TileCoord, hit map[string]interface{}, xFiel
 		return 0, 0, false
 	}
 	// convert to tile pixel coords in the range [0 : 256)
+	if !isLinear(e.Projection) {
+		tx, ty := project(e.Projection, coord, x, y)
+		return tx, ty, true
+	}
 	tx := e.GetX(coord, x)
 	ty := e.GetY(coord, y)
 	// return position in tile coords
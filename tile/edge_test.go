@@ -156,6 +156,21 @@ var _ = Describe("Edge", func() {
 			err := edge.Parse(params)
 			Expect(err).NotTo(BeNil())
 		})
+
+		It("should not require bounds for a geographic `projection`", func() {
+			params := JSON(
+				`{
+					"srcXField": "sx",
+					"srcYField": "sy",
+					"dstXField": "dx",
+					"dstYField": "dy",
+					"weightField": "weight",
+					"projection": "platecarree"
+				}`)
+			err := edge.Parse(params)
+			Expect(err).To(BeNil())
+			Expect(edge.Projection).To(BeAssignableToTypeOf(&binning.PlateCarree{}))
+		})
 	})
 
 	Describe("TileBounds", func() {
@@ -252,6 +267,54 @@ var _ = Describe("Edge", func() {
 			Expect(binD).To(Equal(192.0))
 			Expect(binE).To(Equal(256.0))
 		})
+
+		It("should return the x coordinate of the `projection`", func() {
+			params := JSON(
+				`{
+					"srcXField": "sx",
+					"srcYField": "sy",
+					"dstXField": "dx",
+					"dstYField": "dy",
+					"weightField": "weight",
+					"projection": "mercator"
+				}`)
+			coord := &binning.TileCoord{
+				Z: 0,
+				X: 0,
+				Y: 0,
+			}
+			err := edge.Parse(params)
+			Expect(err).To(BeNil())
+			Expect(edge.GetX(coord, -180.0)).To(BeNumerically("~", 0.0, 0.000001))
+			Expect(edge.GetX(coord, 0.0)).To(BeNumerically("~", 128.0, 0.000001))
+			Expect(edge.GetX(coord, 180.0)).To(BeNumerically("~", 256.0, 0.000001))
+		})
+
+		It("should preserve precision at zoom 20 for unit range bounds", func() {
+			params := JSON(
+				`{
+					"srcXField": "sx",
+					"srcYField": "sy",
+					"dstXField": "dx",
+					"dstYField": "dy",
+					"weightField": "weight",
+					"left": 0.0,
+					"right": 1.0,
+					"bottom": 0.0,
+					"top": 1.0
+				}`)
+			coord := &binning.TileCoord{
+				Z: 20,
+				X: 524288,
+				Y: 524288,
+			}
+			err := edge.Parse(params)
+			Expect(err).To(BeNil())
+			Expect(edge.GetX(coord, 0.5)).To(Equal(0.0))
+			Expect(edge.GetX(coord, 0.5+0.25/1048576.0)).To(BeNumerically("~", 64.0, 0.0001))
+			Expect(edge.GetX(coord, 0.5+0.5/1048576.0)).To(BeNumerically("~", 128.0, 0.0001))
+			Expect(edge.GetY(coord, 0.5+0.75/1048576.0)).To(BeNumerically("~", 192.0, 0.0001))
+		})
 	})
 
 	Describe("GetY", func() {
@@ -542,4 +605,34 @@ var _ = Describe("Edge", func() {
 			Expect(ok).To(Equal(false))
 		})
 	})
+
+	Describe("Unprojector", func() {
+		It("should return the data coordinate of the tile coord", func() {
+			params := JSON(
+				`{
+					"srcXField": "sx",
+					"srcYField": "sy",
+					"dstXField": "dx",
+					"dstYField": "dy",
+					"weightField": "weight",
+					"left": -1.0,
+					"right": 1.0,
+					"bottom": -1.0,
+					"top": 1.0
+				}`)
+			coord := &binning.TileCoord{
+				Z: 2,
+				X: 1,
+				Y: 2,
+			}
+			hit := JSON(`{ "dx": -0.4, "dy": 0.2 }`)
+			err := edge.Parse(params)
+			Expect(err).To(BeNil())
+			tx, ty, ok := edge.GetDstXY(coord, hit)
+			Expect(ok).To(Equal(true))
+			x, y := edge.Unprojector(coord)(tx, ty)
+			Expect(x).To(BeNumerically("~", -0.4, 0.000001))
+			Expect(y).To(BeNumerically("~", 0.2, 0.000001))
+		})
+	})
 })
@@ -0,0 +1,187 @@
+package tile
+
+import (
+	"encoding/binary"
+	"sort"
+)
+
+// fbTable represents a flatbuffer table as the slots of its fields, indexed
+// by field ID. Missing fields are left as empty slots.
+type fbTable []fbSlot
+
+// fbSlot represents a single field of a flatbuffer table. Scalars are stored
+// inline with their size, while strings, tables and vectors are referenced by
+// a 4 byte offset.
+type fbSlot struct {
+	size  int
+	value uint64
+	ref   interface{}
+}
+
+// fbTables represents a vector of tables.
+type fbTables []fbTable
+
+// fbStructs represents a vector of fixed size structs, already laid out in
+// little endian.
+type fbStructs struct {
+	data  []byte
+	count int
+}
+
+func fbBool(val bool) fbSlot {
+	if val {
+		return fbSlot{size: 1, value: 1}
+	}
+	return fbSlot{size: 1}
+}
+
+func fbByte(val uint8) fbSlot {
+	return fbSlot{size: 1, value: uint64(val)}
+}
+
+func fbShort(val int16) fbSlot {
+	return fbSlot{size: 2, value: uint64(uint16(val))}
+}
+
+func fbInt(val int32) fbSlot {
+	return fbSlot{size: 4, value: uint64(uint32(val))}
+}
+
+func fbLong(val int64) fbSlot {
+	return fbSlot{size: 8, value: uint64(val)}
+}
+
+func fbRef(ref interface{}) fbSlot {
+	return fbSlot{size: 4, ref: ref}
+}
+
+// fbBuilder serializes flatbuffers front to back. Every referenced object is
+// written after the field referencing it, so that all offsets are positive.
+type fbBuilder struct {
+	buf []byte
+}
+
+// finish serializes the root table and returns the buffer, padded to 8 bytes.
+func (b *fbBuilder) finish(root fbTable) []byte {
+	b.buf = make([]byte, 4)
+	pos := b.table(root)
+	binary.LittleEndian.PutUint32(b.buf[0:4], uint32(pos))
+	b.align(8)
+	return b.buf
+}
+
+func (b *fbBuilder) align(n int) {
+	for len(b.buf)%n != 0 {
+		b.buf = append(b.buf, 0)
+	}
+}
+
+func (b *fbBuilder) table(t fbTable) int {
+	// lay out the inline fields by descending size, after the vtable offset
+	order := make([]int, len(t))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return t[order[i]].size > t[order[j]].size
+	})
+	offsets := make([]int, len(t))
+	size := 4
+	for _, id := range order {
+		slot := t[id]
+		if slot.size == 0 {
+			continue
+		}
+		for size%slot.size != 0 {
+			size++
+		}
+		offsets[id] = size
+		size += slot.size
+	}
+	// write the vtable
+	b.align(2)
+	vtable := len(b.buf)
+	b.putUint16(uint16(4 + 2*len(t)))
+	b.putUint16(uint16(size))
+	for _, offset := range offsets {
+		b.putUint16(uint16(offset))
+	}
+	// write the table, aligned for its largest field
+	b.align(8)
+	pos := len(b.buf)
+	b.buf = append(b.buf, make([]byte, size)...)
+	binary.LittleEndian.PutUint32(b.buf[pos:], uint32(int32(pos-vtable)))
+	for id, slot := range t {
+		if slot.size == 0 || slot.ref != nil {
+			continue
+		}
+		field := b.buf[pos+offsets[id]:]
+		switch slot.size {
+		case 1:
+			field[0] = uint8(slot.value)
+		case 2:
+			binary.LittleEndian.PutUint16(field, uint16(slot.value))
+		case 4:
+			binary.LittleEndian.PutUint32(field, uint32(slot.value))
+		case 8:
+			binary.LittleEndian.PutUint64(field, slot.value)
+		}
+	}
+	// write the referenced objects
+	for id, slot := range t {
+		if slot.ref == nil {
+			continue
+		}
+		b.patch(pos+offsets[id], b.object(slot.ref))
+	}
+	return pos
+}
+
+func (b *fbBuilder) object(ref interface{}) int {
+	switch obj := ref.(type) {
+	case string:
+		b.align(4)
+		pos := len(b.buf)
+		b.putUint32(uint32(len(obj)))
+		b.buf = append(b.buf, obj...)
+		b.buf = append(b.buf, 0)
+		return pos
+	case fbTable:
+		return b.table(obj)
+	case fbTables:
+		b.align(4)
+		pos := len(b.buf)
+		b.putUint32(uint32(len(obj)))
+		b.buf = append(b.buf, make([]byte, 4*len(obj))...)
+		for i, t := range obj {
+			b.patch(pos+4+4*i, b.table(t))
+		}
+		return pos
+	case fbStructs:
+		// align the elements, rather than the length, to 8 bytes
+		b.align(4)
+		if len(b.buf)%8 == 0 {
+			b.putUint32(0)
+		}
+		pos := len(b.buf)
+		b.putUint32(uint32(obj.count))
+		b.buf = append(b.buf, obj.data...)
+		return pos
+	}
+	panic("unsupported flatbuffer object")
+}
+
+// patch writes the offset from the field to the referenced object.
+func (b *fbBuilder) patch(field int, target int) {
+	binary.LittleEndian.PutUint32(b.buf[field:], uint32(target-field))
+}
+
+func (b *fbBuilder) putUint16(val uint16) {
+	var buf [2]byte
+	binary.LittleEndian.PutUint16(buf[:], val)
+	b.buf = append(b.buf, buf[:]...)
+}
+
+func (b *fbBuilder) putUint32(val uint32) {
+	b.buf = appendUint32(b.buf, val)
+}
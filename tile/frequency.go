@@ -47,3 +47,15 @@ func (t *Frequency) Parse(params map[string]interface{}) error {
 	t.Interval = interval
 	return nil
 }
+
+// Params returns the parameters accepted by the tile.
+func (t *Frequency) Params() []json.Param {
+	return []json.Param{
+		{Key: "frequencyField", Type: json.TypeString, Required: true},
+		{Key: "gte", Type: json.TypeAny},
+		{Key: "gt", Type: json.TypeAny},
+		{Key: "lte", Type: json.TypeAny},
+		{Key: "lt", Type: json.TypeAny},
+		{Key: "interval", Type: json.TypeString, Required: true},
+	}
+}
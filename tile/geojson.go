@@ -0,0 +1,77 @@
+package tile
+
+import (
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+const (
+	// GeoJSONEncoding encodes the tile as a GeoJSON feature collection.
+	GeoJSONEncoding = "geojson"
+)
+
+// Unprojector represents a function which converts a coord within the range
+// of [0 : 256) for the tile into the corresponding data coordinate.
+type Unprojector func(x float64, y float64) (float64, float64)
+
+// GeoJSON represents a GeoJSON feature collection of point and line features.
+// Features are positioned in the data coordinates of the tile, which are
+// lon / lat for the geographic projections.
+type GeoJSON struct {
+	unproject Unprojector
+	features  []interface{}
+}
+
+// NewGeoJSON instantiates and returns a new feature collection which converts
+// positions within the [0 : 256) pixel space of the tile into data
+// coordinates using the provided function.
+func NewGeoJSON(unproject Unprojector) *GeoJSON {
+	return &GeoJSON{
+		unproject: unproject,
+		features:  make([]interface{}, 0),
+	}
+}
+
+// AddPoint adds a point feature with the provided properties to the
+// collection.
+func (g *GeoJSON) AddPoint(x float32, y float32, props map[string]interface{}) {
+	g.addFeature(map[string]interface{}{
+		"type":        "Point",
+		"coordinates": g.position(x, y),
+	}, props)
+}
+
+// AddLine adds a line feature between the provided source and destination
+// with the provided properties to the collection.
+func (g *GeoJSON) AddLine(x0 float32, y0 float32, x1 float32, y1 float32, props map[string]interface{}) {
+	g.addFeature(map[string]interface{}{
+		"type": "LineString",
+		"coordinates": [][]float64{
+			g.position(x0, y0),
+			g.position(x1, y1),
+		},
+	}, props)
+}
+
+// Encode encodes the feature collection as JSON.
+func (g *GeoJSON) Encode() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"type":     "FeatureCollection",
+		"features": g.features,
+	})
+}
+
+func (g *GeoJSON) addFeature(geometry map[string]interface{}, props map[string]interface{}) {
+	if props == nil {
+		props = make(map[string]interface{})
+	}
+	g.features = append(g.features, map[string]interface{}{
+		"type":       "Feature",
+		"geometry":   geometry,
+		"properties": props,
+	})
+}
+
+func (g *GeoJSON) position(x float32, y float32) []float64 {
+	dx, dy := g.unproject(float64(x), float64(y))
+	return []float64{dx, dy}
+}
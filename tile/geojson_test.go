@@ -0,0 +1,177 @@
+package tile_test
+
+import (
+	"github.com/unchartedsoftware/veldt/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+var _ = Describe("GeoJSON", func() {
+
+	// maps the [0 : 256) pixel space of the tile onto [0 : 1)
+	unproject := func(x float64, y float64) (float64, float64) {
+		return x / 256, y / 256
+	}
+
+	It("should encode points and lines as a feature collection", func() {
+		collection := tile.NewGeoJSON(unproject)
+		collection.AddPoint(64, 128, map[string]interface{}{
+			"name": "a",
+		})
+		collection.AddLine(0, 0, 128, 256, nil)
+		bytes, err := collection.Encode()
+		Expect(err).To(BeNil())
+		Expect(bytes).To(MatchJSON(`{
+			"type": "FeatureCollection",
+			"features": [
+				{
+					"type": "Feature",
+					"geometry": {
+						"type": "Point",
+						"coordinates": [0.25, 0.5]
+					},
+					"properties": {
+						"name": "a"
+					}
+				},
+				{
+					"type": "Feature",
+					"geometry": {
+						"type": "LineString",
+						"coordinates": [[0, 0], [0.5, 1]]
+					},
+					"properties": {}
+				}
+			]
+		}`))
+	})
+
+	It("should encode an empty feature collection", func() {
+		bytes, err := tile.NewGeoJSON(unproject).Encode()
+		Expect(err).To(BeNil())
+		Expect(bytes).To(MatchJSON(`{"type":"FeatureCollection","features":[]}`))
+	})
+
+	Describe("Encoding", func() {
+
+		It("should encode micro tile hits as feature properties", func() {
+			micro := &tile.Micro{}
+			err := micro.Parse(JSON(
+				`{
+					"encoding": "geojson"
+				}`))
+			Expect(err).To(BeNil())
+			bytes, err := micro.Encode([]map[string]interface{}{
+				{"name": "a"},
+			}, []float32{64, 192}, unproject)
+			Expect(err).To(BeNil())
+			Expect(bytes).To(MatchJSON(`{
+				"type": "FeatureCollection",
+				"features": [
+					{
+						"type": "Feature",
+						"geometry": {
+							"type": "Point",
+							"coordinates": [0.25, 0.75]
+						},
+						"properties": {
+							"name": "a"
+						}
+					}
+				]
+			}`))
+		})
+
+		It("should encode macro tile points", func() {
+			macro := &tile.Macro{}
+			err := macro.Parse(JSON(
+				`{
+					"encoding": "geojson"
+				}`))
+			Expect(err).To(BeNil())
+			bytes, err := macro.Encode([]float32{128, 64}, unproject)
+			Expect(err).To(BeNil())
+			Expect(bytes).To(MatchJSON(`{
+				"type": "FeatureCollection",
+				"features": [
+					{
+						"type": "Feature",
+						"geometry": {
+							"type": "Point",
+							"coordinates": [0.5, 0.25]
+						},
+						"properties": {}
+					}
+				]
+			}`))
+		})
+
+		It("should encode macro edge tile edges with their weight", func() {
+			edge := &tile.MacroEdge{}
+			err := edge.Parse(JSON(
+				`{
+					"encoding": "geojson"
+				}`))
+			Expect(err).To(BeNil())
+			bytes, err := edge.Encode([]float32{0, 64, 3, 128, 256, 3}, unproject)
+			Expect(err).To(BeNil())
+			Expect(bytes).To(MatchJSON(`{
+				"type": "FeatureCollection",
+				"features": [
+					{
+						"type": "Feature",
+						"geometry": {
+							"type": "LineString",
+							"coordinates": [[0, 0.25], [0.5, 1]]
+						},
+						"properties": {
+							"weight": 3
+						}
+					}
+				]
+			}`))
+		})
+
+		It("should encode micro edge tile hits as feature properties", func() {
+			edge := &tile.MicroEdge{}
+			err := edge.Parse(JSON(
+				`{
+					"encoding": "geojson"
+				}`))
+			Expect(err).To(BeNil())
+			bytes, err := edge.Encode([]map[string]interface{}{
+				{"name": "a"},
+			}, []float32{0, 64, 128, 256}, unproject)
+			Expect(err).To(BeNil())
+			Expect(bytes).To(MatchJSON(`{
+				"type": "FeatureCollection",
+				"features": [
+					{
+						"type": "Feature",
+						"geometry": {
+							"type": "LineString",
+							"coordinates": [[0, 0.25], [0.5, 1]]
+						},
+						"properties": {
+							"name": "a"
+						}
+					}
+				]
+			}`))
+		})
+
+		It("should return an error if LOD is requested with the geojson encoding", func() {
+			macro := &tile.Macro{}
+			err := macro.Parse(JSON(
+				`{
+					"encoding": "geojson",
+					"lod": 2
+				}`))
+			Expect(err).NotTo(BeNil())
+		})
+
+	})
+
+})
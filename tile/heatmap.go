@@ -0,0 +1,127 @@
+package tile
+
+import (
+	"fmt"
+
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+const (
+	// CountAggregation counts the data points in each bin.
+	CountAggregation = "count"
+	// SumAggregation sums the value field in each bin.
+	SumAggregation = "sum"
+	// AvgAggregation averages the value field in each bin.
+	AvgAggregation = "avg"
+	// MinAggregation takes the minimum of the value field in each bin.
+	MinAggregation = "min"
+	// MaxAggregation takes the maximum of the value field in each bin.
+	MaxAggregation = "max"
+	// CardinalityAggregation counts the distinct values of the value field in
+	// each bin.
+	CardinalityAggregation = "cardinality"
+)
+
+// Heatmap represents a tile which aggregates the data points, or a metric
+// field of them, into each bin.
+type Heatmap struct {
+	ValueField  string
+	Aggregation string
+	// Encoding is the encoding of the bins, if empty the bins are encoded as
+	// float32 values without a header.
+	Encoding string
+	// LOD is the number of downsampled levels encoded along with the bins,
+	// each reduced with LODReduce.
+	LOD       int
+	LODReduce string
+}
+
+// Parse parses the provided JSON object and populates the tiles attributes.
+func (h *Heatmap) Parse(params map[string]interface{}) error {
+	aggregation := json.GetStringDefault(params, CountAggregation, "aggregation")
+	switch aggregation {
+	case CountAggregation, SumAggregation, AvgAggregation, MinAggregation,
+		MaxAggregation, CardinalityAggregation:
+	default:
+		return fmt.Errorf("`aggregation` parameter `%s` is not recognized", aggregation)
+	}
+	valueField, ok := json.GetString(params, "valueField")
+	if !ok && aggregation != CountAggregation {
+		return fmt.Errorf("`valueField` parameter missing from tile")
+	}
+	encoding, ok := json.GetString(params, "encoding")
+	if ok {
+		_, ok = heatmapTypes[encoding]
+		if !ok {
+			return fmt.Errorf("`encoding` parameter `%s` is not recognized", encoding)
+		}
+	}
+	lod := json.GetIntDefault(params, 0, "lod")
+	if lod < 0 {
+		return fmt.Errorf("`lod` parameter must not be negative")
+	}
+	reduce := json.GetStringDefault(params, SumReduce, "lodReduce")
+	if _, ok := heatmapReduces[reduce]; !ok {
+		return fmt.Errorf("`lodReduce` parameter `%s` is not recognized", reduce)
+	}
+	h.ValueField = valueField
+	h.Aggregation = aggregation
+	h.Encoding = encoding
+	h.LOD = lod
+	h.LODReduce = reduce
+	return nil
+}
+
+// Params returns the parameters accepted by the tile.
+func (h *Heatmap) Params() []json.Param {
+	return []json.Param{
+		{Key: "valueField", Type: json.TypeString},
+		{
+			Key:     "aggregation",
+			Type:    json.TypeString,
+			Default: CountAggregation,
+			Enum: []interface{}{
+				CountAggregation,
+				SumAggregation,
+				AvgAggregation,
+				MinAggregation,
+				MaxAggregation,
+				CardinalityAggregation,
+			},
+		},
+		{
+			Key:  "encoding",
+			Type: json.TypeString,
+			Enum: []interface{}{
+				Uint32Encoding,
+				Float32Encoding,
+				Uint16Encoding,
+				Uint8Encoding,
+			},
+		},
+		{Key: "lod", Type: json.TypeInteger, Default: 0},
+		{
+			Key:     "lodReduce",
+			Type:    json.TypeString,
+			Default: SumReduce,
+			Enum:    []interface{}{SumReduce, MaxReduce},
+		},
+	}
+}
+
+// Encode encodes the bins with the encoding of the tile. If no encoding is
+// set, the bins are encoded as a byte array of little endian float32 values
+// without a header, which existing clients expect, so the layout of the
+// payload cannot be detected from its bytes.
+// If a LOD is set, the bins are encoded as a pyramid of downsampled levels.
+func (h *Heatmap) Encode(bins []float32) ([]byte, error) {
+	if h.LOD > 0 {
+		return EncodeHeatmapLOD(bins, h.LOD, h.LODReduce, h.Encoding)
+	}
+	return encodeBins(bins, h.Encoding)
+}
+
+// Decode decodes the full resolution bins encoded by Encode.
+func (h *Heatmap) Decode(data []byte) ([]float32, error) {
+	return DecodeBins(data, h.Encoding, h.LOD)
+}
@@ -0,0 +1,224 @@
+package tile
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+const (
+	// Uint32Encoding encodes each bin as a uint32, rounding the values.
+	Uint32Encoding = "uint32"
+	// Float32Encoding encodes each bin as a float32.
+	Float32Encoding = "float32"
+	// Uint16Encoding quantizes each bin into a uint16 between the minimum and
+	// maximum bin values.
+	Uint16Encoding = "uint16"
+	// Uint8Encoding quantizes each bin into a uint8 between the minimum and
+	// maximum bin values.
+	Uint8Encoding = "uint8"
+
+	heatmapVersion      = 1
+	heatmapHeaderSize   = 12
+	heatmapScaleSize    = 8
+	heatmapDenseLayout  = 0
+	heatmapSparseLayout = 1
+	sparseIndexSize     = 4
+)
+
+var (
+	heatmapTypes = map[string]byte{
+		Uint32Encoding:  1,
+		Float32Encoding: 2,
+		Uint16Encoding:  3,
+		Uint8Encoding:   4,
+	}
+	heatmapEncodings = map[byte]string{
+		1: Uint32Encoding,
+		2: Float32Encoding,
+		3: Uint16Encoding,
+		4: Uint8Encoding,
+	}
+)
+
+// EncodeHeatmap encodes the bins with the provided encoding, prefixed by a
+// header which describes it:
+//
+//     byte 0:       version
+//     byte 1:       encoding, 1 = uint32, 2 = float32, 3 = uint16, 4 = uint8
+//     byte 2:       layout, 0 = dense, 1 = sparse
+//     byte 3:       reserved
+//     bytes 4-7:    uint32 number of bins
+//     bytes 8-11:   uint32 number of encoded values
+//     bytes 12-19:  float32 minimum and maximum, quantized encodings only
+//
+// All values are little endian. Dense layouts encode every bin, while sparse
+// layouts encode a uint32 index before each non-zero bin. The sparse layout is
+// used whenever it is smaller.
+func EncodeHeatmap(bins []float32, encoding string) ([]byte, error) {
+	typ, ok := heatmapTypes[encoding]
+	if !ok {
+		return nil, fmt.Errorf("unrecognized heatmap encoding `%s`", encoding)
+	}
+	size := heatmapValueSize(encoding)
+	quantized := isQuantized(encoding)
+	// find the range of quantized values
+	var min, max float32
+	if quantized && len(bins) > 0 {
+		min, max = bins[0], bins[0]
+		for _, bin := range bins {
+			min = float32(math.Min(float64(min), float64(bin)))
+			max = float32(math.Max(float64(max), float64(bin)))
+		}
+	}
+	// count the non-zero bins
+	nonZero := 0
+	for _, bin := range bins {
+		if bin != 0 {
+			nonZero++
+		}
+	}
+	layout := byte(heatmapDenseLayout)
+	count := len(bins)
+	if nonZero*(sparseIndexSize+size) < len(bins)*size {
+		layout = heatmapSparseLayout
+		count = nonZero
+	}
+	// write the header
+	offset := heatmapHeaderSize
+	if quantized {
+		offset += heatmapScaleSize
+	}
+	stride := size
+	if layout == heatmapSparseLayout {
+		stride += sparseIndexSize
+	}
+	bytes := make([]byte, offset+count*stride)
+	bytes[0] = heatmapVersion
+	bytes[1] = typ
+	bytes[2] = layout
+	binary.LittleEndian.PutUint32(bytes[4:8], uint32(len(bins)))
+	binary.LittleEndian.PutUint32(bytes[8:12], uint32(count))
+	if quantized {
+		binary.LittleEndian.PutUint32(bytes[12:16], math.Float32bits(min))
+		binary.LittleEndian.PutUint32(bytes[16:20], math.Float32bits(max))
+	}
+	// write the values
+	for i, bin := range bins {
+		if layout == heatmapSparseLayout {
+			if bin == 0 {
+				continue
+			}
+			binary.LittleEndian.PutUint32(bytes[offset:offset+sparseIndexSize], uint32(i))
+			offset += sparseIndexSize
+		}
+		putHeatmapValue(bytes[offset:offset+size], encoding, bin, min, max)
+		offset += size
+	}
+	return bytes, nil
+}
+
+// DecodeHeatmap decodes bins encoded by EncodeHeatmap. Quantized values are
+// restored to within the precision of their encoding.
+func DecodeHeatmap(bytes []byte) ([]float32, error) {
+	if len(bytes) < heatmapHeaderSize {
+		return nil, fmt.Errorf("heatmap header is truncated")
+	}
+	if bytes[0] != heatmapVersion {
+		return nil, fmt.Errorf("unsupported heatmap version `%d`", bytes[0])
+	}
+	encoding, ok := heatmapEncodings[bytes[1]]
+	if !ok {
+		return nil, fmt.Errorf("unrecognized heatmap encoding `%d`", bytes[1])
+	}
+	layout := bytes[2]
+	if layout != heatmapDenseLayout && layout != heatmapSparseLayout {
+		return nil, fmt.Errorf("unrecognized heatmap layout `%d`", layout)
+	}
+	numBins := int(binary.LittleEndian.Uint32(bytes[4:8]))
+	count := int(binary.LittleEndian.Uint32(bytes[8:12]))
+	size := heatmapValueSize(encoding)
+	offset := heatmapHeaderSize
+	var min, max float32
+	if isQuantized(encoding) {
+		if len(bytes) < offset+heatmapScaleSize {
+			return nil, fmt.Errorf("heatmap header is truncated")
+		}
+		min = math.Float32frombits(binary.LittleEndian.Uint32(bytes[12:16]))
+		max = math.Float32frombits(binary.LittleEndian.Uint32(bytes[16:20]))
+		offset += heatmapScaleSize
+	}
+	stride := size
+	if layout == heatmapSparseLayout {
+		stride += sparseIndexSize
+	} else if count != numBins {
+		return nil, fmt.Errorf("dense heatmap has %d values for %d bins", count, numBins)
+	}
+	if len(bytes) != offset+count*stride {
+		return nil, fmt.Errorf("heatmap is %d bytes, expected %d", len(bytes), offset+count*stride)
+	}
+	bins := make([]float32, numBins)
+	for i := 0; i < count; i++ {
+		index := i
+		if layout == heatmapSparseLayout {
+			index = int(binary.LittleEndian.Uint32(bytes[offset : offset+sparseIndexSize]))
+			offset += sparseIndexSize
+			if index >= numBins {
+				return nil, fmt.Errorf("heatmap bin index %d is out of range", index)
+			}
+		}
+		bins[index] = getHeatmapValue(bytes[offset:offset+size], encoding, min, max)
+		offset += size
+	}
+	return bins, nil
+}
+
+func heatmapValueSize(encoding string) int {
+	switch encoding {
+	case Uint16Encoding:
+		return 2
+	case Uint8Encoding:
+		return 1
+	}
+	return 4
+}
+
+func isQuantized(encoding string) bool {
+	return encoding == Uint16Encoding || encoding == Uint8Encoding
+}
+
+func quantize(val float32, min float32, max float32, levels float64) float64 {
+	if max == min {
+		return 0
+	}
+	return math.Floor(float64(val-min)/float64(max-min)*levels + 0.5)
+}
+
+func unquantize(val float64, min float32, max float32, levels float64) float32 {
+	return min + float32(val/levels)*(max-min)
+}
+
+func putHeatmapValue(bytes []byte, encoding string, val float32, min float32, max float32) {
+	switch encoding {
+	case Uint32Encoding:
+		binary.LittleEndian.PutUint32(bytes, uint32(math.Max(0, math.Floor(float64(val)+0.5))))
+	case Float32Encoding:
+		binary.LittleEndian.PutUint32(bytes, math.Float32bits(val))
+	case Uint16Encoding:
+		binary.LittleEndian.PutUint16(bytes, uint16(quantize(val, min, max, math.MaxUint16)))
+	case Uint8Encoding:
+		bytes[0] = uint8(quantize(val, min, max, math.MaxUint8))
+	}
+}
+
+func getHeatmapValue(bytes []byte, encoding string, min float32, max float32) float32 {
+	switch encoding {
+	case Uint32Encoding:
+		return float32(binary.LittleEndian.Uint32(bytes))
+	case Uint16Encoding:
+		return unquantize(float64(binary.LittleEndian.Uint16(bytes)), min, max, math.MaxUint16)
+	case Uint8Encoding:
+		return unquantize(float64(bytes[0]), min, max, math.MaxUint8)
+	}
+	return math.Float32frombits(binary.LittleEndian.Uint32(bytes))
+}
@@ -0,0 +1,89 @@
+package tile_test
+
+import (
+	"github.com/unchartedsoftware/veldt/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EncodeHeatmap", func() {
+
+	var (
+		dense  []float32
+		sparse []float32
+	)
+
+	BeforeEach(func() {
+		dense = make([]float32, 16)
+		for i := range dense {
+			dense[i] = float32(i) * 2
+		}
+		sparse = make([]float32, 256)
+		sparse[3] = 7
+		sparse[200] = 1.5
+	})
+
+	It("should round trip float32 bins", func() {
+		for _, bins := range [][]float32{dense, sparse} {
+			bytes, err := tile.EncodeHeatmap(bins, tile.Float32Encoding)
+			Expect(err).To(BeNil())
+			decoded, err := tile.DecodeHeatmap(bytes)
+			Expect(err).To(BeNil())
+			Expect(decoded).To(Equal(bins))
+		}
+	})
+
+	It("should round uint32 bins", func() {
+		bytes, err := tile.EncodeHeatmap([]float32{1.4, 1.6, 3}, tile.Uint32Encoding)
+		Expect(err).To(BeNil())
+		decoded, err := tile.DecodeHeatmap(bytes)
+		Expect(err).To(BeNil())
+		Expect(decoded).To(Equal([]float32{1, 2, 3}))
+	})
+
+	It("should quantize bins between the minimum and maximum", func() {
+		for _, encoding := range []string{tile.Uint8Encoding, tile.Uint16Encoding} {
+			bytes, err := tile.EncodeHeatmap(dense, encoding)
+			Expect(err).To(BeNil())
+			decoded, err := tile.DecodeHeatmap(bytes)
+			Expect(err).To(BeNil())
+			Expect(len(decoded)).To(Equal(len(dense)))
+			for i, bin := range dense {
+				Expect(decoded[i]).To(BeNumerically("~", bin, 30.0/255.0))
+			}
+		}
+	})
+
+	It("should use the sparse layout when it is smaller", func() {
+		bytes, err := tile.EncodeHeatmap(sparse, tile.Float32Encoding)
+		Expect(err).To(BeNil())
+		Expect(bytes[2]).To(Equal(byte(1)))
+		Expect(len(bytes)).To(Equal(12 + 2*8))
+		decoded, err := tile.DecodeHeatmap(bytes)
+		Expect(err).To(BeNil())
+		Expect(decoded).To(Equal(sparse))
+	})
+
+	It("should use the dense layout when it is smaller", func() {
+		bytes, err := tile.EncodeHeatmap(dense, tile.Uint8Encoding)
+		Expect(err).To(BeNil())
+		Expect(bytes[2]).To(Equal(byte(0)))
+		Expect(len(bytes)).To(Equal(12 + 8 + 16))
+	})
+
+	It("should return an error for an unrecognized encoding", func() {
+		_, err := tile.EncodeHeatmap(dense, "int64")
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should return an error when decoding truncated bytes", func() {
+		bytes, err := tile.EncodeHeatmap(dense, tile.Float32Encoding)
+		Expect(err).To(BeNil())
+		_, err = tile.DecodeHeatmap(bytes[:len(bytes)-1])
+		Expect(err).NotTo(BeNil())
+		_, err = tile.DecodeHeatmap(bytes[:4])
+		Expect(err).NotTo(BeNil())
+	})
+
+})
@@ -0,0 +1,187 @@
+package tile
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+const (
+	// SumReduce sums the four bins of each 2x2 block when downsampling a
+	// heatmap LOD level.
+	SumReduce = "sum"
+	// MaxReduce takes the maximum of the four bins of each 2x2 block when
+	// downsampling a heatmap LOD level.
+	MaxReduce = "max"
+
+	heatmapLODHeaderSize = 8
+	heatmapLODEntrySize  = 12
+)
+
+var (
+	heatmapReduces = map[string]byte{
+		SumReduce: 1,
+		MaxReduce: 2,
+	}
+)
+
+// EncodeHeatmapLOD encodes the bins along with lod levels, each downsampled
+// by 2x from the previous level using the provided reduction. Each level is
+// encoded with the provided encoding, or as little endian float32 values if
+// the encoding is empty. The levels are prefixed by a header and a table of
+// their offsets:
+//
+//     byte 0:       version
+//     byte 1:       0, reserved
+//     byte 2:       reduction, 1 = sum, 2 = max
+//     byte 3:       reserved
+//     bytes 4-7:    uint32 number of levels
+//
+// Followed by a uint32 resolution, byte offset and byte length for each
+// level, ordered from the full resolution bins to the lowest resolution. All
+// values are little endian.
+func EncodeHeatmapLOD(bins []float32, lod int, reduce string, encoding string) ([]byte, error) {
+	typ, ok := heatmapReduces[reduce]
+	if !ok {
+		return nil, fmt.Errorf("unrecognized heatmap reduction `%s`", reduce)
+	}
+	resolution := int(math.Sqrt(float64(len(bins))))
+	if resolution*resolution != len(bins) {
+		return nil, fmt.Errorf("heatmap of %d bins is not square", len(bins))
+	}
+	if lod < 0 || resolution%(1<<uint(lod)) != 0 {
+		return nil, fmt.Errorf("heatmap resolution %d cannot be downsampled %d times", resolution, lod)
+	}
+	// encode each level
+	levels := make([][]byte, lod+1)
+	resolutions := make([]int, lod+1)
+	for i := range levels {
+		if i > 0 {
+			bins = reduceBins(bins, resolution, reduce)
+			resolution /= 2
+		}
+		level, err := encodeBins(bins, encoding)
+		if err != nil {
+			return nil, err
+		}
+		levels[i] = level
+		resolutions[i] = resolution
+	}
+	// write the header and offset table
+	offset := heatmapLODHeaderSize + len(levels)*heatmapLODEntrySize
+	bytes := make([]byte, offset)
+	bytes[0] = heatmapVersion
+	bytes[2] = typ
+	binary.LittleEndian.PutUint32(bytes[4:8], uint32(len(levels)))
+	for i, level := range levels {
+		entry := bytes[heatmapLODHeaderSize+i*heatmapLODEntrySize:]
+		binary.LittleEndian.PutUint32(entry[0:4], uint32(resolutions[i]))
+		binary.LittleEndian.PutUint32(entry[4:8], uint32(offset))
+		binary.LittleEndian.PutUint32(entry[8:12], uint32(len(level)))
+		offset += len(level)
+	}
+	// write the levels
+	for _, level := range levels {
+		bytes = append(bytes, level...)
+	}
+	return bytes, nil
+}
+
+// DecodeHeatmapLOD decodes the levels encoded by EncodeHeatmapLOD with the
+// provided encoding, ordered from the full resolution bins to the lowest
+// resolution.
+func DecodeHeatmapLOD(bytes []byte, encoding string) ([][]float32, error) {
+	if !isHeatmapLOD(bytes) {
+		return nil, fmt.Errorf("bytes are not a heatmap LOD pyramid")
+	}
+	numLevels := int(binary.LittleEndian.Uint32(bytes[4:8]))
+	if len(bytes) < heatmapLODHeaderSize+numLevels*heatmapLODEntrySize {
+		return nil, fmt.Errorf("heatmap LOD offset table is truncated")
+	}
+	levels := make([][]float32, numLevels)
+	for i := range levels {
+		entry := bytes[heatmapLODHeaderSize+i*heatmapLODEntrySize:]
+		resolution := int(binary.LittleEndian.Uint32(entry[0:4]))
+		offset := int(binary.LittleEndian.Uint32(entry[4:8]))
+		length := int(binary.LittleEndian.Uint32(entry[8:12]))
+		if offset+length > len(bytes) {
+			return nil, fmt.Errorf("heatmap LOD level %d is out of range", i)
+		}
+		bins, err := decodeBins(bytes[offset:offset+length], encoding)
+		if err != nil {
+			return nil, err
+		}
+		if len(bins) != resolution*resolution {
+			return nil, fmt.Errorf("heatmap LOD level %d has %d bins for resolution %d", i, len(bins), resolution)
+		}
+		levels[i] = bins
+	}
+	return levels, nil
+}
+
+// isHeatmapLOD returns whether the bytes begin with a valid EncodeHeatmapLOD
+// header.
+func isHeatmapLOD(bytes []byte) bool {
+	return len(bytes) >= heatmapLODHeaderSize &&
+		bytes[0] == heatmapVersion &&
+		bytes[1] == 0 &&
+		(bytes[2] == heatmapReduces[SumReduce] || bytes[2] == heatmapReduces[MaxReduce])
+}
+
+// reduceBins downsamples the bins by 2x, reducing each 2x2 block of bins into
+// a single bin.
+func reduceBins(bins []float32, resolution int, reduce string) []float32 {
+	half := resolution / 2
+	reduced := make([]float32, half*half)
+	for y := 0; y < half; y++ {
+		for x := 0; x < half; x++ {
+			i := (y*2)*resolution + x*2
+			block := []float32{
+				bins[i],
+				bins[i+1],
+				bins[i+resolution],
+				bins[i+resolution+1],
+			}
+			val := block[0]
+			for _, bin := range block[1:] {
+				if reduce == MaxReduce {
+					val = float32(math.Max(float64(val), float64(bin)))
+				} else {
+					val += bin
+				}
+			}
+			reduced[y*half+x] = val
+		}
+	}
+	return reduced
+}
+
+// encodeBins encodes the bins with the provided encoding, or as little endian
+// float32 values if the encoding is empty.
+func encodeBins(bins []float32, encoding string) ([]byte, error) {
+	if encoding != "" {
+		return EncodeHeatmap(bins, encoding)
+	}
+	bytes := make([]byte, len(bins)*4)
+	for i, bin := range bins {
+		binary.LittleEndian.PutUint32(
+			bytes[i*4:i*4+4],
+			math.Float32bits(bin))
+	}
+	return bytes, nil
+}
+
+// decodeBins decodes bins encoded by encodeBins with the provided encoding.
+func decodeBins(data []byte, encoding string) ([]float32, error) {
+	if encoding != "" {
+		return DecodeHeatmap(data)
+	}
+	if len(data)%4 != 0 {
+		return nil, fmt.Errorf("heatmap of %d bytes is not a sequence of float32 bins", len(data))
+	}
+	bins := make([]float32, len(data)/4)
+	for i := range bins {
+		bins[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[i*4 : i*4+4]))
+	}
+	return bins, nil
+}
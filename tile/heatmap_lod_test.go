@@ -0,0 +1,99 @@
+package tile_test
+
+import (
+	"encoding/binary"
+
+	"github.com/unchartedsoftware/veldt/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HeatmapLOD", func() {
+
+	var bins []float32
+
+	BeforeEach(func() {
+		bins = make([]float32, 4*4)
+		for i := range bins {
+			bins[i] = float32(i)
+		}
+	})
+
+	It("should encode each level summed from the previous level", func() {
+		bytes, err := tile.EncodeHeatmapLOD(bins, 2, tile.SumReduce, "")
+		Expect(err).To(BeNil())
+		levels, err := tile.DecodeHeatmapLOD(bytes, "")
+		Expect(err).To(BeNil())
+		Expect(levels).To(Equal([][]float32{
+			bins,
+			{10, 18, 42, 50},
+			{120},
+		}))
+	})
+
+	It("should encode each level as the maximum of the previous level", func() {
+		bytes, err := tile.EncodeHeatmapLOD(bins, 1, tile.MaxReduce, "")
+		Expect(err).To(BeNil())
+		levels, err := tile.DecodeHeatmapLOD(bytes, "")
+		Expect(err).To(BeNil())
+		Expect(levels).To(Equal([][]float32{
+			bins,
+			{5, 7, 13, 15},
+		}))
+	})
+
+	It("should write the resolution, offset and length of each level", func() {
+		bytes, err := tile.EncodeHeatmapLOD(bins, 2, tile.SumReduce, "")
+		Expect(err).To(BeNil())
+		Expect(bytes[1]).To(Equal(byte(0)))
+		Expect(binary.LittleEndian.Uint32(bytes[4:8])).To(Equal(uint32(3)))
+		offset := uint32(8 + 3*12)
+		for i, resolution := range []uint32{4, 2, 1} {
+			entry := bytes[8+i*12:]
+			Expect(binary.LittleEndian.Uint32(entry[0:4])).To(Equal(resolution))
+			Expect(binary.LittleEndian.Uint32(entry[4:8])).To(Equal(offset))
+			Expect(binary.LittleEndian.Uint32(entry[8:12])).To(Equal(resolution * resolution * 4))
+			offset += resolution * resolution * 4
+		}
+		Expect(len(bytes)).To(Equal(int(offset)))
+	})
+
+	It("should encode each level with the provided encoding", func() {
+		bytes, err := tile.EncodeHeatmapLOD(bins, 1, tile.SumReduce, "uint32")
+		Expect(err).To(BeNil())
+		levels, err := tile.DecodeHeatmapLOD(bytes, "uint32")
+		Expect(err).To(BeNil())
+		Expect(levels[1]).To(Equal([]float32{10, 18, 42, 50}))
+		// each level is prefixed with a heatmap header
+		offset := binary.LittleEndian.Uint32(bytes[12:16])
+		length := binary.LittleEndian.Uint32(bytes[16:20])
+		decoded, err := tile.DecodeHeatmap(bytes[offset : offset+length])
+		Expect(err).To(BeNil())
+		Expect(decoded).To(Equal(bins))
+	})
+
+	It("should decode the full resolution bins with DecodeBins", func() {
+		bytes, err := tile.EncodeHeatmapLOD(bins, 2, tile.MaxReduce, "uint16")
+		Expect(err).To(BeNil())
+		decoded, err := tile.DecodeBins(bytes, "uint16", 2)
+		Expect(err).To(BeNil())
+		Expect(decoded).To(HaveLen(16))
+	})
+
+	It("should return an error if the bins are not square", func() {
+		_, err := tile.EncodeHeatmapLOD(bins[:12], 1, tile.SumReduce, "")
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should return an error if the resolution cannot be downsampled", func() {
+		_, err := tile.EncodeHeatmapLOD(bins, 3, tile.SumReduce, "")
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should return an error if the reduction is not recognized", func() {
+		_, err := tile.EncodeHeatmapLOD(bins, 1, "avg", "")
+		Expect(err).NotTo(BeNil())
+	})
+
+})
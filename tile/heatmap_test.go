@@ -0,0 +1,121 @@
+package tile_test
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/unchartedsoftware/veldt/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+var _ = Describe("Heatmap", func() {
+
+	var heatmap *tile.Heatmap
+
+	BeforeEach(func() {
+		heatmap = &tile.Heatmap{}
+	})
+
+	Describe("Parse", func() {
+		It("should parse properties from the params argument", func() {
+			params := JSON(
+				`{
+					"valueField": "value",
+					"aggregation": "avg"
+				}`)
+			err := heatmap.Parse(params)
+			Expect(err).To(BeNil())
+			Expect(heatmap.ValueField).To(Equal("value"))
+			Expect(heatmap.Aggregation).To(Equal(tile.AvgAggregation))
+		})
+
+		It("should count the data points by default", func() {
+			params := JSON(`{}`)
+			err := heatmap.Parse(params)
+			Expect(err).To(BeNil())
+			Expect(heatmap.Aggregation).To(Equal(tile.CountAggregation))
+		})
+
+		It("should return an error if the `aggregation` is not recognized", func() {
+			params := JSON(
+				`{
+					"valueField": "value",
+					"aggregation": "median"
+				}`)
+			err := heatmap.Parse(params)
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should return an error if the `encoding` is not recognized", func() {
+			params := JSON(
+				`{
+					"encoding": "int64"
+				}`)
+			err := heatmap.Parse(params)
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should parse the `lod` and `lodReduce` parameters", func() {
+			params := JSON(
+				`{
+					"lod": 2,
+					"lodReduce": "max"
+				}`)
+			err := heatmap.Parse(params)
+			Expect(err).To(BeNil())
+			Expect(heatmap.LOD).To(Equal(2))
+			Expect(heatmap.LODReduce).To(Equal(tile.MaxReduce))
+		})
+
+		It("should return an error if the `lodReduce` is not recognized", func() {
+			params := JSON(
+				`{
+					"lod": 1,
+					"lodReduce": "avg"
+				}`)
+			err := heatmap.Parse(params)
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should return an error if `valueField` is not specified for a metric aggregation", func() {
+			params := JSON(
+				`{
+					"aggregation": "sum"
+				}`)
+			err := heatmap.Parse(params)
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Encode", func() {
+		It("should encode the bins with the `encoding` parameter", func() {
+			params := JSON(
+				`{
+					"encoding": "uint16"
+				}`)
+			err := heatmap.Parse(params)
+			Expect(err).To(BeNil())
+			bytes, err := heatmap.Encode([]float32{0, 1, 2})
+			Expect(err).To(BeNil())
+			bins, err := tile.DecodeHeatmap(bytes)
+			Expect(err).To(BeNil())
+			Expect(len(bins)).To(Equal(3))
+			Expect(bins[0]).To(BeNumerically("~", 0, 0.001))
+			Expect(bins[1]).To(BeNumerically("~", 1, 0.001))
+			Expect(bins[2]).To(BeNumerically("~", 2, 0.001))
+		})
+
+		It("should encode the bins as little endian float32 values", func() {
+			bytes, err := heatmap.Encode([]float32{0, 1.5, 1024.125})
+			Expect(err).To(BeNil())
+			Expect(len(bytes)).To(Equal(12))
+			Expect(math.Float32frombits(binary.LittleEndian.Uint32(bytes[0:4]))).To(Equal(float32(0)))
+			Expect(math.Float32frombits(binary.LittleEndian.Uint32(bytes[4:8]))).To(Equal(float32(1.5)))
+			Expect(math.Float32frombits(binary.LittleEndian.Uint32(bytes[8:12]))).To(Equal(float32(1024.125)))
+		})
+	})
+
+})
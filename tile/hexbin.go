@@ -0,0 +1,186 @@
+package tile
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/geometry"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+const (
+	defaultHexSize        = 16.0
+	defaultHexOrientation = binning.PointyHex
+	hexCellStride         = 12
+)
+
+// HexCell represents a hexagonal cell and the number of data points it
+// contains.
+type HexCell struct {
+	binning.HexCoord
+	Count float64
+}
+
+// Hexbin represents the parameters required for any hexagonal binning tile.
+// Cells are laid over the global pixel space of the zoom level, and each cell
+// belongs to the tile containing its center.
+type Hexbin struct {
+	XField      string
+	YField      string
+	HexSize     float64
+	Orientation string
+	Projection  binning.Projection
+	Grid        *binning.HexGrid
+}
+
+// Parse parses the provided JSON object and populates the tiles attributes.
+func (h *Hexbin) Parse(params map[string]interface{}) error {
+	// get x and y fields
+	xField, ok := json.GetString(params, "xField")
+	if !ok {
+		return fmt.Errorf("`xField` parameter missing from tile")
+	}
+	yField, ok := json.GetString(params, "yField")
+	if !ok {
+		return fmt.Errorf("`yField` parameter missing from tile")
+	}
+	// get hex size and orientation
+	size := json.GetFloatDefault(params, defaultHexSize, "hexSize")
+	orientation := json.GetStringDefault(params, defaultHexOrientation, "orientation")
+	grid, err := binning.NewHexGrid(size, orientation)
+	if err != nil {
+		return err
+	}
+	// get the projection
+	projection, _, err := parseProjection(params)
+	if err != nil {
+		return err
+	}
+	// set attributes
+	h.XField = xField
+	h.YField = yField
+	h.HexSize = size
+	h.Orientation = orientation
+	h.Projection = projection
+	h.Grid = grid
+	return nil
+}
+
+// Params returns the parameters accepted by the tile.
+func (h *Hexbin) Params() []json.Param {
+	params := []json.Param{
+		{Key: "xField", Type: json.TypeString, Required: true},
+		{Key: "yField", Type: json.TypeString, Required: true},
+		{Key: "hexSize", Type: json.TypeNumber, Default: defaultHexSize},
+		{
+			Key:     "orientation",
+			Type:    json.TypeString,
+			Default: defaultHexOrientation,
+			Enum:    []interface{}{binning.FlatHex, binning.PointyHex},
+		},
+	}
+	return append(params, projectionParams()...)
+}
+
+// GetProjection returns the projection of the tile.
+func (h *Hexbin) GetProjection() binning.Projection {
+	return h.Projection
+}
+
+// TileBounds computes and returns the tile bounds for the provided tile coord.
+func (h *Hexbin) TileBounds(coord *binning.TileCoord) *geometry.Bounds {
+	return h.Projection.TileBounds(coord)
+}
+
+// QueryBounds returns the tile bounds padded by the size of a cell, so that
+// every cell whose center lies within the tile is complete.
+func (h *Hexbin) QueryBounds(coord *binning.TileCoord) *geometry.Bounds {
+	pad := h.HexSize / binning.MaxTileResolution
+	bottomLeft := h.Projection.Unproject(&binning.FractionalTileCoord{
+		X: float64(coord.X) - pad,
+		Y: float64(coord.Y) - pad,
+		Z: coord.Z,
+	})
+	topRight := h.Projection.Unproject(&binning.FractionalTileCoord{
+		X: float64(coord.X+1) + pad,
+		Y: float64(coord.Y+1) + pad,
+		Z: coord.Z,
+	})
+	return geometry.NewBounds(
+		bottomLeft.X,
+		topRight.X,
+		bottomLeft.Y,
+		topRight.Y)
+}
+
+// GetHex returns the cell containing the data coordinate.
+func (h *Hexbin) GetHex(coord *binning.TileCoord, x float64, y float64) *binning.HexCoord {
+	tile := h.Projection.Project(geometry.NewCoord(x, y), coord.Z)
+	return h.Grid.PixelToHex(
+		tile.X*binning.MaxTileResolution,
+		tile.Y*binning.MaxTileResolution)
+}
+
+// Contains returns whether the center of the cell lies within the tile.
+func (h *Hexbin) Contains(coord *binning.TileCoord, hex *binning.HexCoord) bool {
+	x, y := h.Grid.HexToPixel(hex)
+	tx := math.Floor(x / binning.MaxTileResolution)
+	ty := math.Floor(y / binning.MaxTileResolution)
+	return tx == float64(coord.X) && ty == float64(coord.Y)
+}
+
+// Bin bins the provided hits into the cells of the tile, so that backends which
+// retrieve raw documents rather than aggregations can generate hexbin tiles.
+// Hits missing either field are ignored.
+func (h *Hexbin) Bin(coord *binning.TileCoord, hits []map[string]interface{}) []*HexCell {
+	xPath := strings.Split(h.XField, ".")
+	yPath := strings.Split(h.YField, ".")
+	counts := make(map[binning.HexCoord]float64)
+	for _, hit := range hits {
+		x, ok := getFloat64(hit, xPath...)
+		if !ok {
+			continue
+		}
+		y, ok := getFloat64(hit, yPath...)
+		if !ok {
+			continue
+		}
+		hex := h.GetHex(coord, x, y)
+		if h.Contains(coord, hex) {
+			counts[*hex]++
+		}
+	}
+	cells := make([]*HexCell, 0, len(counts))
+	for hex, count := range counts {
+		cells = append(cells, &HexCell{
+			HexCoord: hex,
+			Count:    count,
+		})
+	}
+	return cells
+}
+
+// Encode encodes the cells as a byte array of little endian int32 q, int32 r
+// and uint32 count triplets, ordered by r and then q.
+func (h *Hexbin) Encode(cells []*HexCell) []byte {
+	sorted := make([]*HexCell, len(cells))
+	copy(sorted, cells)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].R != sorted[j].R {
+			return sorted[i].R < sorted[j].R
+		}
+		return sorted[i].Q < sorted[j].Q
+	})
+	bytes := make([]byte, len(sorted)*hexCellStride)
+	for i, cell := range sorted {
+		offset := i * hexCellStride
+		binary.LittleEndian.PutUint32(bytes[offset:offset+4], uint32(int32(cell.Q)))
+		binary.LittleEndian.PutUint32(bytes[offset+4:offset+8], uint32(int32(cell.R)))
+		binary.LittleEndian.PutUint32(bytes[offset+8:offset+12], uint32(cell.Count))
+	}
+	return bytes
+}
@@ -0,0 +1,175 @@
+package tile_test
+
+import (
+	"encoding/binary"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+var _ = Describe("Hexbin", func() {
+
+	var hexbin *tile.Hexbin
+
+	BeforeEach(func() {
+		hexbin = &tile.Hexbin{}
+	})
+
+	Describe("Parse", func() {
+		It("should parse properties from the params argument", func() {
+			params := JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"hexSize": 8,
+					"orientation": "flat",
+					"left": 0.0,
+					"right": 256.0,
+					"bottom": 0.0,
+					"top": 256.0
+				}`)
+			err := hexbin.Parse(params)
+			Expect(err).To(BeNil())
+			Expect(hexbin.XField).To(Equal("x"))
+			Expect(hexbin.YField).To(Equal("y"))
+			Expect(hexbin.HexSize).To(Equal(8.0))
+			Expect(hexbin.Orientation).To(Equal(binning.FlatHex))
+		})
+
+		It("should default to pointy cells of 16 pixels", func() {
+			params := JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"projection": "mercator"
+				}`)
+			err := hexbin.Parse(params)
+			Expect(err).To(BeNil())
+			Expect(hexbin.HexSize).To(Equal(16.0))
+			Expect(hexbin.Orientation).To(Equal(binning.PointyHex))
+		})
+
+		It("should return an error if the `orientation` is not recognized", func() {
+			params := JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"orientation": "round",
+					"projection": "mercator"
+				}`)
+			err := hexbin.Parse(params)
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should return an error if `xField` property is not specified", func() {
+			params := JSON(`{}`)
+			err := hexbin.Parse(params)
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("QueryBounds", func() {
+		It("should pad the tile bounds by the size of a cell", func() {
+			params := JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"hexSize": 16,
+					"left": 0.0,
+					"right": 512.0,
+					"bottom": 0.0,
+					"top": 512.0
+				}`)
+			err := hexbin.Parse(params)
+			Expect(err).To(BeNil())
+			bounds := hexbin.QueryBounds(&binning.TileCoord{Z: 1, X: 1, Y: 0})
+			Expect(bounds.Left).To(Equal(240.0))
+			Expect(bounds.Right).To(Equal(528.0))
+			Expect(bounds.Bottom).To(Equal(-16.0))
+			Expect(bounds.Top).To(Equal(272.0))
+		})
+	})
+
+	Describe("Contains", func() {
+		It("should assign each cell to exactly one tile across seams", func() {
+			params := JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"hexSize": 10,
+					"left": 0.0,
+					"right": 512.0,
+					"bottom": 0.0,
+					"top": 512.0
+				}`)
+			err := hexbin.Parse(params)
+			Expect(err).To(BeNil())
+			// points on either side of the seam between two tiles
+			left := &binning.TileCoord{Z: 1, X: 0, Y: 0}
+			right := &binning.TileCoord{Z: 1, X: 1, Y: 0}
+			for _, x := range []float64{250, 255, 257, 262} {
+				// the cell is the same from either tile
+				hex := hexbin.GetHex(left, x, 100)
+				Expect(hexbin.GetHex(right, x, 100)).To(Equal(hex))
+				Expect(hexbin.Contains(left, hex)).NotTo(Equal(hexbin.Contains(right, hex)))
+			}
+		})
+	})
+
+	Describe("Bin", func() {
+		It("should bin each hit into exactly one tile across seams", func() {
+			params := JSON(
+				`{
+					"xField": "x",
+					"yField": "y",
+					"hexSize": 10,
+					"left": 0.0,
+					"right": 512.0,
+					"bottom": 0.0,
+					"top": 512.0
+				}`)
+			err := hexbin.Parse(params)
+			Expect(err).To(BeNil())
+			// points on either side of the seam between two tiles
+			hits := []map[string]interface{}{
+				{"x": 250.0, "y": 100.0},
+				{"x": 255.0, "y": 100.0},
+				{"x": 257.0, "y": 100.0},
+				{"x": 262.0, "y": 100.0},
+				{"x": 300.0},
+			}
+			left := hexbin.Bin(&binning.TileCoord{Z: 1, X: 0, Y: 0}, hits)
+			right := hexbin.Bin(&binning.TileCoord{Z: 1, X: 1, Y: 0}, hits)
+			seen := make(map[binning.HexCoord]bool)
+			total := 0.0
+			for _, cell := range append(left, right...) {
+				Expect(seen[cell.HexCoord]).To(BeFalse())
+				seen[cell.HexCoord] = true
+				total += cell.Count
+			}
+			Expect(total).To(Equal(4.0))
+		})
+	})
+
+	Describe("Encode", func() {
+		It("should encode the cells as q, r and count triplets ordered by r and q", func() {
+			cells := []*tile.HexCell{
+				{HexCoord: binning.HexCoord{Q: 2, R: 1}, Count: 3},
+				{HexCoord: binning.HexCoord{Q: -1, R: 0}, Count: 5},
+			}
+			bytes := hexbin.Encode(cells)
+			Expect(len(bytes)).To(Equal(24))
+			Expect(int32(binary.LittleEndian.Uint32(bytes[0:4]))).To(Equal(int32(-1)))
+			Expect(int32(binary.LittleEndian.Uint32(bytes[4:8]))).To(Equal(int32(0)))
+			Expect(binary.LittleEndian.Uint32(bytes[8:12])).To(Equal(uint32(5)))
+			Expect(int32(binary.LittleEndian.Uint32(bytes[12:16]))).To(Equal(int32(2)))
+			Expect(int32(binary.LittleEndian.Uint32(bytes[16:20]))).To(Equal(int32(1)))
+			Expect(binary.LittleEndian.Uint32(bytes[20:24])).To(Equal(uint32(3)))
+		})
+	})
+
+})
@@ -0,0 +1,174 @@
+package tile
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+const (
+	// GaussianKernel weights the bins by a gaussian of the bandwidth, truncated
+	// at three bandwidths.
+	GaussianKernel = "gaussian"
+	// EpanechnikovKernel weights the bins by a parabola reaching zero at the
+	// bandwidth.
+	EpanechnikovKernel = "epanechnikov"
+	// PixelBandwidth specifies the bandwidth in pixels of the tile.
+	PixelBandwidth = "pixel"
+	// DataBandwidth specifies the bandwidth in the units of the x and y
+	// fields.
+	DataBandwidth = "data"
+
+	defaultKDEBandwidth = 8
+	gaussianSupport     = 3
+)
+
+// KDE represents a tile which estimates the density of the data points by
+// convolving the binned counts with a kernel. The bins are padded by the
+// support of the kernel so that densities are continuous across tiles.
+type KDE struct {
+	Kernel        string
+	Bandwidth     float64
+	BandwidthUnit string
+}
+
+// Parse parses the provided JSON object and populates the structs attributes.
+func (k *KDE) Parse(params map[string]interface{}) error {
+	kernel := json.GetStringDefault(params, GaussianKernel, "kernel")
+	if kernel != GaussianKernel && kernel != EpanechnikovKernel {
+		return fmt.Errorf("`kernel` parameter `%s` is not recognized", kernel)
+	}
+	bandwidth := json.GetFloatDefault(params, defaultKDEBandwidth, "bandwidth")
+	if bandwidth <= 0 {
+		return fmt.Errorf("`bandwidth` parameter must be greater than zero")
+	}
+	unit := json.GetStringDefault(params, PixelBandwidth, "bandwidthUnit")
+	if unit != PixelBandwidth && unit != DataBandwidth {
+		return fmt.Errorf("`bandwidthUnit` parameter `%s` is not recognized", unit)
+	}
+	k.Kernel = kernel
+	k.Bandwidth = bandwidth
+	k.BandwidthUnit = unit
+	return nil
+}
+
+// Params returns the parameters accepted by the tile.
+func (k *KDE) Params() []json.Param {
+	return []json.Param{
+		{
+			Key:     "kernel",
+			Type:    json.TypeString,
+			Default: GaussianKernel,
+			Enum:    []interface{}{GaussianKernel, EpanechnikovKernel},
+		},
+		{Key: "bandwidth", Type: json.TypeNumber, Default: defaultKDEBandwidth},
+		{
+			Key:     "bandwidthUnit",
+			Type:    json.TypeString,
+			Default: PixelBandwidth,
+			Enum:    []interface{}{PixelBandwidth, DataBandwidth},
+		},
+	}
+}
+
+// BandwidthBins returns the bandwidth across the x and y axes in bins, for a
+// tile of the provided resolution and bin sizes in data units.
+func (k *KDE) BandwidthBins(resolution int, binSizeX float64, binSizeY float64) (float64, float64) {
+	if k.BandwidthUnit == DataBandwidth {
+		return k.Bandwidth / binSizeX, k.Bandwidth / binSizeY
+	}
+	bandwidth := k.Bandwidth * float64(resolution) / binning.MaxTileResolution
+	return bandwidth, bandwidth
+}
+
+// Padding returns the number of bins required around the tile to cover the
+// support of the kernel. The padding is capped at the resolution, truncating
+// the kernel for bandwidths wider than the tile.
+func (k *KDE) Padding(resolution int, bandwidthX float64, bandwidthY float64) int {
+	padding := k.radius(math.Max(bandwidthX, bandwidthY))
+	if padding > resolution {
+		return resolution
+	}
+	return padding
+}
+
+// Convolve convolves the padded bins with the kernel and returns the densities
+// of the bins within the tile. The bins span resolution + 2 * padding across
+// each axis and the densities span the resolution.
+func (k *KDE) Convolve(bins []float64, resolution int, padding int, bandwidthX float64, bandwidthY float64) []float32 {
+	padded := resolution + 2*padding
+	kx := k.weights(bandwidthX, padding)
+	ky := k.weights(bandwidthY, padding)
+	rx := len(kx) / 2
+	ry := len(ky) / 2
+	// convolve across x, only for the columns of the tile
+	rows := make([]float64, resolution*padded)
+	for y := 0; y < padded; y++ {
+		for x := 0; x < resolution; x++ {
+			sum := 0.0
+			for i, weight := range kx {
+				sum += weight * bins[x+padding+i-rx+y*padded]
+			}
+			rows[x+y*resolution] = sum
+		}
+	}
+	// convolve across y, only for the rows of the tile
+	densities := make([]float32, resolution*resolution)
+	for y := 0; y < resolution; y++ {
+		for x := 0; x < resolution; x++ {
+			sum := 0.0
+			for i, weight := range ky {
+				sum += weight * rows[x+(y+padding+i-ry)*resolution]
+			}
+			densities[x+y*resolution] = float32(sum)
+		}
+	}
+	return densities
+}
+
+// Encode will encode the densities as little endian float32 values.
+func (k *KDE) Encode(densities []float32) ([]byte, error) {
+	return encodeBins(densities, "")
+}
+
+// Decode decodes the densities encoded by Encode.
+func (k *KDE) Decode(data []byte) ([]float32, error) {
+	return decodeBins(data, "")
+}
+
+// radius returns the number of bins covered by the kernel on either side of a
+// bin.
+func (k *KDE) radius(bandwidth float64) int {
+	if k.Kernel == EpanechnikovKernel {
+		return int(math.Ceil(bandwidth))
+	}
+	return int(math.Ceil(gaussianSupport * bandwidth))
+}
+
+// weights returns the discrete kernel for the bandwidth, normalized so that
+// convolution preserves the total count.
+func (k *KDE) weights(bandwidth float64, max int) []float64 {
+	radius := k.radius(bandwidth)
+	if radius > max {
+		radius = max
+	}
+	weights := make([]float64, radius*2+1)
+	sum := 0.0
+	for i := range weights {
+		u := float64(i-radius) / bandwidth
+		var weight float64
+		if k.Kernel == EpanechnikovKernel {
+			weight = math.Max(0, 1-u*u)
+		} else {
+			weight = math.Exp(-u * u / 2)
+		}
+		weights[i] = weight
+		sum += weight
+	}
+	for i := range weights {
+		weights[i] /= sum
+	}
+	return weights
+}
@@ -0,0 +1,179 @@
+package tile_test
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/unchartedsoftware/veldt/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+func sumFloat32(vals []float32) float64 {
+	sum := 0.0
+	for _, val := range vals {
+		sum += float64(val)
+	}
+	return sum
+}
+
+var _ = Describe("KDE", func() {
+
+	var kde *tile.KDE
+
+	BeforeEach(func() {
+		kde = &tile.KDE{}
+	})
+
+	Describe("Parse", func() {
+		It("should parse properties from the params argument", func() {
+			params := JSON(
+				`{
+					"kernel": "epanechnikov",
+					"bandwidth": 0.5,
+					"bandwidthUnit": "data"
+				}`)
+			err := kde.Parse(params)
+			Expect(err).To(BeNil())
+			Expect(kde.Kernel).To(Equal(tile.EpanechnikovKernel))
+			Expect(kde.Bandwidth).To(Equal(0.5))
+			Expect(kde.BandwidthUnit).To(Equal(tile.DataBandwidth))
+		})
+
+		It("should default to a gaussian kernel with a bandwidth of 8 pixels", func() {
+			err := kde.Parse(JSON(`{}`))
+			Expect(err).To(BeNil())
+			Expect(kde.Kernel).To(Equal(tile.GaussianKernel))
+			Expect(kde.Bandwidth).To(Equal(8.0))
+			Expect(kde.BandwidthUnit).To(Equal(tile.PixelBandwidth))
+		})
+
+		It("should return an error if the `kernel` is not recognized", func() {
+			err := kde.Parse(JSON(`{ "kernel": "box" }`))
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should return an error if the `bandwidth` is not positive", func() {
+			err := kde.Parse(JSON(`{ "bandwidth": 0 }`))
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should return an error if the `bandwidthUnit` is not recognized", func() {
+			err := kde.Parse(JSON(`{ "bandwidthUnit": "meters" }`))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("BandwidthBins", func() {
+		It("should scale a pixel bandwidth by the resolution", func() {
+			err := kde.Parse(JSON(`{ "bandwidth": 16 }`))
+			Expect(err).To(BeNil())
+			x, y := kde.BandwidthBins(64, 0.1, 0.2)
+			Expect(x).To(Equal(4.0))
+			Expect(y).To(Equal(4.0))
+		})
+
+		It("should divide a data bandwidth by the bin sizes", func() {
+			err := kde.Parse(JSON(`{ "bandwidth": 1, "bandwidthUnit": "data" }`))
+			Expect(err).To(BeNil())
+			x, y := kde.BandwidthBins(64, 0.5, 0.25)
+			Expect(x).To(Equal(2.0))
+			Expect(y).To(Equal(4.0))
+		})
+	})
+
+	Describe("Padding", func() {
+		It("should cover three bandwidths of a gaussian kernel", func() {
+			err := kde.Parse(JSON(`{}`))
+			Expect(err).To(BeNil())
+			Expect(kde.Padding(64, 1.5, 0.5)).To(Equal(5))
+		})
+
+		It("should cover the bandwidth of an epanechnikov kernel", func() {
+			err := kde.Parse(JSON(`{ "kernel": "epanechnikov" }`))
+			Expect(err).To(BeNil())
+			Expect(kde.Padding(64, 1.5, 2.5)).To(Equal(3))
+		})
+
+		It("should cap the padding at the resolution", func() {
+			err := kde.Parse(JSON(`{}`))
+			Expect(err).To(BeNil())
+			Expect(kde.Padding(8, 10, 10)).To(Equal(8))
+		})
+	})
+
+	Describe("Convolve", func() {
+		It("should preserve the total count of points away from the edges", func() {
+			for _, kernel := range []string{tile.GaussianKernel, tile.EpanechnikovKernel} {
+				kde.Kernel = kernel
+				bins := make([]float64, 16*16)
+				bins[8+8*16] = 10
+				densities := kde.Convolve(bins, 12, 2, 1, 1)
+				Expect(densities).To(HaveLen(12 * 12))
+				Expect(sumFloat32(densities)).To(BeNumerically("~", 10, 0.0001))
+				// the density peaks at the point
+				center := densities[6+6*12]
+				for _, density := range densities {
+					Expect(density).To(BeNumerically("<=", center))
+				}
+			}
+		})
+
+		It("should spread points within the padding into the tile", func() {
+			kde.Kernel = tile.GaussianKernel
+			bins := make([]float64, 8*8)
+			// a point in the left padding of the tile
+			bins[1+4*8] = 1
+			densities := kde.Convolve(bins, 4, 2, 1, 1)
+			Expect(densities[0+2*4]).To(BeNumerically(">", 0))
+			Expect(densities[0+2*4]).To(BeNumerically(">", densities[1+2*4]))
+		})
+
+		It("should produce densities which are continuous across tile seams", func() {
+			kde.Kernel = tile.GaussianKernel
+			// a grid of 3x3 tiles of 4 bins, padded by 2 bins
+			global := make([]float64, 16*16)
+			global[5+3*16] = 4
+			global[6+4*16] = 2
+			global[9+10*16] = 1
+			whole := kde.Convolve(global, 12, 2, 0.5, 0.5)
+			for ty := 0; ty < 3; ty++ {
+				for tx := 0; tx < 3; tx++ {
+					// the padded bins of the tile
+					bins := make([]float64, 8*8)
+					for y := 0; y < 8; y++ {
+						offset := tx*4 + (ty*4+y)*16
+						copy(bins[y*8:y*8+8], global[offset:offset+8])
+					}
+					densities := kde.Convolve(bins, 4, 2, 0.5, 0.5)
+					for y := 0; y < 4; y++ {
+						for x := 0; x < 4; x++ {
+							expected := whole[tx*4+x+(ty*4+y)*12]
+							Expect(densities[x+y*4]).To(BeNumerically("~", expected, 0.000001))
+						}
+					}
+				}
+			}
+		})
+
+		It("should truncate the kernel to the padding", func() {
+			kde.Kernel = tile.GaussianKernel
+			bins := make([]float64, 6*6)
+			bins[3+3*6] = 1
+			densities := kde.Convolve(bins, 4, 1, 4, 4)
+			// the truncated kernel is normalized
+			Expect(sumFloat32(densities)).To(BeNumerically("~", 1, 0.0001))
+		})
+	})
+
+	Describe("Encode", func() {
+		It("should encode the densities as little endian float32", func() {
+			data, err := kde.Encode([]float32{0.125, 1.0 / 3.0})
+			Expect(err).To(BeNil())
+			Expect(data).To(HaveLen(8))
+			Expect(math.Float32frombits(binary.LittleEndian.Uint32(data[4:8]))).To(Equal(float32(1.0 / 3.0)))
+		})
+	})
+})
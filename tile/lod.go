@@ -3,6 +3,8 @@ package tile
 import (
 	"math"
 	"sort"
+
+	"github.com/unchartedsoftware/veldt/binning"
 )
 
 const (
@@ -15,18 +17,25 @@ const (
 // for each LOD. This is used at runtime to only render quadrants of the
 // generated tile.
 func LOD(data []float32, lod int) ([]float32, []int) {
+	return LODResolution(data, lod, binning.MaxTileResolution)
+}
+
+// LODResolution takes the input point array, with values in the range
+// [0.0: resolution), and sorts it by morton code. It then generates an offset
+// array which match the byte offsets into the point buffer for each LOD.
+func LODResolution(data []float32, lod int, resolution float64) ([]float32, []int) {
 	// get the points array sorted by morton code
-	points := sortPoints(data)
+	points := sortPoints(data, resolution)
 
-	// generate codes for the sorted points
-	codes := make([]int, len(points)/pointStride)
+	// generate partitions for the sorted points
+	parts := make([]int, len(points)/pointStride)
 	for i := 0; i < len(points); i += pointStride {
-		codes[i/2] = Morton(points[i], points[i+1])
+		code := MortonResolution(points[i], points[i+1], resolution)
+		parts[i/2] = mortonPartition(code, resolution, lod)
 	}
 
-	// calc number of partitions and partition stride
+	// calc number of partitions
 	partitions := math.Pow(4, float64(lod))
-	paritionStride := maxMorton / int(partitions)
 
 	// set offsets
 	offsets := make([]int, int(partitions))
@@ -35,10 +44,8 @@ func LOD(data []float32, lod int) ([]float32, []int) {
 		offsets[i] = -1
 	}
 	// set the offsets to the least byte in the array
-	for i := len(codes) - 1; i >= 0; i-- {
-		code := codes[i]
-		j := code / paritionStride
-		offsets[j] = i * (bytesPerComponent * pointStride)
+	for i := len(parts) - 1; i >= 0; i-- {
+		offsets[parts[i]] = i * (bytesPerComponent * pointStride)
 	}
 	// fill empty offsets up with next entries to ensure easy LOD
 	for i := len(offsets) - 1; i >= 0; i-- {
@@ -55,38 +62,52 @@ func LOD(data []float32, lod int) ([]float32, []int) {
 
 // EncodeLOD generates the point LOD offsets and encodes them as a byte array.
 func EncodeLOD(data []float32, lod int) []byte {
+	return EncodeLODResolution(data, lod, binning.MaxTileResolution)
+}
+
+// EncodeLODResolution generates the point LOD offsets for a tile of the
+// provided resolution and encodes them as a byte array.
+func EncodeLODResolution(data []float32, lod int, resolution float64) []byte {
 	// get sorted points and offsets
-	points, offsets := LOD(data, lod)
+	points, offsets := LODResolution(data, lod, resolution)
 	// encode the results
 	return encodeLOD(points, offsets)
 }
 
-func sortPoints(data []float32) []float32 {
-	points := make(pointArray, len(data)/pointStride)
+func sortPoints(data []float32, resolution float64) []float32 {
+	points := pointArray{
+		points:     make([][pointStride]float32, len(data)/pointStride),
+		resolution: resolution,
+	}
 	for i := 0; i < len(data); i += pointStride {
 		x := data[i]
 		y := data[i+1]
-		points[i/pointStride] = [pointStride]float32{x, y}
+		points.points[i/pointStride] = [pointStride]float32{x, y}
 	}
 	// sort the points
 	sort.Sort(points)
 	// convert to flat array
-	res := make([]float32, len(points)*pointStride)
-	for i, point := range points {
+	res := make([]float32, len(points.points)*pointStride)
+	for i, point := range points.points {
 		res[i*pointStride] = point[0]
 		res[i*pointStride+1] = point[1]
 	}
 	return res
 }
 
-type pointArray [][pointStride]float32 // x, y
+type pointArray struct {
+	points     [][pointStride]float32 // x, y
+	resolution float64
+}
 
 func (p pointArray) Len() int {
-	return len(p)
+	return len(p.points)
 }
 func (p pointArray) Swap(i, j int) {
-	p[i], p[j] = p[j], p[i]
+	p.points[i], p.points[j] = p.points[j], p.points[i]
 }
 func (p pointArray) Less(i, j int) bool {
-	return Morton(p[i][0], p[i][1]) < Morton(p[j][0], p[j][1])
+	a := p.points[i]
+	b := p.points[j]
+	return MortonResolution(a[0], a[1], p.resolution) < MortonResolution(b[0], b[1], p.resolution)
 }
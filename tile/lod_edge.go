@@ -16,21 +16,29 @@ const (
 // byte offsets into the edge buffer for each LOD. This is used at runtime to
 // only render quadrants of the generated tile.
 func EdgeLOD(data []float32, lod int) ([]float32, []int) {
+	return EdgeLODResolution(data, lod, binning.MaxTileResolution)
+}
+
+// EdgeLODResolution takes the input edge array, with values in the range
+// [0.0: resolution), and sorts it by the morton code of the first point in the
+// edge. It then generates an offset array which match the byte offsets into
+// the edge buffer for each LOD.
+func EdgeLODResolution(data []float32, lod int, resolution float64) ([]float32, []int) {
 	// get the edges array sorted by morton code
-	edges := sortEdges(data)
+	edges := sortEdges(data, resolution)
 
-	// generate codes for the sorted edges
-	codes := make([]int, len(edges)/edgeStride)
+	// generate partitions for the sorted edges
+	parts := make([]int, len(edges)/edgeStride)
 	for i := 0; i < len(edges); i += edgeStride {
 		sx := edges[i]   // src x
 		sy := edges[i+1] // src y
-		// sort based on src point
-		codes[i/edgeStride] = Morton(sx, sy)
+		// partition based on src point
+		code := MortonResolution(sx, sy, resolution)
+		parts[i/edgeStride] = mortonPartition(code, resolution, lod)
 	}
 
-	// calc number of partitions and partition stride
+	// calc number of partitions
 	partitions := math.Pow(4, float64(lod))
-	paritionStride := maxMorton / int(partitions)
 
 	// set offsets
 	offsets := make([]int, int(partitions))
@@ -39,10 +47,8 @@ func EdgeLOD(data []float32, lod int) ([]float32, []int) {
 		offsets[i] = -1
 	}
 	// set the offsets to the least byte in the array
-	for i := len(codes) - 1; i >= 0; i-- {
-		code := codes[i]
-		j := code / paritionStride
-		offsets[j] = i * (bytesPerComponent * edgeStride)
+	for i := len(parts) - 1; i >= 0; i-- {
+		offsets[parts[i]] = i * (bytesPerComponent * edgeStride)
 	}
 	// fill empty offsets up with next entries to ensure easy LOD
 	for i := len(offsets) - 1; i >= 0; i-- {
@@ -59,15 +65,24 @@ func EdgeLOD(data []float32, lod int) ([]float32, []int) {
 
 // EncodeEdgeLOD generates the point LOD offsets and encodes them as a byte array.
 func EncodeEdgeLOD(data []float32, lod int) []byte {
-	// get sorted points and offsets
-	edges, offsets := EdgeLOD(data, lod)
+	return EncodeEdgeLODResolution(data, lod, binning.MaxTileResolution)
+}
+
+// EncodeEdgeLODResolution generates the edge LOD offsets for a tile of the
+// provided resolution and encodes them as a byte array.
+func EncodeEdgeLODResolution(data []float32, lod int, resolution float64) []byte {
+	// get sorted edges and offsets
+	edges, offsets := EdgeLODResolution(data, lod, resolution)
 	// encode the results
 	return encodeLOD(edges, offsets)
 }
 
-func sortEdges(data []float32) []float32 {
-	edges := make(edgeArray, len(data)/edgeStride)
-	maxPixel := float32(binning.MaxTileResolution)
+func sortEdges(data []float32, resolution float64) []float32 {
+	edges := edgeArray{
+		edges:      make([][edgeStride]float32, len(data)/edgeStride),
+		resolution: resolution,
+	}
+	maxPixel := float32(resolution)
 	for i := 0; i < len(data); i += edgeStride {
 		ax := data[i]   // src x
 		ay := data[i+1] // src y
@@ -78,16 +93,16 @@ func sortEdges(data []float32) []float32 {
 		// ensure first point is within the tile
 		if ax >= 0.0 && ax < maxPixel &&
 			ay >= 0.0 && ay < maxPixel {
-			edges[i/edgeStride] = [edgeStride]float32{ax, ay, aw, bx, by, bw}
+			edges.edges[i/edgeStride] = [edgeStride]float32{ax, ay, aw, bx, by, bw}
 		} else {
-			edges[i/edgeStride] = [edgeStride]float32{bx, by, bw, ax, ay, aw}
+			edges.edges[i/edgeStride] = [edgeStride]float32{bx, by, bw, ax, ay, aw}
 		}
 	}
 	// sort the edges
 	sort.Sort(edges)
 	// convert to flat array
-	res := make([]float32, len(edges)*edgeStride)
-	for i, edge := range edges {
+	res := make([]float32, len(edges.edges)*edgeStride)
+	for i, edge := range edges.edges {
 		res[i*edgeStride] = edge[0]   // src x
 		res[i*edgeStride+1] = edge[1] // src y
 		res[i*edgeStride+2] = edge[2] // src weight
@@ -98,14 +113,19 @@ func sortEdges(data []float32) []float32 {
 	return res
 }
 
-type edgeArray [][edgeStride]float32 // srcX, srcY, srcWeight, dstX, dstY, dstWeight
+type edgeArray struct {
+	edges      [][edgeStride]float32 // srcX, srcY, srcWeight, dstX, dstY, dstWeight
+	resolution float64
+}
 
 func (e edgeArray) Len() int {
-	return len(e)
+	return len(e.edges)
 }
 func (e edgeArray) Swap(i, j int) {
-	e[i], e[j] = e[j], e[i]
+	e.edges[i], e.edges[j] = e.edges[j], e.edges[i]
 }
 func (e edgeArray) Less(i, j int) bool {
-	return Morton(e[i][0], e[i][1]) < Morton(e[j][0], e[j][1])
+	a := e.edges[i]
+	b := e.edges[j]
+	return MortonResolution(a[0], a[1], e.resolution) < MortonResolution(b[0], b[1], e.resolution)
 }
@@ -80,4 +80,18 @@ var _ = Describe("EdgeLOD", func() {
 		})
 	})
 
+	Describe("EdgeLODResolution", func() {
+		It("should partition edges by the source point within the provided resolution", func() {
+			es, os := tile.EdgeLODResolution([]float32{
+				900, 900, 1, 100, 100, 1,
+				2000, 100, 2, 600, 100, 2,
+			}, 1, 1024)
+			Expect(es).To(Equal([]float32{
+				600, 100, 2, 2000, 100, 2,
+				900, 900, 1, 100, 100, 1,
+			}))
+			Expect(os).To(Equal([]int{0, 0, 24, 24}))
+		})
+	})
+
 })
@@ -78,4 +78,33 @@ var _ = Describe("LOD", func() {
 		})
 	})
 
+	Describe("LODResolution", func() {
+		It("should partition points within the provided resolution", func() {
+			ps, os := tile.LODResolution([]float32{
+				900, 900,
+				100, 600,
+				600, 100,
+				100, 100,
+			}, 1, 1024)
+			Expect(ps).To(Equal([]float32{
+				100, 100,
+				600, 100,
+				100, 600,
+				900, 900,
+			}))
+			Expect(os).To(Equal([]int{0, 8, 16, 24}))
+		})
+
+		It("should sort points within the same pixel", func() {
+			ps, _ := tile.LODResolution([]float32{
+				10.75, 10.75,
+				10.25, 10.25,
+			}, 2, 256)
+			Expect(ps).To(Equal([]float32{
+				10.25, 10.25,
+				10.75, 10.75,
+			}))
+		})
+	})
+
 })
@@ -1,27 +1,111 @@
 package tile
 
 import (
+	"fmt"
+
+	"github.com/unchartedsoftware/veldt/binning"
 	"github.com/unchartedsoftware/veldt/util/json"
 )
 
 // Macro represents a tile which returns a point for any bin that contains a
 // data point.
 type Macro struct {
-	LOD int
+	LOD      int
+	Encoding string
+	// Resolution is the extent of the pixel space of the points, which are in
+	// the range [0 : Resolution) along each axis.
+	Resolution int
 }
 
 // Parse parses the provided JSON object and populates the structs attributes.
 func (m *Macro) Parse(params map[string]interface{}) error {
 	// parse LOD
-	m.LOD = json.GetIntDefault(params, 0, "lod")
+	lod := json.GetIntDefault(params, 0, "lod")
+	// parse encoding
+	encoding, err := parseEncoding(params, lod, Float32Encoding, GeoJSONEncoding)
+	if err != nil {
+		return err
+	}
+	// parse resolution
+	resolution, err := parseResolution(params)
+	if err != nil {
+		return err
+	}
+	m.LOD = lod
+	m.Encoding = encoding
+	m.Resolution = resolution
 	return nil
 }
 
-// Encode will encode the tile results based on the LOD property.
-func (m *Macro) Encode(points []float32) ([]byte, error) {
+// Params returns the parameters accepted by the tile.
+func (m *Macro) Params() []json.Param {
+	return []json.Param{
+		{Key: "lod", Type: json.TypeInteger, Default: 0},
+		encodingParam(Float32Encoding, GeoJSONEncoding),
+		resolutionParam(),
+	}
+}
+
+// Encode will encode the tile results based on the LOD and encoding
+// properties. The points are in the range [0 : Resolution) along each axis.
+// The unprojector converts points back into data coordinates for the GeoJSON
+// encoding.
+func (m *Macro) Encode(points []float32, unproject Unprojector) ([]byte, error) {
+	resolution := tileResolution(m.Resolution)
+	scale := pixelScale(resolution)
+	// encode as a vector tile
+	if m.Encoding == MVTEncoding {
+		layer := NewMVTLayer("points")
+		for i := 0; i < len(points)/2; i++ {
+			layer.AddPoint(points[i*2]*scale, points[i*2+1]*scale, nil)
+		}
+		return EncodeMVT(layer), nil
+	}
+	// encode as a feature collection
+	if m.Encoding == GeoJSONEncoding {
+		collection := NewGeoJSON(unproject)
+		for i := 0; i < len(points)/2; i++ {
+			collection.AddPoint(points[i*2]*scale, points[i*2+1]*scale, nil)
+		}
+		return collection.Encode()
+	}
 	// encode the results
 	if m.LOD > 0 {
-		return EncodeLOD(points, m.LOD), nil
+		return EncodeLODResolution(points, m.LOD, resolution), nil
 	}
 	return EncodeFloat32(points), nil
 }
+
+// parseResolution parses the `tileResolution` parameter, which defaults to
+// binning.MaxTileResolution. It is distinct from the `resolution` parameter,
+// which sets the number of bins of the tile.
+func parseResolution(params map[string]interface{}) (int, error) {
+	resolution := json.GetIntDefault(params, int(binning.MaxTileResolution), "tileResolution")
+	if resolution <= 0 {
+		return 0, fmt.Errorf("`tileResolution` parameter must be positive")
+	}
+	return resolution, nil
+}
+
+func resolutionParam() json.Param {
+	return json.Param{
+		Key:     "tileResolution",
+		Type:    json.TypeInteger,
+		Default: int(binning.MaxTileResolution),
+	}
+}
+
+// tileResolution returns the provided resolution, or binning.MaxTileResolution
+// if it is not set.
+func tileResolution(resolution int) float64 {
+	if resolution <= 0 {
+		return binning.MaxTileResolution
+	}
+	return float64(resolution)
+}
+
+// pixelScale returns the factor converting positions in the range
+// [0 : resolution) into the [0 : 256) pixel space of the tile.
+func pixelScale(resolution float64) float32 {
+	return float32(binning.MaxTileResolution / resolution)
+}
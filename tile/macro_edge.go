@@ -7,16 +7,32 @@ import (
 // MacroEdge represents a tile that returns individual data edges with optional
 // included attributes.
 type MacroEdge struct {
-	LOD int
+	LOD      int
+	Encoding string
 }
 
 // Parse parses the provided JSON object and populates the structs attributes.
 func (e *MacroEdge) Parse(params map[string]interface{}) error {
 	// parse LOD
-	e.LOD = json.GetIntDefault(params, 0, "lod")
+	lod := json.GetIntDefault(params, 0, "lod")
+	// parse encoding
+	encoding, err := parseEncoding(params, lod, Float32Encoding, GeoJSONEncoding)
+	if err != nil {
+		return err
+	}
+	e.LOD = lod
+	e.Encoding = encoding
 	return nil
 }
 
+// Params returns the parameters accepted by the tile.
+func (e *MacroEdge) Params() []json.Param {
+	return []json.Param{
+		{Key: "lod", Type: json.TypeInteger, Default: 0},
+		encodingParam(Float32Encoding, GeoJSONEncoding),
+	}
+}
+
 // ParseIncludes parses the included attributes to ensure they include the raw
 // data coordinates.
 func (e *MacroEdge) ParseIncludes(includes []string, srcXField string, srcYField string, dstXField string, dstYField string, weightField string) []string {
@@ -41,8 +57,32 @@ func (e *MacroEdge) ParseIncludes(includes []string, srcXField string, srcYField
 	return includes
 }
 
-// Encode will encode the tile results
-func (e *MacroEdge) Encode(edges []float32) ([]byte, error) {
+// Encode will encode the tile results. Each edge is encoded as the source x,
+// y and weight followed by the destination x, y and weight. The unprojector
+// converts edges back into data coordinates for the GeoJSON encoding.
+func (e *MacroEdge) Encode(edges []float32, unproject Unprojector) ([]byte, error) {
+	// encode as a vector tile
+	if e.Encoding == MVTEncoding {
+		layer := NewMVTLayer("edges")
+		for i := 0; i < len(edges)/6; i++ {
+			edge := edges[i*6 : i*6+6]
+			layer.AddLine(edge[0], edge[1], edge[3], edge[4], map[string]interface{}{
+				"weight": edge[2],
+			})
+		}
+		return EncodeMVT(layer), nil
+	}
+	// encode as a feature collection
+	if e.Encoding == GeoJSONEncoding {
+		collection := NewGeoJSON(unproject)
+		for i := 0; i < len(edges)/6; i++ {
+			edge := edges[i*6 : i*6+6]
+			collection.AddLine(edge[0], edge[1], edge[3], edge[4], map[string]interface{}{
+				"weight": edge[2],
+			})
+		}
+		return collection.Encode()
+	}
 	// encode the results
 	if e.LOD > 0 {
 		return EncodeEdgeLOD(edges, e.LOD), nil
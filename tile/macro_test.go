@@ -35,6 +35,36 @@ var _ = Describe("Macro", func() {
 			err := macro.Parse(params)
 			Expect(err).To(BeNil())
 			Expect(macro.LOD).To(Equal(4))
+			Expect(macro.Resolution).To(Equal(256))
+		})
+
+		It("should parse the `tileResolution` parameter", func() {
+			params := JSON(
+				`{
+					"tileResolution": 1024
+				}`)
+			err := macro.Parse(params)
+			Expect(err).To(BeNil())
+			Expect(macro.Resolution).To(Equal(1024))
+		})
+
+		It("should not parse the bin `resolution` as the `tileResolution`", func() {
+			params := JSON(
+				`{
+					"resolution": 64
+				}`)
+			err := macro.Parse(params)
+			Expect(err).To(BeNil())
+			Expect(macro.Resolution).To(Equal(256))
+		})
+
+		It("should return an error if the `tileResolution` is not positive", func() {
+			params := JSON(
+				`{
+					"tileResolution": 0
+				}`)
+			err := macro.Parse(params)
+			Expect(err).NotTo(BeNil())
 		})
 	})
 
@@ -47,7 +77,7 @@ var _ = Describe("Macro", func() {
 				}`)
 			err := macro.Parse(params)
 			Expect(err).To(BeNil())
-			bytes, err := macro.Encode(input)
+			bytes, err := macro.Encode(input, nil)
 			Expect(err).To(BeNil())
 			Expect(len(bytes)).To(Equal(len(input) * bytesPerFloat))
 		})
@@ -60,7 +90,7 @@ var _ = Describe("Macro", func() {
 				}`)
 			err := macro.Parse(params)
 			Expect(err).To(BeNil())
-			bytes, err := macro.Encode(input)
+			bytes, err := macro.Encode(input, nil)
 			pointCount := bytesPerFloat
 			offsetCount := bytesPerOffset
 			pointsBytes := len(input) * bytesPerFloat
@@ -69,5 +99,39 @@ var _ = Describe("Macro", func() {
 			Expect(err).To(BeNil())
 			Expect(len(bytes)).To(Equal(totalBytes))
 		})
+		It("should partition the LOD offsets by the `tileResolution`", func() {
+			params := JSON(
+				`{
+					"lod": 1,
+					"tileResolution": 1024
+				}`)
+			err := macro.Parse(params)
+			Expect(err).To(BeNil())
+			points := []float32{900, 900, 100, 100}
+			bytes, err := macro.Encode(points, nil)
+			Expect(err).To(BeNil())
+			Expect(bytes).To(Equal(tile.EncodeLODResolution(points, 1, 1024)))
+		})
+		It("should scale points into the pixel space of the tile for GeoJSON", func() {
+			params := JSON(
+				`{
+					"encoding": "geojson",
+					"tileResolution": 512
+				}`)
+			err := macro.Parse(params)
+			Expect(err).To(BeNil())
+			bytes, err := macro.Encode([]float32{256, 128}, func(x, y float64) (float64, float64) {
+				return x, y
+			})
+			Expect(err).To(BeNil())
+			Expect(bytes).To(MatchJSON(`{
+				"type": "FeatureCollection",
+				"features": [{
+					"type": "Feature",
+					"geometry": {"type": "Point", "coordinates": [128, 64]},
+					"properties": {}
+				}]
+			}`))
+		})
 	})
 })
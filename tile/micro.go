@@ -9,20 +9,46 @@ import (
 // Micro represents a tile that returns individual data points with optional
 // included attributes.
 type Micro struct {
-	LOD       int
-	xField    string
-	yField    string
-	xIncluded bool
-	yIncluded bool
+	LOD      int
+	Encoding string
+	// Resolution is the extent of the pixel space of the points, which are in
+	// the range [0 : Resolution) along each axis.
+	Resolution int
+	xField     string
+	yField     string
+	xIncluded  bool
+	yIncluded  bool
 }
 
 // Parse parses the provided JSON object and populates the structs attributes.
 func (m *Micro) Parse(params map[string]interface{}) error {
 	// parse LOD
-	m.LOD = json.GetIntDefault(params, 0, "lod")
+	lod := json.GetIntDefault(params, 0, "lod")
+	// parse encoding
+	encoding, err := parseEncoding(params, lod, JSONEncoding, ColumnarEncoding, ArrowEncoding, GeoJSONEncoding)
+	if err != nil {
+		return err
+	}
+	// parse resolution
+	resolution, err := parseResolution(params)
+	if err != nil {
+		return err
+	}
+	m.LOD = lod
+	m.Encoding = encoding
+	m.Resolution = resolution
 	return nil
 }
 
+// Params returns the parameters accepted by the tile.
+func (m *Micro) Params() []json.Param {
+	return []json.Param{
+		{Key: "lod", Type: json.TypeInteger, Default: 0},
+		encodingParam(JSONEncoding, ColumnarEncoding, ArrowEncoding, GeoJSONEncoding),
+		resolutionParam(),
+	}
+}
+
 // ParseIncludes parses the included attributes to ensure they include the raw
 // data coordinates.
 func (m *Micro) ParseIncludes(includes []string, xField string, yField string) []string {
@@ -43,8 +69,11 @@ func (m *Micro) ParseIncludes(includes []string, xField string, yField string) [
 	return includes
 }
 
-// Encode will encode the tile results based on the LOD property.
-func (m *Micro) Encode(hits []map[string]interface{}, points []float32) ([]byte, error) {
+// Encode will encode the tile results based on the LOD and encoding
+// properties. The points are in the range [0 : Resolution) along each axis.
+// The unprojector converts points back into data coordinates for the GeoJSON
+// encoding.
+func (m *Micro) Encode(hits []map[string]interface{}, points []float32, unproject Unprojector) ([]byte, error) {
 	emptyHits := false
 
 	// remove any non-included fields from hits
@@ -69,13 +98,69 @@ func (m *Micro) Encode(hits []map[string]interface{}, points []float32) ([]byte,
 		hits = nil
 	}
 
+	resolution := tileResolution(m.Resolution)
+	scale := pixelScale(resolution)
+
+	// encode as a vector tile
+	if m.Encoding == MVTEncoding {
+		layer := NewMVTLayer("points")
+		for i := 0; i < len(points)/2; i++ {
+			var props map[string]interface{}
+			if hits != nil {
+				props = hits[i]
+			}
+			layer.AddPoint(points[i*2]*scale, points[i*2+1]*scale, props)
+		}
+		return EncodeMVT(layer), nil
+	}
+
+	// encode as a feature collection
+	if m.Encoding == GeoJSONEncoding {
+		collection := NewGeoJSON(unproject)
+		for i := 0; i < len(points)/2; i++ {
+			var props map[string]interface{}
+			if hits != nil {
+				props = hits[i]
+			}
+			collection.AddPoint(points[i*2]*scale, points[i*2+1]*scale, props)
+		}
+		return collection.Encode()
+	}
+
+	// encode as an arrow table, with a row per point
+	if m.Encoding == ArrowEncoding {
+		table := NewTable("x", "y")
+		for i := 0; i < len(points)/2; i++ {
+			row := make(map[string]interface{})
+			if hits != nil {
+				for field, val := range hits[i] {
+					row[field] = val
+				}
+			}
+			row["x"] = points[i*2]
+			row["y"] = points[i*2+1]
+			table.AddRow(row)
+		}
+		return table.EncodeArrow()
+	}
+
+	// encode as columns
+	if m.Encoding == ColumnarEncoding {
+		var offsets []int
+		if m.LOD > 0 {
+			sortHitsArray(hits, points, resolution)
+			points, offsets = LODResolution(points, m.LOD, resolution)
+		}
+		return EncodeMicro(points, offsets, hits)
+	}
+
 	// encode using LOD
 	if m.LOD > 0 {
 		// NOTE: during LOD points are sorted by morton code, therefore we sort
 		// the hits by morton code as well to ensure both arrays align by index.
-		sortHitsArray(hits, points)
+		sortHitsArray(hits, points, resolution)
 		// sort points and get offsets
-		sorted, offsets := LOD(points, m.LOD)
+		sorted, offsets := LODResolution(points, m.LOD, resolution)
 		return json.Marshal(map[string]interface{}{
 			"points":  sorted,
 			"offsets": offsets,
@@ -98,16 +183,19 @@ func existsIn(val string, arr []string) bool {
 	return false
 }
 
-func sortHitsArray(hits []map[string]interface{}, points []float32) {
+func sortHitsArray(hits []map[string]interface{}, points []float32, resolution float64) {
 	// exit early if no hits
 	if hits == nil {
 		return
 	}
 	// sort hits by morton code so they align
-	hitsArr := make(hitsArray, len(hits))
+	hitsArr := hitsArray{
+		hits:       make([]*hitWrapper, len(hits)),
+		resolution: resolution,
+	}
 	for i, hit := range hits {
 		// add to hits array
-		hitsArr[i] = &hitWrapper{
+		hitsArr.hits[i] = &hitWrapper{
 			x:    points[i*2],
 			y:    points[i*2+1],
 			data: hit,
@@ -115,7 +203,7 @@ func sortHitsArray(hits []map[string]interface{}, points []float32) {
 	}
 	sort.Sort(hitsArr)
 	// copy back into same arr
-	for i, hit := range hitsArr {
+	for i, hit := range hitsArr.hits {
 		hits[i] = hit.data
 	}
 }
@@ -126,14 +214,19 @@ type hitWrapper struct {
 	data map[string]interface{}
 }
 
-type hitsArray []*hitWrapper
+type hitsArray struct {
+	hits       []*hitWrapper
+	resolution float64
+}
 
 func (h hitsArray) Len() int {
-	return len(h)
+	return len(h.hits)
 }
 func (h hitsArray) Swap(i, j int) {
-	h[i], h[j] = h[j], h[i]
+	h.hits[i], h.hits[j] = h.hits[j], h.hits[i]
 }
 func (h hitsArray) Less(i, j int) bool {
-	return Morton(h[i].x, h[i].y) < Morton(h[j].x, h[j].y)
+	a := h.hits[i]
+	b := h.hits[j]
+	return MortonResolution(a.x, a.y, h.resolution) < MortonResolution(b.x, b.y, h.resolution)
 }
@@ -1,13 +1,15 @@
 package tile
 
 import (
+	"github.com/unchartedsoftware/veldt/binning"
 	"github.com/unchartedsoftware/veldt/util/json"
 )
 
 // MicroEdge represents a tile that returns individual data edges with optional
 // included attributes.
 type MicroEdge struct {
-	LOD int
+	LOD      int
+	Encoding string
 	// src
 	srcXField    string
 	srcYField    string
@@ -23,10 +25,25 @@ type MicroEdge struct {
 // Parse parses the provided JSON object and populates the structs attributes.
 func (e *MicroEdge) Parse(params map[string]interface{}) error {
 	// parse LOD
-	e.LOD = json.GetIntDefault(params, 0, "lod")
+	lod := json.GetIntDefault(params, 0, "lod")
+	// parse encoding
+	encoding, err := parseEncoding(params, lod, JSONEncoding, GeoJSONEncoding)
+	if err != nil {
+		return err
+	}
+	e.LOD = lod
+	e.Encoding = encoding
 	return nil
 }
 
+// Params returns the parameters accepted by the tile.
+func (e *MicroEdge) Params() []json.Param {
+	return []json.Param{
+		{Key: "lod", Type: json.TypeInteger, Default: 0},
+		encodingParam(JSONEncoding, GeoJSONEncoding),
+	}
+}
+
 // ParseIncludes parses the included attributes to ensure they include the raw
 // data coordinates.
 func (e *MicroEdge) ParseIncludes(includes []string, srcXField string, srcYField string, dstXField string, dstYField string) []string {
@@ -60,8 +77,11 @@ func (e *MicroEdge) ParseIncludes(includes []string, srcXField string, srcYField
 	return includes
 }
 
-// Encode will encode the tile results
-func (e *MicroEdge) Encode(hits []map[string]interface{}, points []float32) ([]byte, error) {
+// Encode will encode the tile results. For the vector tile and GeoJSON
+// encodings each edge is provided as the source x and y followed by the
+// destination x and y, and the unprojector converts edges back into data
+// coordinates for the GeoJSON encoding.
+func (e *MicroEdge) Encode(hits []map[string]interface{}, points []float32, unproject Unprojector) ([]byte, error) {
 	emptyHits := false
 	// remove any non-included fields from hits
 	if !e.srcXIncluded || !e.srcYIncluded ||
@@ -92,11 +112,39 @@ func (e *MicroEdge) Encode(hits []map[string]interface{}, points []float32) ([]b
 		hits = nil
 	}
 
+	// encode as a vector tile
+	if e.Encoding == MVTEncoding {
+		layer := NewMVTLayer("edges")
+		for i := 0; i < len(points)/4; i++ {
+			var props map[string]interface{}
+			if hits != nil {
+				props = hits[i]
+			}
+			edge := points[i*4 : i*4+4]
+			layer.AddLine(edge[0], edge[1], edge[2], edge[3], props)
+		}
+		return EncodeMVT(layer), nil
+	}
+
+	// encode as a feature collection
+	if e.Encoding == GeoJSONEncoding {
+		collection := NewGeoJSON(unproject)
+		for i := 0; i < len(points)/4; i++ {
+			var props map[string]interface{}
+			if hits != nil {
+				props = hits[i]
+			}
+			edge := points[i*4 : i*4+4]
+			collection.AddLine(edge[0], edge[1], edge[2], edge[3], props)
+		}
+		return collection.Encode()
+	}
+
 	// encode using LOD
 	if e.LOD > 0 {
 		// NOTE: during LOD points are sorted by morton code, therefore we sort
 		// the hits by morton code as well to ensure both arrays align by index.
-		sortHitsArray(hits, points)
+		sortHitsArray(hits, points, binning.MaxTileResolution)
 		// sort points and get offsets
 		sorted, offsets := LOD(points, e.LOD)
 		return json.Marshal(map[string]interface{}{
@@ -0,0 +1,422 @@
+package tile
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+const (
+	// ColumnarEncoding encodes the hits of a micro tile as binary columns.
+	ColumnarEncoding = "columnar"
+
+	microVersion    = 1
+	microHeaderSize = 16
+
+	// column types
+	numberColumn = 1
+	stringColumn = 2
+	boolColumn   = 3
+	jsonColumn   = 4
+	intColumn    = 5
+)
+
+// MicroData represents the decoded contents of a micro tile.
+type MicroData struct {
+	Points  []float32
+	Offsets []int
+	Hits    []map[string]interface{}
+}
+
+// EncodeMicro encodes the points, LOD offsets and hits of a micro tile into a
+// columnar binary format, prefixed by a header:
+//
+//     byte 0:       version
+//     bytes 1-3:    reserved
+//     bytes 4-7:    uint32 number of points
+//     bytes 8-11:   uint32 number of offsets
+//     bytes 12-15:  uint32 number of columns
+//
+// The header is followed by the float32 x and y of each point, the uint32
+// offsets, then each column. Each hit corresponds to the point at the same
+// index. A column is encoded as its uint16 name length and name, a type byte,
+// and a null bitmap with a bit set for each hit containing the field,
+// followed by the values:
+//
+//     int:     an int64 per hit
+//     number:  a float64 per hit
+//     bool:    a bitmap with a bit set for each true value
+//     string:  a uint32 dictionary size, each value as a uint32 length and
+//              bytes, then a uint32 dictionary index per hit
+//     json:    as string, with each value marshalled to JSON
+//
+// Numeric fields are encoded as int columns if every value is an integer type,
+// otherwise as number columns. Fields with values of mixed types, objects or
+// arrays are encoded as json columns. All values are little endian.
+func EncodeMicro(points []float32, offsets []int, hits []map[string]interface{}) ([]byte, error) {
+	numPoints := len(points) / 2
+	if hits != nil && len(hits) != numPoints {
+		return nil, fmt.Errorf("micro tile has %d hits for %d points", len(hits), numPoints)
+	}
+	fields := getHitFields(hits)
+	// write the header
+	bytes := make([]byte, microHeaderSize, microHeaderSize+len(points)*4+len(offsets)*4)
+	bytes[0] = microVersion
+	binary.LittleEndian.PutUint32(bytes[4:8], uint32(numPoints))
+	binary.LittleEndian.PutUint32(bytes[8:12], uint32(len(offsets)))
+	binary.LittleEndian.PutUint32(bytes[12:16], uint32(len(fields)))
+	// write the points and offsets
+	for _, val := range points {
+		bytes = appendUint32(bytes, math.Float32bits(val))
+	}
+	for _, offset := range offsets {
+		bytes = appendUint32(bytes, uint32(offset))
+	}
+	// write the columns
+	for _, field := range fields {
+		column, err := encodeColumn(field, hits)
+		if err != nil {
+			return nil, err
+		}
+		bytes = append(bytes, column...)
+	}
+	return bytes, nil
+}
+
+// DecodeMicro decodes a micro tile encoded by EncodeMicro.
+func DecodeMicro(bytes []byte) (*MicroData, error) {
+	if len(bytes) < microHeaderSize {
+		return nil, fmt.Errorf("micro tile header is truncated")
+	}
+	if bytes[0] != microVersion {
+		return nil, fmt.Errorf("unsupported micro tile version `%d`", bytes[0])
+	}
+	numPoints := int(binary.LittleEndian.Uint32(bytes[4:8]))
+	numOffsets := int(binary.LittleEndian.Uint32(bytes[8:12]))
+	numColumns := int(binary.LittleEndian.Uint32(bytes[12:16]))
+	if len(bytes) < microHeaderSize+numPoints*8+numOffsets*4 {
+		return nil, fmt.Errorf("micro tile is truncated")
+	}
+	r := &reader{
+		bytes:  bytes,
+		offset: microHeaderSize,
+	}
+	// read the points and offsets
+	points := make([]float32, numPoints*2)
+	for i := range points {
+		points[i] = math.Float32frombits(r.uint32())
+	}
+	var offsets []int
+	if numOffsets > 0 {
+		offsets = make([]int, numOffsets)
+		for i := range offsets {
+			offsets[i] = int(r.uint32())
+		}
+	}
+	// read the columns
+	var hits []map[string]interface{}
+	if numColumns > 0 {
+		hits = make([]map[string]interface{}, numPoints)
+		for i := range hits {
+			hits[i] = make(map[string]interface{})
+		}
+	}
+	for i := 0; i < numColumns; i++ {
+		err := decodeColumn(r, hits)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	if r.offset != len(bytes) {
+		return nil, fmt.Errorf("micro tile has %d trailing bytes", len(bytes)-r.offset)
+	}
+	return &MicroData{
+		Points:  points,
+		Offsets: offsets,
+		Hits:    hits,
+	}, nil
+}
+
+func getHitFields(hits []map[string]interface{}) []string {
+	set := make(map[string]bool)
+	for _, hit := range hits {
+		for field := range hit {
+			set[field] = true
+		}
+	}
+	fields := make([]string, 0, len(set))
+	for field := range set {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func getColumnType(field string, hits []map[string]interface{}) byte {
+	typ := byte(0)
+	for _, hit := range hits {
+		var t byte
+		switch hit[field].(type) {
+		case nil:
+			continue
+		case int, int32, int64, uint32, uint64:
+			t = intColumn
+		case float64, float32:
+			t = numberColumn
+		case string:
+			t = stringColumn
+		case bool:
+			t = boolColumn
+		default:
+			return jsonColumn
+		}
+		if typ != 0 && typ != t {
+			if isNumberColumn(typ) && isNumberColumn(t) {
+				// integers mixed with floats are encoded as numbers
+				t = numberColumn
+			} else {
+				return jsonColumn
+			}
+		}
+		typ = t
+	}
+	if typ == 0 {
+		// every value is null
+		return jsonColumn
+	}
+	return typ
+}
+
+func isNumberColumn(typ byte) bool {
+	return typ == intColumn || typ == numberColumn
+}
+
+func encodeColumn(field string, hits []map[string]interface{}) ([]byte, error) {
+	typ := getColumnType(field, hits)
+	bytes := make([]byte, 2, 2+len(field)+1)
+	binary.LittleEndian.PutUint16(bytes, uint16(len(field)))
+	bytes = append(bytes, field...)
+	bytes = append(bytes, typ)
+	// write the null bitmap
+	nulls := make([]byte, bitmapSize(len(hits)))
+	for i, hit := range hits {
+		if hit[field] != nil {
+			nulls[i/8] |= 1 << uint(i%8)
+		}
+	}
+	bytes = append(bytes, nulls...)
+	// write the values
+	switch typ {
+	case intColumn:
+		for _, hit := range hits {
+			val, _ := toInt64(hit[field])
+			bytes = appendUint64(bytes, uint64(val))
+		}
+	case numberColumn:
+		for _, hit := range hits {
+			val, _ := toFloat64(hit[field])
+			bytes = appendUint64(bytes, math.Float64bits(val))
+		}
+	case boolColumn:
+		bits := make([]byte, bitmapSize(len(hits)))
+		for i, hit := range hits {
+			if val, ok := hit[field].(bool); ok && val {
+				bits[i/8] |= 1 << uint(i%8)
+			}
+		}
+		bytes = append(bytes, bits...)
+	default:
+		// build the dictionary
+		var dict []string
+		dictIndex := make(map[string]uint32)
+		indices := make([]uint32, len(hits))
+		for i, hit := range hits {
+			val := hit[field]
+			if val == nil {
+				continue
+			}
+			str, ok := val.(string)
+			if !ok || typ == jsonColumn {
+				marshalled, err := json.Marshal(val)
+				if err != nil {
+					return nil, err
+				}
+				str = string(marshalled)
+			}
+			index, ok := dictIndex[str]
+			if !ok {
+				index = uint32(len(dict))
+				dict = append(dict, str)
+				dictIndex[str] = index
+			}
+			indices[i] = index
+		}
+		bytes = appendUint32(bytes, uint32(len(dict)))
+		for _, str := range dict {
+			bytes = appendUint32(bytes, uint32(len(str)))
+			bytes = append(bytes, str...)
+		}
+		for _, index := range indices {
+			bytes = appendUint32(bytes, index)
+		}
+	}
+	return bytes, nil
+}
+
+func decodeColumn(r *reader, hits []map[string]interface{}) error {
+	field := string(r.next(int(r.uint16())))
+	typ := r.next(1)
+	nulls := r.next(bitmapSize(len(hits)))
+	if r.err != nil {
+		return r.err
+	}
+	present := func(i int) bool {
+		return nulls[i/8]&(1<<uint(i%8)) != 0
+	}
+	switch typ[0] {
+	case intColumn:
+		for i := range hits {
+			val := int64(r.uint64())
+			if present(i) {
+				hits[i][field] = val
+			}
+		}
+	case numberColumn:
+		for i := range hits {
+			val := math.Float64frombits(r.uint64())
+			if present(i) {
+				hits[i][field] = val
+			}
+		}
+	case boolColumn:
+		bits := r.next(bitmapSize(len(hits)))
+		if r.err != nil {
+			return r.err
+		}
+		for i := range hits {
+			if present(i) {
+				hits[i][field] = bits[i/8]&(1<<uint(i%8)) != 0
+			}
+		}
+	case stringColumn, jsonColumn:
+		dict := make([]interface{}, r.uint32())
+		for i := range dict {
+			str := r.next(int(r.uint32()))
+			if r.err != nil {
+				return r.err
+			}
+			if typ[0] == stringColumn {
+				dict[i] = string(str)
+				continue
+			}
+			val, err := json.UnmarshalValue(str)
+			if err != nil {
+				return err
+			}
+			dict[i] = val
+		}
+		for i := range hits {
+			index := int(r.uint32())
+			if r.err != nil {
+				return r.err
+			}
+			if !present(i) {
+				continue
+			}
+			if index >= len(dict) {
+				return fmt.Errorf("micro tile column `%s` index %d is out of range", field, index)
+			}
+			hits[i][field] = dict[index]
+		}
+	default:
+		return fmt.Errorf("unrecognized micro tile column type `%d`", typ[0])
+	}
+	return r.err
+}
+
+func toFloat64(val interface{}) (float64, bool) {
+	switch v := val.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+func bitmapSize(n int) int {
+	return (n + 7) / 8
+}
+
+func appendUint32(bytes []byte, val uint32) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], val)
+	return append(bytes, buf[:]...)
+}
+
+func appendUint64(bytes []byte, val uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], val)
+	return append(bytes, buf[:]...)
+}
+
+// reader reads little endian values from a byte array, recording an error
+// rather than panicking if the array is truncated.
+type reader struct {
+	bytes  []byte
+	offset int
+	err    error
+}
+
+func (r *reader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || r.offset+n > len(r.bytes) {
+		r.err = fmt.Errorf("micro tile is truncated")
+		return nil
+	}
+	bytes := r.bytes[r.offset : r.offset+n]
+	r.offset += n
+	return bytes
+}
+
+func (r *reader) uint16() uint16 {
+	bytes := r.next(2)
+	if bytes == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint16(bytes)
+}
+
+func (r *reader) uint32() uint32 {
+	bytes := r.next(4)
+	if bytes == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(bytes)
+}
+
+func (r *reader) uint64() uint64 {
+	bytes := r.next(8)
+	if bytes == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(bytes)
+}
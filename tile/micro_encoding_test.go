@@ -0,0 +1,141 @@
+package tile_test
+
+import (
+	"github.com/unchartedsoftware/veldt/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+var _ = Describe("EncodeMicro", func() {
+
+	var points []float32
+	var hits []map[string]interface{}
+
+	BeforeEach(func() {
+		points = []float32{
+			1, 2,
+			3, 4,
+			5, 6,
+		}
+		hits = []map[string]interface{}{
+			{"name": "a", "count": 1.0, "flag": true, "tags": []interface{}{"x"}},
+			{"name": "b", "count": 2.5, "flag": false, "mixed": "one"},
+			{"name": "a", "mixed": 2.0},
+		}
+	})
+
+	It("should round trip points and hits", func() {
+		bytes, err := tile.EncodeMicro(points, nil, hits)
+		Expect(err).To(BeNil())
+		data, err := tile.DecodeMicro(bytes)
+		Expect(err).To(BeNil())
+		Expect(data.Points).To(Equal(points))
+		Expect(data.Offsets).To(BeNil())
+		Expect(data.Hits).To(Equal(hits))
+	})
+
+	It("should round trip points without hits", func() {
+		bytes, err := tile.EncodeMicro(points, []int{0, 8, 16, 24}, nil)
+		Expect(err).To(BeNil())
+		data, err := tile.DecodeMicro(bytes)
+		Expect(err).To(BeNil())
+		Expect(data.Points).To(Equal(points))
+		Expect(data.Offsets).To(Equal([]int{0, 8, 16, 24}))
+		Expect(data.Hits).To(BeNil())
+	})
+
+	It("should round trip integers exactly", func() {
+		bytes, err := tile.EncodeMicro(points, nil, []map[string]interface{}{
+			{"id": int64(9007199254740993)},
+			{"id": uint32(7)},
+			{"id": nil},
+		})
+		Expect(err).To(BeNil())
+		data, err := tile.DecodeMicro(bytes)
+		Expect(err).To(BeNil())
+		Expect(data.Hits).To(Equal([]map[string]interface{}{
+			{"id": int64(9007199254740993)},
+			{"id": int64(7)},
+			{},
+		}))
+	})
+
+	It("should encode integers mixed with floats as numbers", func() {
+		bytes, err := tile.EncodeMicro(points[:4], nil, []map[string]interface{}{
+			{"value": 1},
+			{"value": 2.5},
+		})
+		Expect(err).To(BeNil())
+		data, err := tile.DecodeMicro(bytes)
+		Expect(err).To(BeNil())
+		Expect(data.Hits).To(Equal([]map[string]interface{}{
+			{"value": 1.0},
+			{"value": 2.5},
+		}))
+	})
+
+	It("should store repeated strings once", func() {
+		single, err := tile.EncodeMicro(points[:2], nil, []map[string]interface{}{
+			{"name": "a long repeated value"},
+		})
+		Expect(err).To(BeNil())
+		repeated, err := tile.EncodeMicro(points, nil, []map[string]interface{}{
+			{"name": "a long repeated value"},
+			{"name": "a long repeated value"},
+			{"name": "a long repeated value"},
+		})
+		Expect(err).To(BeNil())
+		// each additional hit adds a point and a dictionary index
+		Expect(len(repeated) - len(single)).To(Equal(2 * (8 + 4)))
+	})
+
+	It("should return an error if the hits do not align with the points", func() {
+		_, err := tile.EncodeMicro(points, nil, hits[:2])
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should return an error when decoding a truncated tile", func() {
+		bytes, err := tile.EncodeMicro(points, nil, hits)
+		Expect(err).To(BeNil())
+		_, err = tile.DecodeMicro(bytes[:len(bytes)-1])
+		Expect(err).NotTo(BeNil())
+		_, err = tile.DecodeMicro(bytes[:20])
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should return an error when decoding an unsupported version", func() {
+		bytes, err := tile.EncodeMicro(points, nil, hits)
+		Expect(err).To(BeNil())
+		bytes[0] = 2
+		_, err = tile.DecodeMicro(bytes)
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should be selected by the `encoding` parameter of micro tiles", func() {
+		micro := &tile.Micro{}
+		err := micro.Parse(JSON(
+			`{
+				"encoding": "columnar",
+				"lod": 1
+			}`))
+		Expect(err).To(BeNil())
+		micro.ParseIncludes([]string{"name"}, "x", "y")
+		bytes, err := micro.Encode([]map[string]interface{}{
+			{"name": "a", "x": 200.0, "y": 200.0},
+			{"name": "b", "x": 10.0, "y": 10.0},
+		}, []float32{200, 200, 10, 10}, nil)
+		Expect(err).To(BeNil())
+		data, err := tile.DecodeMicro(bytes)
+		Expect(err).To(BeNil())
+		// the points and hits are sorted by morton code
+		Expect(data.Points).To(Equal([]float32{10, 10, 200, 200}))
+		Expect(data.Hits).To(Equal([]map[string]interface{}{
+			{"name": "b"},
+			{"name": "a"},
+		}))
+		Expect(len(data.Offsets)).To(Equal(4))
+	})
+
+})
@@ -25,6 +25,17 @@ var _ = Describe("Micro", func() {
 			err := micro.Parse(params)
 			Expect(err).To(BeNil())
 			Expect(micro.LOD).To(Equal(4))
+			Expect(micro.Resolution).To(Equal(256))
+		})
+
+		It("should parse the `tileResolution` parameter", func() {
+			params := JSON(
+				`{
+					"tileResolution": 4096
+				}`)
+			err := micro.Parse(params)
+			Expect(err).To(BeNil())
+			Expect(micro.Resolution).To(Equal(4096))
 		})
 	})
 
@@ -50,7 +61,21 @@ var _ = Describe("Micro", func() {
 				}`)
 			err := micro.Parse(params)
 			Expect(err).To(BeNil())
-			bytes, err := micro.Encode(nil, []float32{1, 1})
+			bytes, err := micro.Encode(nil, []float32{1, 1}, nil)
+			Expect(err).To(BeNil())
+			Expect(bytes).To(Equal(correct))
+		})
+
+		It("should partition the LOD offsets by the `tileResolution`", func() {
+			correct := []byte(`{"hits":null,"offsets":[0,8,8,8],"points":[300,100]}`)
+			params := JSON(
+				`{
+					"lod": 1,
+					"tileResolution": 1024
+				}`)
+			err := micro.Parse(params)
+			Expect(err).To(BeNil())
+			bytes, err := micro.Encode(nil, []float32{300, 100}, nil)
 			Expect(err).To(BeNil())
 			Expect(bytes).To(Equal(correct))
 		})
@@ -1,7 +1,9 @@
 package tile
 
-const (
-	maxMorton = 256 * 256
+import (
+	"math"
+
+	"github.com/unchartedsoftware/veldt/binning"
 )
 
 var (
@@ -17,6 +19,63 @@ func Morton(fx float32, fy float32) int {
 	return int(my[y&0xFF] | mx[x&0xFF])
 }
 
+// Morton16 returns the 32 bit morton code interleaving the provided 16 bit
+// values.
+func Morton16(x uint16, y uint16) uint32 {
+	hi := my[y>>8] | mx[x>>8]
+	lo := my[y&0xFF] | mx[x&0xFF]
+	return uint32(hi<<16 | lo)
+}
+
+// Morton32 returns the 64 bit morton code interleaving the provided 32 bit
+// values.
+func Morton32(x uint32, y uint32) uint64 {
+	hi := Morton16(uint16(x>>16), uint16(y>>16))
+	lo := Morton16(uint16(x), uint16(y))
+	return uint64(hi)<<32 | uint64(lo)
+}
+
+// MortonResolution returns the morton code for the provided point within a
+// tile of the provided resolution, where values are in the range
+// [0.0: resolution). The point is quantized to 16 bits per axis for
+// resolutions up to binning.MaxTileResolution, and 32 bits per axis beyond,
+// preserving sub-pixel precision.
+func MortonResolution(fx float32, fy float32, resolution float64) uint64 {
+	bits := mortonBits(resolution)
+	x := quantizeMorton(fx, resolution, bits)
+	y := quantizeMorton(fy, resolution, bits)
+	if bits == 16 {
+		return uint64(Morton16(uint16(x), uint16(y)))
+	}
+	return Morton32(uint32(x), uint32(y))
+}
+
+// mortonBits returns the number of bits per axis used by MortonResolution for
+// the provided resolution.
+func mortonBits(resolution float64) uint {
+	if resolution <= binning.MaxTileResolution {
+		return 16
+	}
+	return 32
+}
+
+// mortonPartition returns the LOD partition of the provided morton code.
+func mortonPartition(code uint64, resolution float64, lod int) int {
+	return int(code >> (2*mortonBits(resolution) - 2*uint(lod)))
+}
+
+func quantizeMorton(v float32, resolution float64, bits uint) uint64 {
+	max := float64(uint64(1) << bits)
+	q := math.Floor(float64(v) / resolution * max)
+	if q < 0 {
+		return 0
+	}
+	if q > max-1 {
+		return uint64(max - 1)
+	}
+	return uint64(q)
+}
+
 func init() {
 	// init the morton code lookups.
 	mx = []uint64{0, 1}
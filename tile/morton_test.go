@@ -0,0 +1,57 @@
+package tile_test
+
+import (
+	"github.com/unchartedsoftware/veldt/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Morton", func() {
+
+	Describe("Morton16", func() {
+		It("should interleave the bits of x and y", func() {
+			Expect(tile.Morton16(1, 0)).To(Equal(uint32(1)))
+			Expect(tile.Morton16(0, 1)).To(Equal(uint32(2)))
+			Expect(tile.Morton16(3, 3)).To(Equal(uint32(15)))
+			Expect(tile.Morton16(0x100, 0)).To(Equal(uint32(0x10000)))
+			Expect(tile.Morton16(0xFFFF, 0)).To(Equal(uint32(0x55555555)))
+			Expect(tile.Morton16(0, 0xFFFF)).To(Equal(uint32(0xAAAAAAAA)))
+		})
+
+		It("should match the 8 bit morton code for values within [0: 256)", func() {
+			Expect(tile.Morton16(137, 7)).To(Equal(uint32(tile.Morton(137.24, 7.07))))
+			Expect(tile.Morton16(255, 128)).To(Equal(uint32(tile.Morton(255.5, 128.1))))
+		})
+	})
+
+	Describe("Morton32", func() {
+		It("should interleave the bits of x and y", func() {
+			Expect(tile.Morton32(1, 0)).To(Equal(uint64(1)))
+			Expect(tile.Morton32(0, 1)).To(Equal(uint64(2)))
+			Expect(tile.Morton32(0x10000, 0)).To(Equal(uint64(1) << 32))
+			Expect(tile.Morton32(0xFFFFFFFF, 0)).To(Equal(uint64(0x5555555555555555)))
+			Expect(tile.Morton32(0, 0xFFFFFFFF)).To(Equal(uint64(0xAAAAAAAAAAAAAAAA)))
+		})
+	})
+
+	Describe("MortonResolution", func() {
+		It("should order points within the same pixel", func() {
+			a := tile.MortonResolution(10.25, 10.25, 256)
+			b := tile.MortonResolution(10.75, 10.75, 256)
+			Expect(tile.Morton(10.25, 10.25)).To(Equal(tile.Morton(10.75, 10.75)))
+			Expect(a).To(BeNumerically("<", b))
+		})
+
+		It("should scale points by the resolution", func() {
+			Expect(tile.MortonResolution(512, 512, 1024)).To(Equal(uint64(3) << 62))
+			Expect(tile.MortonResolution(128, 128, 256)).To(Equal(uint64(3) << 30))
+		})
+
+		It("should clamp points outside of the tile", func() {
+			Expect(tile.MortonResolution(-1, -1, 256)).To(Equal(uint64(0)))
+			Expect(tile.MortonResolution(300, 300, 256)).To(Equal(uint64(0xFFFFFFFF)))
+		})
+	})
+
+})
@@ -0,0 +1,282 @@
+package tile
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+const (
+	// MVTEncoding encodes the tile as a Mapbox vector tile.
+	MVTEncoding = "mvt"
+	// JSONEncoding encodes the tile as JSON.
+	JSONEncoding = "json"
+
+	// MVTExtent is the extent of the vector tile that the 256 pixel tile is
+	// mapped to.
+	MVTExtent = 4096
+
+	mvtVersion = 2
+
+	// protobuf wire types
+	wireVarint = 0
+	wireDouble = 1
+	wireBytes  = 2
+
+	// geometry types
+	mvtPoint      = 1
+	mvtLineString = 2
+
+	// geometry commands
+	mvtMoveTo = 1
+	mvtLineTo = 2
+)
+
+// MVTLayer represents a layer of point and line features within a Mapbox
+// vector tile. Features are positioned in the [0 : 256) pixel space of the
+// tile, with the origin at the bottom-left.
+type MVTLayer struct {
+	name     string
+	keys     []string
+	keyIndex map[string]int
+	values   []interface{}
+	valIndex map[interface{}]int
+	features [][]byte
+}
+
+// NewMVTLayer instantiates and returns a new layer under the provided name.
+func NewMVTLayer(name string) *MVTLayer {
+	return &MVTLayer{
+		name:     name,
+		keyIndex: make(map[string]int),
+		valIndex: make(map[interface{}]int),
+	}
+}
+
+// AddPoint adds a point feature with the provided properties to the layer.
+func (l *MVTLayer) AddPoint(x float32, y float32, props map[string]interface{}) {
+	px, py := toMVT(x, y)
+	geometry := []uint32{
+		mvtCommand(mvtMoveTo, 1),
+		uint32(zigzag(px)),
+		uint32(zigzag(py)),
+	}
+	l.addFeature(mvtPoint, geometry, props)
+}
+
+// AddLine adds a line feature between the provided source and destination
+// with the provided properties to the layer.
+func (l *MVTLayer) AddLine(x0 float32, y0 float32, x1 float32, y1 float32, props map[string]interface{}) {
+	px0, py0 := toMVT(x0, y0)
+	px1, py1 := toMVT(x1, y1)
+	geometry := []uint32{
+		mvtCommand(mvtMoveTo, 1),
+		uint32(zigzag(px0)),
+		uint32(zigzag(py0)),
+		mvtCommand(mvtLineTo, 1),
+		uint32(zigzag(px1 - px0)),
+		uint32(zigzag(py1 - py0)),
+	}
+	l.addFeature(mvtLineString, geometry, props)
+}
+
+func (l *MVTLayer) addFeature(typ uint64, geometry []uint32, props map[string]interface{}) {
+	// sort the keys so the encoding is deterministic
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	tags := make([]uint32, 0, len(keys)*2)
+	for _, key := range keys {
+		val, ok := mvtValue(props[key])
+		if !ok {
+			continue
+		}
+		tags = append(tags, l.getKey(key), l.getValue(val))
+	}
+	var feature []byte
+	feature = appendVarintField(feature, 1, uint64(len(l.features)+1))
+	if len(tags) > 0 {
+		feature = appendBytesField(feature, 2, packUint32(tags))
+	}
+	feature = appendVarintField(feature, 3, typ)
+	feature = appendBytesField(feature, 4, packUint32(geometry))
+	l.features = append(l.features, feature)
+}
+
+func (l *MVTLayer) getKey(key string) uint32 {
+	index, ok := l.keyIndex[key]
+	if !ok {
+		index = len(l.keys)
+		l.keys = append(l.keys, key)
+		l.keyIndex[key] = index
+	}
+	return uint32(index)
+}
+
+func (l *MVTLayer) getValue(val interface{}) uint32 {
+	index, ok := l.valIndex[val]
+	if !ok {
+		index = len(l.values)
+		l.values = append(l.values, val)
+		l.valIndex[val] = index
+	}
+	return uint32(index)
+}
+
+func (l *MVTLayer) encode() []byte {
+	var layer []byte
+	layer = appendVarintField(layer, 15, mvtVersion)
+	layer = appendBytesField(layer, 1, []byte(l.name))
+	for _, feature := range l.features {
+		layer = appendBytesField(layer, 2, feature)
+	}
+	for _, key := range l.keys {
+		layer = appendBytesField(layer, 3, []byte(key))
+	}
+	for _, val := range l.values {
+		layer = appendBytesField(layer, 4, encodeMVTValue(val))
+	}
+	layer = appendVarintField(layer, 5, MVTExtent)
+	return layer
+}
+
+// EncodeMVT encodes the provided layers as a Mapbox vector tile.
+func EncodeMVT(layers ...*MVTLayer) []byte {
+	var bytes []byte
+	for _, layer := range layers {
+		bytes = appendBytesField(bytes, 3, layer.encode())
+	}
+	return bytes
+}
+
+// toMVT converts a pixel position within the tile into the integer vector tile
+// coordinates, which have their origin at the top-left.
+func toMVT(x float32, y float32) (int64, int64) {
+	scale := MVTExtent / binning.MaxTileResolution
+	px := math.Floor(float64(x)*scale + 0.5)
+	py := math.Floor((binning.MaxTileResolution-float64(y))*scale + 0.5)
+	return int64(px), int64(py)
+}
+
+// mvtValue converts a property into a value which may be stored in a vector
+// tile. Objects and arrays are stored as JSON strings.
+func mvtValue(val interface{}) (interface{}, bool) {
+	switch v := val.(type) {
+	case nil:
+		return nil, false
+	case string, bool, float64, int64, uint64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case uint32:
+		return uint64(v), true
+	}
+	bytes, err := json.Marshal(val)
+	if err != nil {
+		return nil, false
+	}
+	return string(bytes), true
+}
+
+func encodeMVTValue(val interface{}) []byte {
+	var bytes []byte
+	switch v := val.(type) {
+	case string:
+		bytes = appendBytesField(bytes, 1, []byte(v))
+	case float64:
+		bytes = appendTag(bytes, 3, wireDouble)
+		bytes = appendFixed64(bytes, math.Float64bits(v))
+	case int64:
+		bytes = appendVarintField(bytes, 6, zigzag(v))
+	case uint64:
+		bytes = appendVarintField(bytes, 5, v)
+	case bool:
+		b := uint64(0)
+		if v {
+			b = 1
+		}
+		bytes = appendVarintField(bytes, 7, b)
+	}
+	return bytes
+}
+
+// parseEncoding parses the encoding of a point or edge tile, which is either
+// the provided default encoding, one of the provided alternatives, or
+// MVTEncoding. Vector tiles, Arrow tables and GeoJSON do not support levels of
+// detail.
+func parseEncoding(params map[string]interface{}, lod int, def string, alternatives ...string) (string, error) {
+	encoding := json.GetStringDefault(params, def, "encoding")
+	if encoding != def && encoding != MVTEncoding && !existsIn(encoding, alternatives) {
+		return "", fmt.Errorf("`encoding` parameter `%s` is not recognized", encoding)
+	}
+	if (encoding == MVTEncoding || encoding == ArrowEncoding || encoding == GeoJSONEncoding) && lod > 0 {
+		return "", fmt.Errorf("`lod` parameter is not supported by the `%s` encoding", encoding)
+	}
+	return encoding, nil
+}
+
+func encodingParam(def string, alternatives ...string) json.Param {
+	enum := []interface{}{def}
+	for _, alternative := range alternatives {
+		enum = append(enum, alternative)
+	}
+	return json.Param{
+		Key:     "encoding",
+		Type:    json.TypeString,
+		Default: def,
+		Enum:    append(enum, MVTEncoding),
+	}
+}
+
+func mvtCommand(id uint32, count uint32) uint32 {
+	return (id & 0x7) | (count << 3)
+}
+
+func zigzag(val int64) uint64 {
+	return uint64((val << 1) ^ (val >> 63))
+}
+
+func packUint32(vals []uint32) []byte {
+	var bytes []byte
+	for _, val := range vals {
+		bytes = appendVarint(bytes, uint64(val))
+	}
+	return bytes
+}
+
+func appendTag(bytes []byte, field uint64, wire uint64) []byte {
+	return appendVarint(bytes, field<<3|wire)
+}
+
+func appendVarint(bytes []byte, val uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], val)
+	return append(bytes, buf[:n]...)
+}
+
+func appendFixed64(bytes []byte, val uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], val)
+	return append(bytes, buf[:]...)
+}
+
+func appendVarintField(bytes []byte, field uint64, val uint64) []byte {
+	bytes = appendTag(bytes, field, wireVarint)
+	return appendVarint(bytes, val)
+}
+
+func appendBytesField(bytes []byte, field uint64, val []byte) []byte {
+	bytes = appendTag(bytes, field, wireBytes)
+	bytes = appendVarint(bytes, uint64(len(val)))
+	return append(bytes, val...)
+}
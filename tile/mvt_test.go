@@ -0,0 +1,199 @@
+package tile_test
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/unchartedsoftware/veldt/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+// readFields decodes the fields of a protobuf message by field number. Varint
+// and fixed64 fields are returned as uint64 and length delimited fields as
+// []byte.
+func readFields(bytes []byte) map[uint64][]interface{} {
+	fields := make(map[uint64][]interface{})
+	for len(bytes) > 0 {
+		tag, n := binary.Uvarint(bytes)
+		bytes = bytes[n:]
+		field := tag >> 3
+		switch tag & 0x7 {
+		case 0:
+			val, n := binary.Uvarint(bytes)
+			bytes = bytes[n:]
+			fields[field] = append(fields[field], val)
+		case 1:
+			fields[field] = append(fields[field], binary.LittleEndian.Uint64(bytes))
+			bytes = bytes[8:]
+		case 2:
+			length, n := binary.Uvarint(bytes)
+			bytes = bytes[n:]
+			fields[field] = append(fields[field], bytes[:length])
+			bytes = bytes[length:]
+		default:
+			Fail("unexpected wire type")
+		}
+	}
+	return fields
+}
+
+func readPacked(bytes []byte) []uint64 {
+	var vals []uint64
+	for len(bytes) > 0 {
+		val, n := binary.Uvarint(bytes)
+		bytes = bytes[n:]
+		vals = append(vals, val)
+	}
+	return vals
+}
+
+func unzigzag(val uint64) int64 {
+	return int64(val>>1) ^ -int64(val&1)
+}
+
+func readLayer(bytes []byte) map[uint64][]interface{} {
+	tile := readFields(bytes)
+	Expect(len(tile[3])).To(Equal(1))
+	return readFields(tile[3][0].([]byte))
+}
+
+var _ = Describe("MVT", func() {
+
+	Describe("EncodeMVT", func() {
+		It("should encode a layer with the version, name and extent", func() {
+			layer := readLayer(tile.EncodeMVT(tile.NewMVTLayer("points")))
+			Expect(layer[15]).To(Equal([]interface{}{uint64(2)}))
+			Expect(layer[1]).To(Equal([]interface{}{[]byte("points")}))
+			Expect(layer[5]).To(Equal([]interface{}{uint64(tile.MVTExtent)}))
+		})
+
+		It("should encode points with the origin at the top-left", func() {
+			layer := tile.NewMVTLayer("points")
+			layer.AddPoint(16, 240, nil)
+			fields := readLayer(tile.EncodeMVT(layer))
+			Expect(len(fields[2])).To(Equal(1))
+			feature := readFields(fields[2][0].([]byte))
+			Expect(feature[3]).To(Equal([]interface{}{uint64(1)}))
+			geometry := readPacked(feature[4][0].([]byte))
+			Expect(geometry[0]).To(Equal(uint64(1<<3 | 1)))
+			Expect(unzigzag(geometry[1])).To(Equal(int64(256)))
+			Expect(unzigzag(geometry[2])).To(Equal(int64(256)))
+		})
+
+		It("should encode lines as relative moves", func() {
+			layer := tile.NewMVTLayer("edges")
+			layer.AddLine(0, 256, 128, 128, nil)
+			fields := readLayer(tile.EncodeMVT(layer))
+			feature := readFields(fields[2][0].([]byte))
+			Expect(feature[3]).To(Equal([]interface{}{uint64(2)}))
+			geometry := readPacked(feature[4][0].([]byte))
+			Expect(len(geometry)).To(Equal(6))
+			Expect(unzigzag(geometry[1])).To(Equal(int64(0)))
+			Expect(unzigzag(geometry[2])).To(Equal(int64(0)))
+			Expect(geometry[3]).To(Equal(uint64(1<<3 | 2)))
+			Expect(unzigzag(geometry[4])).To(Equal(int64(2048)))
+			Expect(unzigzag(geometry[5])).To(Equal(int64(2048)))
+		})
+
+		It("should share keys and values between features", func() {
+			layer := tile.NewMVTLayer("points")
+			layer.AddPoint(0, 0, map[string]interface{}{
+				"name":  "a",
+				"count": 2.5,
+			})
+			layer.AddPoint(1, 1, map[string]interface{}{
+				"name":   "a",
+				"nested": map[string]interface{}{"b": true},
+			})
+			fields := readLayer(tile.EncodeMVT(layer))
+			Expect(fields[3]).To(Equal([]interface{}{
+				[]byte("count"),
+				[]byte("name"),
+				[]byte("nested"),
+			}))
+			Expect(len(fields[4])).To(Equal(3))
+			// count is a double
+			count := readFields(fields[4][0].([]byte))
+			Expect(math.Float64frombits(count[3][0].(uint64))).To(Equal(2.5))
+			// nested objects are JSON strings
+			nested := readFields(fields[4][2].([]byte))
+			Expect(nested[1]).To(Equal([]interface{}{[]byte(`{"b":true}`)}))
+			// the second feature reuses the name key and value
+			feature := readFields(fields[2][1].([]byte))
+			Expect(readPacked(feature[2][0].([]byte))).To(Equal([]uint64{1, 1, 2, 2}))
+		})
+	})
+
+	Describe("Encoding", func() {
+		It("should encode micro tiles with the hits as properties", func() {
+			micro := &tile.Micro{}
+			err := micro.Parse(JSON(
+				`{
+					"encoding": "mvt"
+				}`))
+			Expect(err).To(BeNil())
+			bytes, err := micro.Encode([]map[string]interface{}{
+				{"id": "a"},
+				{"id": "b"},
+			}, []float32{0, 0, 128, 128}, nil)
+			Expect(err).To(BeNil())
+			fields := readLayer(bytes)
+			Expect(len(fields[2])).To(Equal(2))
+			Expect(fields[3]).To(Equal([]interface{}{[]byte("id")}))
+		})
+
+		It("should encode macro edge tiles as weighted lines", func() {
+			edge := &tile.MacroEdge{}
+			err := edge.Parse(JSON(
+				`{
+					"encoding": "mvt"
+				}`))
+			Expect(err).To(BeNil())
+			bytes, err := edge.Encode([]float32{0, 0, 3, 128, 128, 3}, nil)
+			Expect(err).To(BeNil())
+			fields := readLayer(bytes)
+			Expect(fields[1]).To(Equal([]interface{}{[]byte("edges")}))
+			Expect(fields[3]).To(Equal([]interface{}{[]byte("weight")}))
+		})
+
+		It("should encode micro edge tiles as lines with the hits as properties", func() {
+			edge := &tile.MicroEdge{}
+			err := edge.Parse(JSON(
+				`{
+					"encoding": "mvt"
+				}`))
+			Expect(err).To(BeNil())
+			bytes, err := edge.Encode([]map[string]interface{}{
+				{"id": "a"},
+			}, []float32{0, 0, 128, 128}, nil)
+			Expect(err).To(BeNil())
+			fields := readLayer(bytes)
+			Expect(fields[1]).To(Equal([]interface{}{[]byte("edges")}))
+			Expect(len(fields[2])).To(Equal(1))
+			Expect(fields[3]).To(Equal([]interface{}{[]byte("id")}))
+		})
+
+		It("should return an error if the `encoding` is not recognized", func() {
+			macro := &tile.Macro{}
+			err := macro.Parse(JSON(
+				`{
+					"encoding": "svg"
+				}`))
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should return an error if LOD is requested with the mvt encoding", func() {
+			macro := &tile.Macro{}
+			err := macro.Parse(JSON(
+				`{
+					"encoding": "mvt",
+					"lod": 4
+				}`))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+})
@@ -0,0 +1,80 @@
+package tile
+
+import (
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/geometry"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+const (
+	defaultProjection = binning.LinearProjection
+)
+
+// parseProjection parses the projection and the optional data bounds from the
+// provided JSON object. The bounds are required by the linear projection.
+func parseProjection(params map[string]interface{}) (binning.Projection, *geometry.Bounds, error) {
+	id := json.GetStringDefault(params, defaultProjection, "projection")
+	var bounds *geometry.Bounds
+	if id == binning.LinearProjection || hasBounds(params) {
+		bounds = &geometry.Bounds{}
+		err := bounds.Parse(params)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	projection, err := binning.GetProjection(id, bounds)
+	if err != nil {
+		return nil, nil, err
+	}
+	return projection, bounds, nil
+}
+
+func hasBounds(params map[string]interface{}) bool {
+	return json.Exists(params, "left") ||
+		json.Exists(params, "right") ||
+		json.Exists(params, "bottom") ||
+		json.Exists(params, "top")
+}
+
+// projectionParams returns the parameters accepted by parseProjection.
+func projectionParams() []json.Param {
+	ids := binning.Projections()
+	enum := make([]interface{}, len(ids))
+	for i, id := range ids {
+		enum[i] = id
+	}
+	params := []json.Param{
+		{Key: "projection", Type: json.TypeString, Default: defaultProjection, Enum: enum},
+	}
+	// bounds are only required by the linear projection, which is checked
+	// when parsing
+	for _, param := range (&geometry.Bounds{}).Params() {
+		param.Required = false
+		params = append(params, param)
+	}
+	return params
+}
+
+func isLinear(projection binning.Projection) bool {
+	_, ok := projection.(*binning.Linear)
+	return ok
+}
+
+// project returns the position of the data coordinate within the range of
+// [0 : 256) for the tile.
+func project(projection binning.Projection, coord *binning.TileCoord, x float64, y float64) (float64, float64) {
+	tile := projection.Project(geometry.NewCoord(x, y), coord.Z)
+	return (tile.X - float64(coord.X)) * binning.MaxTileResolution,
+		(tile.Y - float64(coord.Y)) * binning.MaxTileResolution
+}
+
+// unproject returns the data coordinate of the position within the range of
+// [0 : 256) for the tile.
+func unproject(projection binning.Projection, coord *binning.TileCoord, x float64, y float64) (float64, float64) {
+	data := projection.Unproject(&binning.FractionalTileCoord{
+		X: float64(coord.X) + x/binning.MaxTileResolution,
+		Y: float64(coord.Y) + y/binning.MaxTileResolution,
+		Z: coord.Z,
+	})
+	return data.X, data.Y
+}
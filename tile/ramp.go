@@ -0,0 +1,206 @@
+package tile
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+const (
+	// ViridisRamp is a perceptually uniform ramp from purple to yellow.
+	ViridisRamp = "viridis"
+	// HotRamp is a ramp from black through red and yellow to white.
+	HotRamp = "hot"
+	// GreyscaleRamp is a ramp from black to white.
+	GreyscaleRamp = "greyscale"
+)
+
+var (
+	ramps = map[string][]string{
+		ViridisRamp: {
+			"#440154",
+			"#472d7b",
+			"#3b528b",
+			"#2c728e",
+			"#21918c",
+			"#28ae80",
+			"#5ec962",
+			"#addc30",
+			"#fde725",
+		},
+		HotRamp: {
+			"#000000",
+			"#ff0000",
+			"#ffff00",
+			"#ffffff",
+		},
+		GreyscaleRamp: {
+			"#000000",
+			"#ffffff",
+		},
+	}
+)
+
+// ColorStop represents a color at a normalized position along a ramp.
+type ColorStop struct {
+	Value float64
+	Color color.RGBA
+}
+
+// ColorRamp represents a sequence of color stops, sorted by value, which are
+// linearly interpolated between.
+type ColorRamp struct {
+	Stops []ColorStop
+}
+
+// GetColorRamp returns the named color ramp.
+func GetColorRamp(name string) (*ColorRamp, error) {
+	colors, ok := ramps[name]
+	if !ok {
+		return nil, fmt.Errorf("color ramp `%s` is not recognized", name)
+	}
+	stops := make([]ColorStop, len(colors))
+	for i, hex := range colors {
+		c, err := ParseColor(hex)
+		if err != nil {
+			return nil, err
+		}
+		stops[i] = ColorStop{
+			Value: float64(i) / float64(len(colors)-1),
+			Color: c,
+		}
+	}
+	return &ColorRamp{
+		Stops: stops,
+	}, nil
+}
+
+// ParseColorRamp parses a color ramp from either the name of a ramp, or an
+// array of stops. Stops are either hex color strings, which are spaced
+// evenly, or objects with a `value` between 0 and 1 and a hex `color`.
+//
+// Ex:
+//     [
+//         { "value": 0.0, "color": "#000000" },
+//         { "value": 0.8, "color": "#ff0000" },
+//         { "value": 1.0, "color": "#ffffff" }
+//     ]
+func ParseColorRamp(arg interface{}) (*ColorRamp, error) {
+	name, ok := arg.(string)
+	if ok {
+		return GetColorRamp(name)
+	}
+	vals, ok := arg.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("`ramp` parameter is not a string or an array")
+	}
+	if len(vals) < 2 {
+		return nil, fmt.Errorf("`ramp` parameter requires at least two stops")
+	}
+	stops := make([]ColorStop, len(vals))
+	for i, val := range vals {
+		stop, err := parseColorStop(val, float64(i)/float64(len(vals)-1))
+		if err != nil {
+			return nil, err
+		}
+		stops[i] = *stop
+	}
+	sort.SliceStable(stops, func(i, j int) bool {
+		return stops[i].Value < stops[j].Value
+	})
+	return &ColorRamp{
+		Stops: stops,
+	}, nil
+}
+
+func parseColorStop(arg interface{}, def float64) (*ColorStop, error) {
+	hex, ok := arg.(string)
+	if ok {
+		c, err := ParseColor(hex)
+		if err != nil {
+			return nil, err
+		}
+		return &ColorStop{
+			Value: def,
+			Color: c,
+		}, nil
+	}
+	obj, ok := arg.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("`ramp` stop is not a string or an object")
+	}
+	value, ok := json.GetFloat(obj, "value")
+	if !ok {
+		return nil, fmt.Errorf("`value` parameter missing from `ramp` stop")
+	}
+	if value < 0 || value > 1 {
+		return nil, fmt.Errorf("`ramp` stop value %v is not between 0 and 1", value)
+	}
+	hex, ok = json.GetString(obj, "color")
+	if !ok {
+		return nil, fmt.Errorf("`color` parameter missing from `ramp` stop")
+	}
+	c, err := ParseColor(hex)
+	if err != nil {
+		return nil, err
+	}
+	return &ColorStop{
+		Value: value,
+		Color: c,
+	}, nil
+}
+
+// ParseColor parses a `#rrggbb` or `#rrggbbaa` hex color string.
+func ParseColor(hex string) (color.RGBA, error) {
+	digits := strings.TrimPrefix(hex, "#")
+	if len(digits) == 6 {
+		digits += "ff"
+	}
+	if len(digits) != 8 {
+		return color.RGBA{}, fmt.Errorf("color `%s` is not a hex color", hex)
+	}
+	val, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("color `%s` is not a hex color", hex)
+	}
+	return color.RGBA{
+		R: uint8(val >> 24),
+		G: uint8(val >> 16),
+		B: uint8(val >> 8),
+		A: uint8(val),
+	}, nil
+}
+
+// Color returns the color at the normalized position along the ramp.
+func (r *ColorRamp) Color(t float64) color.RGBA {
+	first := r.Stops[0]
+	last := r.Stops[len(r.Stops)-1]
+	if t <= first.Value {
+		return first.Color
+	}
+	if t >= last.Value {
+		return last.Color
+	}
+	// find the first stop past the position
+	i := sort.Search(len(r.Stops), func(i int) bool {
+		return r.Stops[i].Value > t
+	})
+	from := r.Stops[i-1]
+	to := r.Stops[i]
+	alpha := (t - from.Value) / (to.Value - from.Value)
+	return color.RGBA{
+		R: lerpChannel(from.Color.R, to.Color.R, alpha),
+		G: lerpChannel(from.Color.G, to.Color.G, alpha),
+		B: lerpChannel(from.Color.B, to.Color.B, alpha),
+		A: lerpChannel(from.Color.A, to.Color.A, alpha),
+	}
+}
+
+func lerpChannel(from uint8, to uint8, alpha float64) uint8 {
+	return uint8(math.Floor(float64(from) + (float64(to)-float64(from))*alpha + 0.5))
+}
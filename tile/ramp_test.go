@@ -0,0 +1,88 @@
+package tile_test
+
+import (
+	"image/color"
+
+	"github.com/unchartedsoftware/veldt/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+var _ = Describe("ColorRamp", func() {
+
+	Describe("ParseColor", func() {
+		It("should parse rgb and rgba hex colors", func() {
+			c, err := tile.ParseColor("#ff8000")
+			Expect(err).To(BeNil())
+			Expect(c).To(Equal(color.RGBA{R: 255, G: 128, B: 0, A: 255}))
+			c, err = tile.ParseColor("#ff800080")
+			Expect(err).To(BeNil())
+			Expect(c).To(Equal(color.RGBA{R: 255, G: 128, B: 0, A: 128}))
+		})
+		It("should return an error for an invalid color", func() {
+			_, err := tile.ParseColor("#ff80")
+			Expect(err).NotTo(BeNil())
+			_, err = tile.ParseColor("#gg8000")
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("ParseColorRamp", func() {
+		It("should return a named ramp", func() {
+			ramp, err := tile.ParseColorRamp(tile.GreyscaleRamp)
+			Expect(err).To(BeNil())
+			Expect(ramp.Color(0)).To(Equal(color.RGBA{R: 0, G: 0, B: 0, A: 255}))
+			Expect(ramp.Color(1)).To(Equal(color.RGBA{R: 255, G: 255, B: 255, A: 255}))
+		})
+		It("should space color strings evenly", func() {
+			params := JSON(
+				`{
+					"ramp": ["#000000", "#ff0000", "#ffffff"]
+				}`)
+			ramp, err := tile.ParseColorRamp(params["ramp"])
+			Expect(err).To(BeNil())
+			Expect(ramp.Stops[1].Value).To(Equal(0.5))
+			Expect(ramp.Color(0.5)).To(Equal(color.RGBA{R: 255, G: 0, B: 0, A: 255}))
+		})
+		It("should sort custom stops by value", func() {
+			params := JSON(
+				`{
+					"ramp": [
+						{ "value": 1.0, "color": "#ffffff" },
+						{ "value": 0.0, "color": "#000000" },
+						{ "value": 0.8, "color": "#ff0000" }
+					]
+				}`)
+			ramp, err := tile.ParseColorRamp(params["ramp"])
+			Expect(err).To(BeNil())
+			Expect(ramp.Stops[1].Value).To(Equal(0.8))
+			Expect(ramp.Color(0.4)).To(Equal(color.RGBA{R: 128, G: 0, B: 0, A: 255}))
+		})
+		It("should return an error for an unrecognized ramp", func() {
+			_, err := tile.ParseColorRamp("rainbow")
+			Expect(err).NotTo(BeNil())
+		})
+		It("should return an error for a stop out of range", func() {
+			params := JSON(
+				`{
+					"ramp": [
+						{ "value": 0.0, "color": "#000000" },
+						{ "value": 1.5, "color": "#ffffff" }
+					]
+				}`)
+			_, err := tile.ParseColorRamp(params["ramp"])
+			Expect(err).NotTo(BeNil())
+		})
+		It("should return an error for a single stop", func() {
+			params := JSON(
+				`{
+					"ramp": ["#000000"]
+				}`)
+			_, err := tile.ParseColorRamp(params["ramp"])
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+})
@@ -0,0 +1,212 @@
+package tile
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"math"
+	"sort"
+
+	"github.com/unchartedsoftware/veldt/binning"
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+const (
+	// LinearTransform maps values linearly between the minimum and maximum.
+	LinearTransform = "linear"
+	// LogTransform maps the logarithm of values between the minimum and
+	// maximum.
+	LogTransform = "log"
+	// EqualizeTransform maps values by their rank among the non-empty bins of
+	// the tile, spreading the colors evenly across the bins.
+	EqualizeTransform = "equalize"
+
+	defaultRamp      = ViridisRamp
+	defaultTransform = LinearTransform
+)
+
+// Render represents the parameters required to render the bins of a heatmap
+// tile into an image. Empty bins, with a value of zero, are transparent.
+type Render struct {
+	Ramp      *ColorRamp
+	Transform string
+	Min       *float64
+	Max       *float64
+}
+
+// Parse parses the provided JSON object and populates the tiles attributes.
+func (r *Render) Parse(params map[string]interface{}) error {
+	// get the color ramp
+	arg, ok := json.Get(params, "ramp")
+	if !ok {
+		arg = defaultRamp
+	}
+	ramp, err := ParseColorRamp(arg)
+	if err != nil {
+		return err
+	}
+	// get the transfer function
+	transform := json.GetStringDefault(params, defaultTransform, "transform")
+	switch transform {
+	case LinearTransform, LogTransform, EqualizeTransform:
+	default:
+		return fmt.Errorf("`transform` parameter `%s` is not recognized", transform)
+	}
+	// get the explicit value range
+	var min, max *float64
+	val, ok := json.GetFloat(params, "min")
+	if ok {
+		min = &val
+	}
+	val, ok = json.GetFloat(params, "max")
+	if ok {
+		max = &val
+	}
+	// set attributes
+	r.Ramp = ramp
+	r.Transform = transform
+	r.Min = min
+	r.Max = max
+	return nil
+}
+
+// Params returns the parameters accepted by the tile.
+func (r *Render) Params() []json.Param {
+	return []json.Param{
+		{Key: "ramp", Default: defaultRamp},
+		{
+			Key:     "transform",
+			Type:    json.TypeString,
+			Default: defaultTransform,
+			Enum: []interface{}{
+				LinearTransform,
+				LogTransform,
+				EqualizeTransform,
+			},
+		},
+		{Key: "min", Type: json.TypeNumber},
+		{Key: "max", Type: json.TypeNumber},
+	}
+}
+
+// Extrema returns the value range to render the bins with. Bounds which are
+// not explicitly set are taken from the non-empty bins.
+func (r *Render) Extrema(bins []float32) (float64, float64) {
+	min := math.Inf(1)
+	max := math.Inf(-1)
+	for _, bin := range bins {
+		if bin == 0 {
+			continue
+		}
+		min = math.Min(min, float64(bin))
+		max = math.Max(max, float64(bin))
+	}
+	if r.Min != nil {
+		min = *r.Min
+	}
+	if r.Max != nil {
+		max = *r.Max
+	}
+	if math.IsInf(min, 0) || math.IsInf(max, 0) {
+		return 0, 0
+	}
+	return min, max
+}
+
+// Image renders the bins into a square image, with the first bin at the
+// bottom-left corner.
+func (r *Render) Image(bins []float32, min float64, max float64) (*image.RGBA, error) {
+	if len(bins) == 0 {
+		resolution := int(binning.MaxTileResolution)
+		return image.NewRGBA(image.Rect(0, 0, resolution, resolution)), nil
+	}
+	resolution := int(math.Sqrt(float64(len(bins))))
+	if resolution*resolution != len(bins) {
+		return nil, fmt.Errorf("cannot render %d bins into a square image", len(bins))
+	}
+	transfer := r.getTransfer(bins, min, max)
+	img := image.NewRGBA(image.Rect(0, 0, resolution, resolution))
+	for i, bin := range bins {
+		if bin == 0 {
+			continue
+		}
+		x := i % resolution
+		y := i / resolution
+		img.SetRGBA(x, resolution-1-y, r.Ramp.Color(transfer(float64(bin))))
+	}
+	return img, nil
+}
+
+// EncodePNG renders the bins into a square image and encodes it as a PNG.
+func (r *Render) EncodePNG(bins []float32, min float64, max float64) ([]byte, error) {
+	img, err := r.Image(bins, min, max)
+	if err != nil {
+		return nil, err
+	}
+	buffer := &bytes.Buffer{}
+	err = png.Encode(buffer, img)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// BinDecoder represents a tile whose output can be decoded into bins.
+type BinDecoder interface {
+	Decode(data []byte) ([]float32, error)
+}
+
+// DecodeBins decodes the output of a heatmap tile into its bins, given the
+// encoding and LOD of the tile. Bins are decoded as headerless little endian
+// float32 values if the encoding is empty, and the full resolution level is
+// returned if the LOD is set.
+func DecodeBins(data []byte, encoding string, lod int) ([]float32, error) {
+	if lod > 0 {
+		levels, err := DecodeHeatmapLOD(data, encoding)
+		if err != nil {
+			return nil, err
+		}
+		return levels[0], nil
+	}
+	return decodeBins(data, encoding)
+}
+
+func (r *Render) getTransfer(bins []float32, min float64, max float64) func(float64) float64 {
+	switch r.Transform {
+	case LogTransform:
+		return func(val float64) float64 {
+			if max <= min {
+				return 1
+			}
+			return math.Log1p(clamp(val, min, max)-min) / math.Log1p(max-min)
+		}
+	case EqualizeTransform:
+		// sort the clamped values of the non-empty bins
+		var sorted []float64
+		for _, bin := range bins {
+			if bin != 0 {
+				sorted = append(sorted, clamp(float64(bin), min, max))
+			}
+		}
+		sort.Float64s(sorted)
+		return func(val float64) float64 {
+			// the fraction of bins with a value at most this one
+			val = clamp(val, min, max)
+			rank := sort.Search(len(sorted), func(i int) bool {
+				return sorted[i] > val
+			})
+			return float64(rank) / float64(len(sorted))
+		}
+	}
+	return func(val float64) float64 {
+		if max <= min {
+			return 1
+		}
+		return (clamp(val, min, max) - min) / (max - min)
+	}
+}
+
+func clamp(val float64, min float64, max float64) float64 {
+	return math.Max(min, math.Min(max, val))
+}
@@ -0,0 +1,153 @@
+package tile_test
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"math"
+
+	"github.com/unchartedsoftware/veldt/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+var _ = Describe("Render", func() {
+
+	var render *tile.Render
+	var black = color.RGBA{R: 0, G: 0, B: 0, A: 255}
+	var white = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+
+	BeforeEach(func() {
+		render = &tile.Render{}
+	})
+
+	Describe("Parse", func() {
+		It("should default to a linear viridis ramp", func() {
+			err := render.Parse(JSON(`{}`))
+			Expect(err).To(BeNil())
+			Expect(render.Transform).To(Equal(tile.LinearTransform))
+			Expect(render.Ramp.Color(0)).To(Equal(color.RGBA{R: 68, G: 1, B: 84, A: 255}))
+			Expect(render.Min).To(BeNil())
+			Expect(render.Max).To(BeNil())
+		})
+		It("should return an error if the `transform` is not recognized", func() {
+			err := render.Parse(JSON(
+				`{
+					"transform": "sqrt"
+				}`))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Extrema", func() {
+		It("should take the range from the non-empty bins", func() {
+			err := render.Parse(JSON(`{}`))
+			Expect(err).To(BeNil())
+			min, max := render.Extrema([]float32{0, 4, 2, 8})
+			Expect(min).To(Equal(2.0))
+			Expect(max).To(Equal(8.0))
+		})
+		It("should prefer the explicit range", func() {
+			err := render.Parse(JSON(
+				`{
+					"max": 100
+				}`))
+			Expect(err).To(BeNil())
+			min, max := render.Extrema([]float32{0, 4, 2, 8})
+			Expect(min).To(Equal(2.0))
+			Expect(max).To(Equal(100.0))
+		})
+	})
+
+	Describe("Image", func() {
+		It("should render the first bin in the bottom-left corner", func() {
+			err := render.Parse(JSON(
+				`{
+					"ramp": "greyscale"
+				}`))
+			Expect(err).To(BeNil())
+			img, err := render.Image([]float32{1, 0, 0, 2}, 1, 2)
+			Expect(err).To(BeNil())
+			Expect(img.Bounds().Dx()).To(Equal(2))
+			Expect(img.RGBAAt(0, 1)).To(Equal(black))
+			Expect(img.RGBAAt(1, 0)).To(Equal(white))
+			Expect(img.RGBAAt(0, 0).A).To(Equal(uint8(0)))
+			Expect(img.RGBAAt(1, 1).A).To(Equal(uint8(0)))
+		})
+		It("should apply the log transform", func() {
+			err := render.Parse(JSON(
+				`{
+					"ramp": "greyscale",
+					"transform": "log"
+				}`))
+			Expect(err).To(BeNil())
+			img, err := render.Image([]float32{1, 10, 100, 1000}, 0, 1000)
+			Expect(err).To(BeNil())
+			// log1p(100) / log1p(1000) is roughly two thirds
+			Expect(img.RGBAAt(0, 0).R).To(BeNumerically("~", 170, 2))
+		})
+		It("should apply the equalize transform", func() {
+			err := render.Parse(JSON(
+				`{
+					"ramp": "greyscale",
+					"transform": "equalize"
+				}`))
+			Expect(err).To(BeNil())
+			img, err := render.Image([]float32{1, 2, 1000, 10000}, 1, 10000)
+			Expect(err).To(BeNil())
+			Expect(img.RGBAAt(0, 1).R).To(Equal(uint8(64)))
+			Expect(img.RGBAAt(1, 1).R).To(Equal(uint8(128)))
+			Expect(img.RGBAAt(0, 0).R).To(Equal(uint8(191)))
+			Expect(img.RGBAAt(1, 0)).To(Equal(white))
+		})
+		It("should return an error if the bins are not square", func() {
+			err := render.Parse(JSON(`{}`))
+			Expect(err).To(BeNil())
+			_, err = render.Image([]float32{1, 2, 3}, 0, 1)
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("EncodePNG", func() {
+		It("should encode the image as a PNG", func() {
+			err := render.Parse(JSON(`{}`))
+			Expect(err).To(BeNil())
+			data, err := render.EncodePNG(make([]float32, 16), 0, 1)
+			Expect(err).To(BeNil())
+			img, err := png.Decode(bytes.NewReader(data))
+			Expect(err).To(BeNil())
+			Expect(img.Bounds().Dx()).To(Equal(4))
+		})
+	})
+
+	Describe("DecodeBins", func() {
+		It("should decode headerless float32 and encoded bins", func() {
+			heatmap := &tile.Heatmap{}
+			legacy, err := heatmap.Encode([]float32{1, 2, 3, 4})
+			Expect(err).To(BeNil())
+			bins, err := tile.DecodeBins(legacy, "", 0)
+			Expect(err).To(BeNil())
+			Expect(bins).To(Equal([]float32{1, 2, 3, 4}))
+			encoded, err := tile.EncodeHeatmap([]float32{1, 2, 3, 4}, tile.Uint32Encoding)
+			Expect(err).To(BeNil())
+			bins, err = tile.DecodeBins(encoded, tile.Uint32Encoding, 0)
+			Expect(err).To(BeNil())
+			Expect(bins).To(Equal([]float32{1, 2, 3, 4}))
+		})
+
+		It("should not mistake headerless float32 bins for a LOD pyramid", func() {
+			// the bits of the first bin match a LOD pyramid header
+			bins := make([]float32, 16)
+			bins[0] = math.Float32frombits(0x3F010001)
+			heatmap := &tile.Heatmap{}
+			data, err := heatmap.Encode(bins)
+			Expect(err).To(BeNil())
+			decoded, err := heatmap.Decode(data)
+			Expect(err).To(BeNil())
+			Expect(decoded).To(Equal(bins))
+		})
+	})
+
+})
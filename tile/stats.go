@@ -0,0 +1,244 @@
+package tile
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+const (
+	// CountStatistic is the number of values of the field in each bin.
+	CountStatistic = "count"
+	// SumStatistic is the sum of the values of the field in each bin.
+	SumStatistic = "sum"
+	// MeanStatistic is the mean of the values of the field in each bin.
+	MeanStatistic = "mean"
+	// MinStatistic is the minimum of the values of the field in each bin.
+	MinStatistic = "min"
+	// MaxStatistic is the maximum of the values of the field in each bin.
+	MaxStatistic = "max"
+	// StdDevStatistic is the population standard deviation of the values of
+	// the field in each bin.
+	StdDevStatistic = "stddev"
+
+	statsVersion    = 1
+	statsHeaderSize = 8
+)
+
+var (
+	statsCodes = map[string]byte{
+		CountStatistic:  1,
+		SumStatistic:    2,
+		MeanStatistic:   3,
+		MinStatistic:    4,
+		MaxStatistic:    5,
+		StdDevStatistic: 6,
+	}
+	statsNames = map[byte]string{
+		1: CountStatistic,
+		2: SumStatistic,
+		3: MeanStatistic,
+		4: MinStatistic,
+		5: MaxStatistic,
+		6: StdDevStatistic,
+	}
+	defaultStatistics = []string{
+		CountStatistic,
+		SumStatistic,
+		MeanStatistic,
+		MinStatistic,
+		MaxStatistic,
+		StdDevStatistic,
+	}
+)
+
+// BinStats represents the statistics of the values of a single bin.
+type BinStats struct {
+	Count  uint64
+	Sum    float64
+	Mean   float64
+	Min    float64
+	Max    float64
+	StdDev float64
+}
+
+// Get returns the value of the provided statistic.
+func (b *BinStats) Get(statistic string) float64 {
+	switch statistic {
+	case SumStatistic:
+		return b.Sum
+	case MeanStatistic:
+		return b.Mean
+	case MinStatistic:
+		return b.Min
+	case MaxStatistic:
+		return b.Max
+	case StdDevStatistic:
+		return b.StdDev
+	}
+	return float64(b.Count)
+}
+
+// Merge combines the statistics of another set of values of the same bin.
+func (b *BinStats) Merge(other *BinStats) {
+	if other.Count == 0 {
+		return
+	}
+	if b.Count == 0 {
+		*b = *other
+		return
+	}
+	count := float64(b.Count + other.Count)
+	// combine the mean of the squares of each set to recover the variance
+	squares := (float64(b.Count)*(b.StdDev*b.StdDev+b.Mean*b.Mean) +
+		float64(other.Count)*(other.StdDev*other.StdDev+other.Mean*other.Mean)) / count
+	b.Count += other.Count
+	b.Sum += other.Sum
+	b.Mean = b.Sum / count
+	b.Min = math.Min(b.Min, other.Min)
+	b.Max = math.Max(b.Max, other.Max)
+	b.StdDev = math.Sqrt(math.Max(squares-b.Mean*b.Mean, 0))
+}
+
+// Stats represents a tile which computes multiple statistics of a value field
+// for each bin in a single pass, returning a channel of bins per statistic.
+type Stats struct {
+	ValueField string
+	Statistics []string
+}
+
+// Parse parses the provided JSON object and populates the structs attributes.
+func (s *Stats) Parse(params map[string]interface{}) error {
+	valueField, ok := json.GetString(params, "valueField")
+	if !ok {
+		return fmt.Errorf("`valueField` parameter missing from tile")
+	}
+	statistics := defaultStatistics
+	if json.Exists(params, "statistics") {
+		statistics, ok = json.GetStringArray(params, "statistics")
+		if !ok {
+			return fmt.Errorf("`statistics` parameter is not an array of strings")
+		}
+		if len(statistics) == 0 {
+			return fmt.Errorf("`statistics` parameter must not be empty")
+		}
+		seen := make(map[string]bool, len(statistics))
+		for _, statistic := range statistics {
+			if _, ok := statsCodes[statistic]; !ok {
+				return fmt.Errorf("`statistics` parameter `%s` is not recognized", statistic)
+			}
+			if seen[statistic] {
+				return fmt.Errorf("`statistics` parameter `%s` is duplicated", statistic)
+			}
+			seen[statistic] = true
+		}
+	}
+	s.ValueField = valueField
+	s.Statistics = statistics
+	return nil
+}
+
+// Params returns the parameters accepted by the tile.
+func (s *Stats) Params() []json.Param {
+	statistics := make([]interface{}, len(defaultStatistics))
+	for i, statistic := range defaultStatistics {
+		statistics[i] = statistic
+	}
+	return []json.Param{
+		{Key: "valueField", Type: json.TypeString, Required: true},
+		{
+			Key:     "statistics",
+			Type:    json.TypeArray,
+			Items:   json.TypeString,
+			Default: statistics,
+		},
+	}
+}
+
+// Encode will encode a channel of bins for each of the statistics, prefixed by
+// a header which describes them:
+//
+//     byte 0:       version
+//     byte 1:       number of channels
+//     bytes 2-3:    reserved
+//     bytes 4-7:    uint32 number of bins
+//     bytes 8-:     statistic of each channel, 1 = count, 2 = sum, 3 = mean,
+//                   4 = min, 5 = max, 6 = stddev, padded to 4 bytes
+//
+// The channels follow the header in order, each as the float32 value of every
+// bin. All values are little endian. The count and sum of empty bins are
+// encoded as zero, while their mean, min, max and stddev are NaN.
+func (s *Stats) Encode(bins []*BinStats) ([]byte, error) {
+	channels := len(s.Statistics)
+	offset := statsHeaderSize + statsCodesSize(channels)
+	bytes := make([]byte, offset+channels*len(bins)*4)
+	bytes[0] = statsVersion
+	bytes[1] = byte(channels)
+	binary.LittleEndian.PutUint32(bytes[4:8], uint32(len(bins)))
+	for i, statistic := range s.Statistics {
+		code, ok := statsCodes[statistic]
+		if !ok {
+			return nil, fmt.Errorf("unrecognized statistic `%s`", statistic)
+		}
+		bytes[statsHeaderSize+i] = code
+	}
+	for _, statistic := range s.Statistics {
+		for _, bin := range bins {
+			val := emptyStatistic(statistic)
+			if bin != nil && bin.Count > 0 {
+				val = bin.Get(statistic)
+			}
+			binary.LittleEndian.PutUint32(bytes[offset:offset+4], math.Float32bits(float32(val)))
+			offset += 4
+		}
+	}
+	return bytes, nil
+}
+
+// DecodeStats decodes the channels encoded by a stats tile, keyed by
+// statistic.
+func DecodeStats(bytes []byte) (map[string][]float32, error) {
+	if len(bytes) < statsHeaderSize {
+		return nil, fmt.Errorf("stats header is truncated")
+	}
+	if bytes[0] != statsVersion {
+		return nil, fmt.Errorf("unsupported stats version `%d`", bytes[0])
+	}
+	channels := int(bytes[1])
+	numBins := int(binary.LittleEndian.Uint32(bytes[4:8]))
+	offset := statsHeaderSize + statsCodesSize(channels)
+	if len(bytes) != offset+channels*numBins*4 {
+		return nil, fmt.Errorf("stats tile is %d bytes, expected %d", len(bytes), offset+channels*numBins*4)
+	}
+	stats := make(map[string][]float32, channels)
+	for i := 0; i < channels; i++ {
+		statistic, ok := statsNames[bytes[statsHeaderSize+i]]
+		if !ok {
+			return nil, fmt.Errorf("unrecognized statistic `%d`", bytes[statsHeaderSize+i])
+		}
+		bins := make([]float32, numBins)
+		for j := range bins {
+			bins[j] = math.Float32frombits(binary.LittleEndian.Uint32(bytes[offset : offset+4]))
+			offset += 4
+		}
+		stats[statistic] = bins
+	}
+	return stats, nil
+}
+
+// emptyStatistic returns the value of the provided statistic for a bin
+// without any values, as only the count and sum are defined.
+func emptyStatistic(statistic string) float64 {
+	if statistic == CountStatistic || statistic == SumStatistic {
+		return 0
+	}
+	return math.NaN()
+}
+
+// statsCodesSize returns the number of bytes of the channel statistics,
+// padded so that the channels are aligned to 4 bytes.
+func statsCodesSize(channels int) int {
+	return (channels + 3) / 4 * 4
+}
@@ -0,0 +1,156 @@
+package tile_test
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/unchartedsoftware/veldt/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+var _ = Describe("Stats", func() {
+
+	var stats *tile.Stats
+
+	BeforeEach(func() {
+		stats = &tile.Stats{}
+	})
+
+	Describe("Parse", func() {
+		It("should parse properties from the params argument", func() {
+			params := JSON(
+				`{
+					"valueField": "value",
+					"statistics": ["mean", "stddev"]
+				}`)
+			err := stats.Parse(params)
+			Expect(err).To(BeNil())
+			Expect(stats.ValueField).To(Equal("value"))
+			Expect(stats.Statistics).To(Equal([]string{"mean", "stddev"}))
+		})
+
+		It("should default to every statistic", func() {
+			err := stats.Parse(JSON(`{ "valueField": "value" }`))
+			Expect(err).To(BeNil())
+			Expect(stats.Statistics).To(Equal([]string{"count", "sum", "mean", "min", "max", "stddev"}))
+		})
+
+		It("should return an error if `valueField` property is not specified", func() {
+			err := stats.Parse(JSON(`{}`))
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should return an error if a statistic is not recognized", func() {
+			err := stats.Parse(JSON(`{ "valueField": "value", "statistics": ["median"] }`))
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should return an error if a statistic is duplicated", func() {
+			err := stats.Parse(JSON(`{ "valueField": "value", "statistics": ["sum", "sum"] }`))
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should return an error if `statistics` is empty", func() {
+			err := stats.Parse(JSON(`{ "valueField": "value", "statistics": [] }`))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Encode", func() {
+		It("should encode a header followed by a channel per statistic", func() {
+			err := stats.Parse(JSON(`{ "valueField": "value", "statistics": ["min", "max", "mean"] }`))
+			Expect(err).To(BeNil())
+			bins := []*tile.BinStats{
+				{Count: 2, Min: 1, Max: 3, Mean: 2},
+				nil,
+			}
+			data, err := stats.Encode(bins)
+			Expect(err).To(BeNil())
+			// header, statistics padded to 4 bytes, then 3 channels of 2 bins
+			Expect(data).To(HaveLen(8 + 4 + 3*2*4))
+			Expect(data[1]).To(Equal(byte(3)))
+			Expect(binary.LittleEndian.Uint32(data[4:8])).To(Equal(uint32(2)))
+			Expect(data[8:11]).To(Equal([]byte{4, 5, 3}))
+			values := make([]float32, 6)
+			for i := range values {
+				values[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[12+i*4:]))
+			}
+			Expect(values[0]).To(Equal(float32(1)))
+			Expect(values[2]).To(Equal(float32(3)))
+			Expect(values[4]).To(Equal(float32(2)))
+			Expect(math.IsNaN(float64(values[1]))).To(BeTrue())
+			Expect(math.IsNaN(float64(values[3]))).To(BeTrue())
+			Expect(math.IsNaN(float64(values[5]))).To(BeTrue())
+		})
+
+		It("should encode the count and sum of empty bins as zero", func() {
+			err := stats.Parse(JSON(`{ "valueField": "value", "statistics": ["count", "sum", "mean"] }`))
+			Expect(err).To(BeNil())
+			data, err := stats.Encode([]*tile.BinStats{nil, {}})
+			Expect(err).To(BeNil())
+			decoded, err := tile.DecodeStats(data)
+			Expect(err).To(BeNil())
+			Expect(decoded["count"]).To(Equal([]float32{0, 0}))
+			Expect(decoded["sum"]).To(Equal([]float32{0, 0}))
+			Expect(math.IsNaN(float64(decoded["mean"][0]))).To(BeTrue())
+			Expect(math.IsNaN(float64(decoded["mean"][1]))).To(BeTrue())
+		})
+	})
+
+	Describe("BinStats", func() {
+		It("should merge the statistics of two sets of values", func() {
+			// values 1, 3 and 5, 7, 9
+			bin := &tile.BinStats{Count: 2, Sum: 4, Mean: 2, Min: 1, Max: 3, StdDev: 1}
+			bin.Merge(&tile.BinStats{Count: 3, Sum: 21, Mean: 7, Min: 5, Max: 9, StdDev: math.Sqrt(8.0 / 3.0)})
+			Expect(bin.Count).To(Equal(uint64(5)))
+			Expect(bin.Sum).To(Equal(25.0))
+			Expect(bin.Mean).To(Equal(5.0))
+			Expect(bin.Min).To(Equal(1.0))
+			Expect(bin.Max).To(Equal(9.0))
+			Expect(bin.StdDev).To(BeNumerically("~", math.Sqrt(8), 1e-9))
+		})
+
+		It("should ignore the statistics of an empty set of values", func() {
+			bin := &tile.BinStats{}
+			bin.Merge(&tile.BinStats{Count: 1, Sum: 4, Mean: 4, Min: 4, Max: 4})
+			bin.Merge(&tile.BinStats{})
+			Expect(*bin).To(Equal(tile.BinStats{Count: 1, Sum: 4, Mean: 4, Min: 4, Max: 4}))
+		})
+	})
+
+	Describe("DecodeStats", func() {
+		It("should decode each channel by statistic", func() {
+			err := stats.Parse(JSON(`{ "valueField": "value" }`))
+			Expect(err).To(BeNil())
+			bins := []*tile.BinStats{
+				{Count: 4, Sum: 10, Mean: 2.5, Min: 1, Max: 4, StdDev: 1.25},
+			}
+			data, err := stats.Encode(bins)
+			Expect(err).To(BeNil())
+			decoded, err := tile.DecodeStats(data)
+			Expect(err).To(BeNil())
+			Expect(decoded).To(Equal(map[string][]float32{
+				"count":  {4},
+				"sum":    {10},
+				"mean":   {2.5},
+				"min":    {1},
+				"max":    {4},
+				"stddev": {1.25},
+			}))
+		})
+
+		It("should return an error if the channels are truncated", func() {
+			err := stats.Parse(JSON(`{ "valueField": "value" }`))
+			Expect(err).To(BeNil())
+			data, err := stats.Encode([]*tile.BinStats{{Count: 1}})
+			Expect(err).To(BeNil())
+			_, err = tile.DecodeStats(data[:len(data)-4])
+			Expect(err).NotTo(BeNil())
+			_, err = tile.DecodeStats(data[:4])
+			Expect(err).NotTo(BeNil())
+		})
+	})
+})
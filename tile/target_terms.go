@@ -27,3 +27,11 @@ func (t *TargetTerms) Parse(params map[string]interface{}) error {
 	t.Terms = terms
 	return nil
 }
+
+// Params returns the parameters accepted by the tile.
+func (t *TargetTerms) Params() []json.Param {
+	return []json.Param{
+		{Key: "termsField", Type: json.TypeString, Required: true},
+		{Key: "terms", Type: json.TypeArray, Items: json.TypeString, Required: true},
+	}
+}
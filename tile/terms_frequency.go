@@ -1,9 +1,9 @@
 package tile
 
 import (
-"fmt"
+	"fmt"
 
-"github.com/unchartedsoftware/veldt/util/json"
+	"github.com/unchartedsoftware/veldt/util/json"
 )
 
 // TermsFrequency represents a tile which returns counts for each of the terms in a provided field.
@@ -28,3 +28,11 @@ func (t *TermsFrequency) Parse(params map[string]interface{}) error {
 	t.TermsField = termsField
 	return nil
 }
+
+// Params returns the parameters accepted by the tile.
+func (t *TermsFrequency) Params() []json.Param {
+	return []json.Param{
+		{Key: "termsField", Type: json.TypeString, Required: true},
+		{Key: "fieldType", Type: json.TypeString},
+	}
+}
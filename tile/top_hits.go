@@ -36,3 +36,13 @@ func (t *TopHits) Parse(params map[string]interface{}) error {
 	t.IncludeFields = includeFields
 	return nil
 }
+
+// Params returns the parameters accepted by the tile.
+func (t *TopHits) Params() []json.Param {
+	return []json.Param{
+		{Key: "sortField", Type: json.TypeString, Default: ""},
+		{Key: "sortOrder", Type: json.TypeString, Default: "desc", Enum: []interface{}{"desc", "asc"}},
+		{Key: "hitsCount", Type: json.TypeInteger, Required: true},
+		{Key: "includeFields", Type: json.TypeArray, Items: json.TypeString},
+	}
+}
@@ -34,3 +34,12 @@ func (t *TopTerms) Parse(params map[string]interface{}) error {
 	t.TermsCount = termsCount
 	return nil
 }
+
+// Params returns the parameters accepted by the tile.
+func (t *TopTerms) Params() []json.Param {
+	return []json.Param{
+		{Key: "termsField", Type: json.TypeString, Required: true},
+		{Key: "termsCount", Type: json.TypeInteger, Required: true},
+		{Key: "fieldType", Type: json.TypeString},
+	}
+}
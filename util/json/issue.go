@@ -0,0 +1,114 @@
+package json
+
+import (
+	"strings"
+)
+
+const (
+	// Missing is the placeholder buffered in place of a missing key or value.
+	Missing = "???"
+	// CodeInvalid indicates a value is invalid for an unspecified reason.
+	CodeInvalid = "invalid"
+	// CodeMissing indicates a required value is missing.
+	CodeMissing = "missing"
+	// CodeType indicates a value is not of the expected type.
+	CodeType = "invalid_type"
+	// CodeEnum indicates a value is not one of the accepted values.
+	CodeEnum = "invalid_enum"
+	// CodeUnrecognized indicates a type or symbol is not recognized.
+	CodeUnrecognized = "unrecognized"
+	// CodeUnexpectedToken indicates a token is not valid in its position.
+	CodeUnexpectedToken = "unexpected_token"
+)
+
+// Issue represents a single problem found while validating a JSON value.
+type Issue struct {
+	// Path is the JSON pointer (RFC 6901) to the offending value.
+	Path string `json:"path"`
+	// Code is a machine readable identifier of the problem.
+	Code string `json:"code"`
+	// Message is a human readable description of the problem.
+	Message string `json:"message"`
+	// Value is the offending value, nil if it is missing.
+	Value interface{} `json:"value,omitempty"`
+}
+
+// CodedError represents an error with a machine readable code. The optional
+// path and value locate the offending value relative to the value the error is
+// buffered against.
+type CodedError struct {
+	Code    string
+	Message string
+	Path    []string
+	Value   interface{}
+}
+
+// Error returns the message of the error.
+func (e *CodedError) Error() string {
+	return e.Message
+}
+
+// CodedErrors represents several coded errors found within a single value.
+type CodedErrors []*CodedError
+
+// Error returns the messages of the errors.
+func (e CodedErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Message
+	}
+	return strings.Join(msgs, ", ")
+}
+
+// Pointer returns the JSON pointer (RFC 6901) for the provided keys.
+func Pointer(keys ...string) string {
+	var path string
+	for _, key := range keys {
+		path += "/" + escapeKey(key)
+	}
+	return path
+}
+
+func escapeKey(key string) string {
+	key = strings.Replace(key, "~", "~0", -1)
+	return strings.Replace(key, "/", "~1", -1)
+}
+
+// NewIssue returns the issue for an error buffered against the value at the
+// provided JSON pointer. If the error is a *CodedError, its code, path and
+// value are used.
+func NewIssue(path string, err error, val interface{}) Issue {
+	if val == Missing {
+		val = nil
+	}
+	issue := Issue{
+		Path:    path,
+		Code:    CodeInvalid,
+		Message: err.Error(),
+		Value:   val,
+	}
+	coded, ok := err.(*CodedError)
+	if ok {
+		issue.Code = coded.Code
+		if len(coded.Path) > 0 {
+			issue.Path += Pointer(coded.Path...)
+			issue.Value = coded.Value
+		}
+	}
+	return issue
+}
+
+// NewIssues returns the issues for an error buffered against the value at the
+// provided JSON pointer. If the error is CodedErrors, an issue is returned for
+// each of its errors, otherwise a single issue is returned as by NewIssue.
+func NewIssues(path string, err error, val interface{}) []Issue {
+	coded, ok := err.(CodedErrors)
+	if !ok {
+		return []Issue{NewIssue(path, err, val)}
+	}
+	issues := make([]Issue, len(coded))
+	for i, err := range coded {
+		issues[i] = NewIssue(path, err, val)
+	}
+	return issues
+}
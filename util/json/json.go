@@ -307,6 +307,16 @@ func UnmarshalArray(data []byte) ([]map[string]interface{}, error) {
 	return arr, nil
 }
 
+// UnmarshalValue unmarshals any JSON value and returns it.
+func UnmarshalValue(data []byte) (interface{}, error) {
+	var v interface{}
+	err := json.Unmarshal(data, &v)
+	if nil != err {
+		return nil, err
+	}
+	return v, nil
+}
+
 // Copy will copy the JSON data deeply by value, this process involves
 // marshalling and then unmarshalling the data.
 func Copy(j map[string]interface{}) (map[string]interface{}, error) {
@@ -623,4 +623,19 @@ var _ = Describe("json", func() {
 		})
 	})
 
+	Describe("UnmarshalValue", func() {
+		It("should unmarshal any JSON value", func() {
+			val, err := json.UnmarshalValue([]byte(`[1, "a", true]`))
+			Expect(err).To(BeNil())
+			Expect(val).To(Equal([]interface{}{1.0, "a", true}))
+			val, err = json.UnmarshalValue([]byte(`"a"`))
+			Expect(err).To(BeNil())
+			Expect(val).To(Equal("a"))
+		})
+		It("should return an error for invalid JSON", func() {
+			_, err := json.UnmarshalValue([]byte(`{`))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
 })
@@ -0,0 +1,212 @@
+package json
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+const (
+	// TypeString represents a JSON string parameter.
+	TypeString = "string"
+	// TypeNumber represents a JSON number parameter.
+	TypeNumber = "number"
+	// TypeInteger represents a JSON number parameter without a fractional
+	// component.
+	TypeInteger = "integer"
+	// TypeBoolean represents a JSON boolean parameter.
+	TypeBoolean = "boolean"
+	// TypeArray represents a JSON array parameter.
+	TypeArray = "array"
+	// TypeObject represents a JSON object parameter.
+	TypeObject = "object"
+	// TypeAny represents a parameter that accepts any JSON value.
+	TypeAny = ""
+)
+
+// Param describes a single parameter of a JSON object.
+type Param struct {
+	// Key is the name of the parameter in the JSON object.
+	Key string
+	// Type is the JSON type of the parameter, TypeAny if unconstrained.
+	Type string
+	// Items is the JSON type of each element if the parameter is an array.
+	Items string
+	// Required indicates that the parameter must be provided.
+	Required bool
+	// Default is the value used if the parameter is not provided.
+	Default interface{}
+	// Enum lists the accepted values of the parameter, if constrained.
+	Enum []interface{}
+}
+
+// Schema returns the JSON schema representation of the parameter.
+func (p Param) Schema() map[string]interface{} {
+	schema := make(map[string]interface{})
+	if p.Type != TypeAny {
+		schema["type"] = p.Type
+	}
+	if p.Type == TypeArray && p.Items != TypeAny {
+		schema["items"] = map[string]interface{}{
+			"type": p.Items,
+		}
+	}
+	if p.Default != nil {
+		schema["default"] = p.Default
+	}
+	if len(p.Enum) > 0 {
+		schema["enum"] = p.Enum
+	}
+	return schema
+}
+
+// ObjectSchema returns the JSON schema representation of an object containing
+// the provided parameters.
+func ObjectSchema(params []Param) map[string]interface{} {
+	properties := make(map[string]interface{}, len(params))
+	var required []interface{}
+	for _, param := range params {
+		properties[param.Key] = param.Schema()
+		if param.Required {
+			required = append(required, param.Key)
+		}
+	}
+	schema := map[string]interface{}{
+		"type":       TypeObject,
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// ValidateParams checks that all required parameters exist in the provided
+// JSON object and that each present parameter is of the described type. The
+// returned error is a *CodedError locating the offending parameter, or
+// CodedErrors locating each of them if several parameters are invalid.
+func ValidateParams(json map[string]interface{}, params []Param) error {
+	var errs CodedErrors
+	for _, param := range params {
+		val, ok := json[param.Key]
+		if !ok {
+			if param.Required {
+				errs = append(errs, &CodedError{
+					Code:    CodeMissing,
+					Message: fmt.Sprintf("`%s` parameter missing", param.Key),
+					Path:    []string{param.Key},
+				})
+			}
+			continue
+		}
+		if !isType(val, param.Type) {
+			errs = append(errs, &CodedError{
+				Code: CodeType,
+				Message: fmt.Sprintf("`%s` parameter is not of type `%s`",
+					param.Key,
+					param.Type),
+				Path:  []string{param.Key},
+				Value: val,
+			})
+			continue
+		}
+		if param.Type == TypeArray && param.Items != TypeAny {
+			arr := val.([]interface{})
+			for i, item := range arr {
+				if !isType(item, param.Items) {
+					errs = append(errs, &CodedError{
+						Code: CodeType,
+						Message: fmt.Sprintf("`%s` parameter is not an array of type `%s`",
+							param.Key,
+							param.Items),
+						Path:  []string{param.Key, strconv.Itoa(i)},
+						Value: item,
+					})
+				}
+			}
+		}
+		if len(param.Enum) > 0 && !isEnum(val, param.Enum) {
+			errs = append(errs, &CodedError{
+				Code: CodeEnum,
+				Message: fmt.Sprintf("`%s` parameter must be one of %v",
+					param.Key,
+					param.Enum),
+				Path:  []string{param.Key},
+				Value: val,
+			})
+		}
+	}
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	return errs
+}
+
+func isType(val interface{}, typ string) bool {
+	switch typ {
+	case TypeString:
+		_, ok := val.(string)
+		return ok
+	case TypeNumber:
+		_, ok := val.(float64)
+		return ok
+	case TypeInteger:
+		num, ok := val.(float64)
+		return ok && num == math.Trunc(num)
+	case TypeBoolean:
+		_, ok := val.(bool)
+		return ok
+	case TypeArray:
+		_, ok := val.([]interface{})
+		return ok
+	case TypeObject:
+		_, ok := val.(map[string]interface{})
+		return ok
+	}
+	return true
+}
+
+func isEnum(val interface{}, enum []interface{}) bool {
+	for _, e := range enum {
+		if val == e {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeParams concatenates the provided parameter lists. If a key is described
+// more than once, the first description is kept.
+func MergeParams(lists ...[]Param) []Param {
+	var merged []Param
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, param := range list {
+			if seen[param.Key] {
+				continue
+			}
+			seen[param.Key] = true
+			merged = append(merged, param)
+		}
+	}
+	return merged
+}
+
+// OmitParams returns the parameters without the descriptions of the provided
+// keys.
+func OmitParams(params []Param, keys ...string) []Param {
+	omit := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		omit[key] = true
+	}
+	var res []Param
+	for _, param := range params {
+		if !omit[param.Key] {
+			res = append(res, param)
+		}
+	}
+	return res
+}
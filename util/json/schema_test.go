@@ -0,0 +1,142 @@
+package json_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/unchartedsoftware/veldt/util/test"
+
+	"github.com/unchartedsoftware/veldt/util/json"
+)
+
+var _ = Describe("schema", func() {
+
+	var params []json.Param
+
+	BeforeEach(func() {
+		params = []json.Param{
+			{Key: "field", Type: json.TypeString, Required: true},
+			{Key: "count", Type: json.TypeInteger, Default: 10},
+			{Key: "order", Type: json.TypeString, Enum: []interface{}{"asc", "desc"}},
+			{Key: "fields", Type: json.TypeArray, Items: json.TypeString},
+			{Key: "value", Type: json.TypeAny},
+		}
+	})
+
+	Describe("ValidateParams", func() {
+		It("should return nil if all parameters are valid", func() {
+			j := JSON(
+				`{
+					"field": "a",
+					"count": 5,
+					"order": "asc",
+					"fields": ["a", "b"],
+					"value": {}
+				}`)
+			Expect(json.ValidateParams(j, params)).To(BeNil())
+		})
+		It("should return an error if a required parameter is missing", func() {
+			j := JSON(`{}`)
+			err := json.ValidateParams(j, params)
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("`field`"))
+			Expect(err.(*json.CodedError).Code).To(Equal(json.CodeMissing))
+		})
+		It("should return an error if a parameter is of the wrong type", func() {
+			j := JSON(
+				`{
+					"field": 5
+				}`)
+			Expect(json.ValidateParams(j, params)).NotTo(BeNil())
+		})
+		It("should return an error if an integer parameter has a fractional component", func() {
+			j := JSON(
+				`{
+					"field": "a",
+					"count": 5.5
+				}`)
+			Expect(json.ValidateParams(j, params)).NotTo(BeNil())
+		})
+		It("should return an error if an array parameter contains items of the wrong type", func() {
+			j := JSON(
+				`{
+					"field": "a",
+					"fields": ["a", 5]
+				}`)
+			err := json.ValidateParams(j, params)
+			Expect(err).NotTo(BeNil())
+			Expect(err.(*json.CodedError).Path).To(Equal([]string{"fields", "1"}))
+			Expect(err.(*json.CodedError).Value).To(Equal(float64(5)))
+		})
+		It("should return an error locating each invalid parameter", func() {
+			j := JSON(
+				`{
+					"count": "5",
+					"fields": [1, "b", 3]
+				}`)
+			err := json.ValidateParams(j, params)
+			Expect(err).NotTo(BeNil())
+			errs, ok := err.(json.CodedErrors)
+			Expect(ok).To(BeTrue())
+			paths := make([][]string, len(errs))
+			for i, err := range errs {
+				paths[i] = err.Path
+			}
+			Expect(paths).To(ConsistOf(
+				[]string{"field"},
+				[]string{"count"},
+				[]string{"fields", "0"},
+				[]string{"fields", "2"},
+			))
+		})
+		It("should return an error if a parameter is not one of the enumerated values", func() {
+			j := JSON(
+				`{
+					"field": "a",
+					"order": "random"
+				}`)
+			Expect(json.ValidateParams(j, params)).NotTo(BeNil())
+		})
+	})
+
+	Describe("ObjectSchema", func() {
+		It("should return a JSON schema describing the parameters", func() {
+			schema := json.ObjectSchema(params)
+			Expect(schema["type"]).To(Equal("object"))
+			Expect(schema["required"]).To(Equal([]interface{}{"field"}))
+			props := schema["properties"].(map[string]interface{})
+			Expect(props["count"]).To(Equal(map[string]interface{}{
+				"type":    "integer",
+				"default": 10,
+			}))
+			Expect(props["fields"]).To(Equal(map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type": "string",
+				},
+			}))
+			Expect(props["value"]).To(Equal(map[string]interface{}{}))
+		})
+	})
+
+	Describe("MergeParams", func() {
+		It("should concatenate parameters, keeping the first description of a key", func() {
+			merged := json.MergeParams(params, []json.Param{
+				{Key: "field", Type: json.TypeNumber},
+				{Key: "other", Type: json.TypeNumber},
+			})
+			Expect(len(merged)).To(Equal(6))
+			Expect(merged[0].Type).To(Equal(json.TypeString))
+			Expect(merged[5].Key).To(Equal("other"))
+		})
+	})
+
+	Describe("OmitParams", func() {
+		It("should remove the descriptions of the keys", func() {
+			omitted := json.OmitParams(params, "field")
+			Expect(len(omitted)).To(Equal(len(params) - 1))
+			for _, param := range omitted {
+				Expect(param.Key).NotTo(Equal("field"))
+			}
+		})
+	})
+})
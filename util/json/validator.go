@@ -1,7 +1,9 @@
 package json
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/unchartedsoftware/veldt/util/color"
@@ -25,16 +27,27 @@ type Validator struct {
 	errFooterIndex  int
 	errMsg          string
 	err             bool
+	frames          []frame
+	issues          []Issue
+}
+
+// frame tracks the JSON pointer of an object or array being buffered.
+type frame struct {
+	path  string
+	array bool
+	index int
 }
 
 // StartObject begins the buffering of an object.
 func (v *Validator) StartObject() {
+	v.push(v.next(""), false)
 	v.buffer("{")
 	v.nextIndentation++
 }
 
 // StartSubObject begins the buffering of a nested object to a key.
 func (v *Validator) StartSubObject(key string) {
+	v.push(v.next(key), false)
 	v.buffer(fmt.Sprintf(`"%s": {`, key))
 	v.nextIndentation++
 }
@@ -44,17 +57,20 @@ func (v *Validator) EndObject() {
 	if v.nextIndentation > 0 {
 		v.nextIndentation--
 	}
+	v.pop()
 	v.buffer("}")
 }
 
 // StartArray begins the buffering of an array.
 func (v *Validator) StartArray() {
+	v.push(v.next(""), true)
 	v.buffer("[")
 	v.nextIndentation++
 }
 
 // StartSubArray begins the buffering of an array value to a key.
 func (v *Validator) StartSubArray(key string) {
+	v.push(v.next(key), true)
 	v.buffer(fmt.Sprintf(`"%s": [`, key))
 	v.nextIndentation++
 }
@@ -64,6 +80,7 @@ func (v *Validator) EndArray() {
 	if v.nextIndentation > 0 {
 		v.nextIndentation--
 	}
+	v.pop()
 	v.buffer("]")
 }
 
@@ -80,11 +97,16 @@ func (v *Validator) HasError() bool {
 // Error returns the error if there is one.
 func (v *Validator) Error() error {
 	if v.err {
-		return fmt.Errorf(v.String())
+		return errors.New(v.String())
 	}
 	return nil
 }
 
+// Issues returns every issue encountered, in the order they were buffered.
+func (v *Validator) Issues() []Issue {
+	return v.issues
+}
+
 // String returns the string in the output buffer.
 func (v *Validator) String() string {
 	length := v.Size()
@@ -130,6 +152,21 @@ func (v *Validator) String() string {
 
 // StartError begins wrapping an error portion of the output buffer.
 func (v *Validator) StartError(msg string) {
+	v.StartCodedError(CodeInvalid, msg, nil)
+}
+
+// StartCodedError begins wrapping an error portion of the output buffer and
+// records an issue with the provided code for the next buffered value.
+func (v *Validator) StartCodedError(code string, msg string, val interface{}) {
+	err := &CodedError{
+		Code:    code,
+		Message: msg,
+	}
+	v.issues = append(v.issues, NewIssue(v.peek(""), err, val))
+	v.startError(msg)
+}
+
+func (v *Validator) startError(msg string) {
 	v.err = true
 	v.errHeaderIndex = v.Size()
 	v.errStartIndex = v.Size() + 1
@@ -216,6 +253,7 @@ func (v *Validator) bufferKeyValue(key string, val interface{}) {
 	// string
 	str, ok := val.(string)
 	if ok {
+		v.next(key)
 		v.buffer(fmt.Sprintf(`"%s": "%s"`, key, str))
 		return
 	}
@@ -243,6 +281,7 @@ func (v *Validator) bufferKeyValue(key string, val interface{}) {
 	}
 
 	// other
+	v.next(key)
 	v.buffer(fmt.Sprintf(`"%s": %v`, key, val))
 }
 
@@ -251,7 +290,8 @@ func (v *Validator) bufferKeyValue(key string, val interface{}) {
 func (v *Validator) BufferKeyValue(key string, val interface{}, err error) {
 	// if error, start
 	if err != nil {
-		v.StartError(fmt.Sprintf("%v", err))
+		v.issues = append(v.issues, NewIssues(v.peek(key), err, val)...)
+		v.startError(fmt.Sprintf("%v", err))
 	}
 	// buffer key / val
 	v.bufferKeyValue(key, val)
@@ -265,6 +305,7 @@ func (v *Validator) bufferValue(val interface{}) {
 	// string
 	str, ok := val.(string)
 	if ok {
+		v.next("")
 		v.buffer(fmt.Sprintf(`"%s"`, str))
 		return
 	}
@@ -292,6 +333,7 @@ func (v *Validator) bufferValue(val interface{}) {
 	}
 
 	// other
+	v.next("")
 	v.buffer(fmt.Sprintf("%v", val))
 }
 
@@ -299,7 +341,8 @@ func (v *Validator) bufferValue(val interface{}) {
 func (v *Validator) BufferValue(val interface{}, err error) {
 	// if error, start
 	if err != nil {
-		v.StartError(fmt.Sprintf("%v", err))
+		v.issues = append(v.issues, NewIssues(v.peek(""), err, val)...)
+		v.startError(fmt.Sprintf("%v", err))
 	}
 	// buffer val
 	v.bufferValue(val)
@@ -314,3 +357,43 @@ func (v *Validator) buffer(str string) {
 	v.output = append(v.output, line)
 	v.indentation = append(v.indentation, v.nextIndentation)
 }
+
+// peek returns the JSON pointer of the next value buffered to the provided
+// key, or to the next index if the current value is an array.
+func (v *Validator) peek(key string) string {
+	var path string
+	if len(v.frames) > 0 {
+		top := v.frames[len(v.frames)-1]
+		path = top.path
+		if top.array {
+			path += Pointer(strconv.Itoa(top.index))
+		}
+	}
+	if key != "" && key != Missing {
+		path += Pointer(key)
+	}
+	return path
+}
+
+// next returns the JSON pointer of the next value and advances the index of
+// the current array.
+func (v *Validator) next(key string) string {
+	path := v.peek(key)
+	if len(v.frames) > 0 && v.frames[len(v.frames)-1].array {
+		v.frames[len(v.frames)-1].index++
+	}
+	return path
+}
+
+func (v *Validator) push(path string, array bool) {
+	v.frames = append(v.frames, frame{
+		path:  path,
+		array: array,
+	})
+}
+
+func (v *Validator) pop() {
+	if len(v.frames) > 0 {
+		v.frames = v.frames[:len(v.frames)-1]
+	}
+}
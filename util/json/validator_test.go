@@ -338,4 +338,50 @@ var _ = Describe("validator", func() {
 		})
 	})
 
+	Describe("Issues", func() {
+		It("should record the JSON pointer, code, message and value of each error", func() {
+			validator.StartObject()
+			validator.BufferKeyValue("a", "b", nil)
+			validator.StartSubArray("c")
+			validator.BufferValue("d", nil)
+			validator.BufferValue("e", &json.CodedError{
+				Code:    json.CodeUnrecognized,
+				Message: "unrecognized",
+			})
+			validator.EndArray()
+			validator.StartSubObject("f/g")
+			validator.BufferKeyValue("h", JSON(`{"i": "j"}`), &json.CodedError{
+				Code:    json.CodeType,
+				Message: "wrong type",
+				Path:    []string{"i"},
+				Value:   "j",
+			})
+			validator.EndObject()
+			validator.BufferKeyValue("k", json.Missing, fmt.Errorf("error"))
+			validator.EndObject()
+			Expect(validator.Issues()).To(Equal([]json.Issue{
+				{Path: "/c/1", Code: json.CodeUnrecognized, Message: "unrecognized", Value: "e"},
+				{Path: "/f~1g/h/i", Code: json.CodeType, Message: "wrong type", Value: "j"},
+				{Path: "/k", Code: json.CodeInvalid, Message: "error", Value: nil},
+			}))
+		})
+		It("should record the index of values wrapped by an error", func() {
+			validator.StartArray()
+			validator.BufferValue("a", nil)
+			validator.StartCodedError(json.CodeUnexpectedToken, "unexpected token", "b")
+			validator.BufferValue("b", nil)
+			validator.EndError()
+			validator.EndArray()
+			Expect(validator.Issues()).To(Equal([]json.Issue{
+				{Path: "/1", Code: json.CodeUnexpectedToken, Message: "unexpected token", Value: "b"},
+			}))
+		})
+		It("should return no issues if the validator did not processes any errors", func() {
+			validator.StartObject()
+			validator.BufferKeyValue("a", "b", nil)
+			validator.EndObject()
+			Expect(validator.Issues()).To(BeEmpty())
+		})
+	})
+
 })
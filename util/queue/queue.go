@@ -14,6 +14,8 @@ type Request interface {
 // Queue represents a queue for orchestating concurrent requests.
 type Queue struct {
 	ready      chan bool
+	done       chan bool
+	closed     bool
 	pending    int
 	mu         *sync.Mutex
 	maxPending int
@@ -24,6 +26,7 @@ type Queue struct {
 func NewQueue() *Queue {
 	q := &Queue{
 		ready:      make(chan bool),
+		done:       make(chan bool),
 		mu:         &sync.Mutex{},
 		maxPending: 32,
 		maxLength:  256 * 8,
@@ -34,7 +37,7 @@ func NewQueue() *Queue {
 	go func() {
 		// send as many ready messages as there are expected listeners
 		for i := 0; i < currentMax; i++ {
-			q.ready <- true
+			q.signalReady()
 		}
 	}()
 	return q
@@ -48,14 +51,19 @@ func (q *Queue) Send(req Request) ([]byte, error) {
 		return nil, err
 	}
 	// wait until equalizer is ready
-	<-q.ready
+	select {
+	case <-q.ready:
+	case <-q.done:
+		q.decrementPending()
+		return nil, fmt.Errorf("queue is closed and is no longer accepting requests")
+	}
 	// dispatch the query
 	res, err := req.Create()
 	// decrement the q.pending count
 	q.decrementPending()
 	go func() {
 		// inform Queue that it is ready to generate another tile
-		q.ready <- true
+		q.signalReady()
 	}()
 	return res, err
 }
@@ -71,7 +79,7 @@ func (q *Queue) SetMaxConcurrent(max int) {
 		go func() {
 			// send as many ready messages as there are expected listeners
 			for i := 0; i < diff; i++ {
-				q.ready <- true
+				q.signalReady()
 			}
 		}()
 	} else {
@@ -79,13 +87,24 @@ func (q *Queue) SetMaxConcurrent(max int) {
 		go func() {
 			// send as many ready messages as there are expected listeners
 			for i := diff; i < 0; i++ {
-				<-q.ready
+				select {
+				case <-q.ready:
+				case <-q.done:
+					return
+				}
 			}
 		}()
 	}
 	runtime.Gosched()
 }
 
+// MaxConcurrent returns the maximum concurrent pending requests for the queue.
+func (q *Queue) MaxConcurrent() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.maxPending
+}
+
 // SetLength sets the maximum length of the queue.
 func (q *Queue) SetLength(length int) {
 	q.mu.Lock()
@@ -94,10 +113,40 @@ func (q *Queue) SetLength(length int) {
 	runtime.Gosched()
 }
 
+// Length returns the maximum length of the queue.
+func (q *Queue) Length() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.maxLength
+}
+
+// Close stops the queue from accepting new requests. Requests that are
+// already dispatched are unaffected, while requests waiting for availability
+// return an error.
+func (q *Queue) Close() {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.done)
+	}
+	q.mu.Unlock()
+	runtime.Gosched()
+}
+
+func (q *Queue) signalReady() {
+	select {
+	case q.ready <- true:
+	case <-q.done:
+	}
+}
+
 func (q *Queue) incrementPending() error {
 	q.mu.Lock()
 	defer runtime.Gosched()
 	defer q.mu.Unlock()
+	if q.closed {
+		return fmt.Errorf("queue is closed and is no longer accepting requests")
+	}
 	if q.pending-q.maxPending > q.maxLength {
 		return fmt.Errorf("queue has reached maximum length of %d and is no longer accepting requests",
 			q.maxLength)
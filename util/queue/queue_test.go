@@ -172,4 +172,32 @@ var _ = Describe("Queue", func() {
 		})
 
 	})
+
+	Describe("MaxConcurrent", func() {
+
+		It("should return the maximum number of concurrent requests", func() {
+			q.SetMaxConcurrent(8)
+			Expect(q.MaxConcurrent()).To(Equal(8))
+		})
+
+	})
+
+	Describe("Length", func() {
+
+		It("should return the maximum queue length", func() {
+			q.SetLength(64)
+			Expect(q.Length()).To(Equal(64))
+		})
+
+	})
+
+	Describe("Close", func() {
+
+		It("should return an error for requests sent after closing", func() {
+			q.Close()
+			_, err := q.Send(newTestRequest())
+			Expect(err).NotTo(BeNil())
+		})
+
+	})
 })
@@ -0,0 +1,185 @@
+package test
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"unicode"
+
+	"github.com/onsi/gomega"
+)
+
+var (
+	evalFuncs = map[string]func(...float64) float64{
+		"floor":   func(args ...float64) float64 { return math.Floor(args[0]) },
+		"ln":      func(args ...float64) float64 { return math.Log(args[0]) },
+		"tan":     func(args ...float64) float64 { return math.Tan(args[0]) },
+		"cos":     func(args ...float64) float64 { return math.Cos(args[0]) },
+		"radians": func(args ...float64) float64 { return args[0] * math.Pi / 180 },
+		"pi":      func(args ...float64) float64 { return math.Pi },
+		"min":     func(args ...float64) float64 { return math.Min(args[0], args[1]) },
+		"max":     func(args ...float64) float64 { return math.Max(args[0], args[1]) },
+	}
+)
+
+// Eval evaluates the provided arithmetic expression, such as the scripts and
+// SQL expressions generated by the backends, with the provided variables and
+// applies an assert. Only the floor, ln, tan, cos, radians, pi, min and max
+// functions are supported.
+// NOTE: for use only in unit tests.
+func Eval(expr string, vars map[string]float64) float64 {
+	e := &evaluator{
+		tokens: tokenize(expr),
+		vars:   vars,
+	}
+	val, err := e.expr()
+	if err == nil && e.pos < len(e.tokens) {
+		err = fmt.Errorf("unexpected token `%s`", e.tokens[e.pos])
+	}
+	gomega.Expect(err).To(gomega.BeNil())
+	return val
+}
+
+func tokenize(expr string) []string {
+	var tokens []string
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j
+		case unicode.IsLetter(r) || r == '_' || r == '$':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || runes[j] == '$') {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j
+		default:
+			tokens = append(tokens, string(r))
+			i++
+		}
+	}
+	return tokens
+}
+
+type evaluator struct {
+	tokens []string
+	pos    int
+	vars   map[string]float64
+}
+
+func (e *evaluator) peek() string {
+	if e.pos < len(e.tokens) {
+		return e.tokens[e.pos]
+	}
+	return ""
+}
+
+func (e *evaluator) next() string {
+	token := e.peek()
+	e.pos++
+	return token
+}
+
+func (e *evaluator) expect(token string) error {
+	if next := e.next(); next != token {
+		return fmt.Errorf("expected `%s` but found `%s`", token, next)
+	}
+	return nil
+}
+
+func (e *evaluator) expr() (float64, error) {
+	val, err := e.term()
+	if err != nil {
+		return 0, err
+	}
+	for e.peek() == "+" || e.peek() == "-" {
+		op := e.next()
+		rhs, err := e.term()
+		if err != nil {
+			return 0, err
+		}
+		if op == "+" {
+			val += rhs
+		} else {
+			val -= rhs
+		}
+	}
+	return val, nil
+}
+
+func (e *evaluator) term() (float64, error) {
+	val, err := e.unary()
+	if err != nil {
+		return 0, err
+	}
+	for e.peek() == "*" || e.peek() == "/" {
+		op := e.next()
+		rhs, err := e.unary()
+		if err != nil {
+			return 0, err
+		}
+		if op == "*" {
+			val *= rhs
+		} else {
+			val /= rhs
+		}
+	}
+	return val, nil
+}
+
+func (e *evaluator) unary() (float64, error) {
+	if e.peek() == "-" {
+		e.next()
+		val, err := e.unary()
+		return -val, err
+	}
+	return e.primary()
+}
+
+func (e *evaluator) primary() (float64, error) {
+	token := e.next()
+	if token == "(" {
+		val, err := e.expr()
+		if err != nil {
+			return 0, err
+		}
+		return val, e.expect(")")
+	}
+	if val, err := strconv.ParseFloat(token, 64); err == nil {
+		return val, nil
+	}
+	if e.peek() == "(" {
+		fn, ok := evalFuncs[token]
+		if !ok {
+			return 0, fmt.Errorf("unrecognized function `%s`", token)
+		}
+		e.next()
+		var args []float64
+		for e.peek() != ")" {
+			arg, err := e.expr()
+			if err != nil {
+				return 0, err
+			}
+			args = append(args, arg)
+			if e.peek() == "," {
+				e.next()
+			}
+		}
+		e.next()
+		return fn(args...), nil
+	}
+	val, ok := e.vars[token]
+	if !ok {
+		return 0, fmt.Errorf("unrecognized variable `%s`", token)
+	}
+	return val, nil
+}
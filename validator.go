@@ -8,7 +8,7 @@ import (
 )
 
 const (
-	missing     = "???"
+	missing     = json.Missing
 	expType     = iota
 	queryType   = iota
 	binaryType  = iota
@@ -16,6 +16,20 @@ const (
 	invalidType = iota
 )
 
+// ValidationError represents a request that failed validation. It lists every
+// issue found in the request, while Error returns the request annotated with
+// each issue.
+type ValidationError struct {
+	// Issues lists every issue found in the request.
+	Issues []json.Issue
+	msg    string
+}
+
+// Error returns the annotated request.
+func (e *ValidationError) Error() string {
+	return e.msg
+}
+
 // validator parses a JSON query expression into its typed format. It
 // ensure all types are correct and that the syntax is valid.
 type validator struct {
@@ -39,11 +53,14 @@ func (v *validator) validateTileRequest(args map[string]interface{}) (*TileReque
 	// validate URI
 	req.URI = v.validateURI(args)
 
+	// parse the tile first, as the coord is checked against its projection
+	tile := v.parseTileArg(args)
+
 	// validate coord
-	req.Coord = v.validateCoord(args)
+	req.Coord = v.validateCoord(args, tile.tile)
 
 	// validate tile
-	req.Tile = v.validateTile(args)
+	req.Tile = v.validateTile(tile)
 
 	// validate query
 	req.Query = v.validateQuery(args)
@@ -51,9 +68,8 @@ func (v *validator) validateTileRequest(args map[string]interface{}) (*TileReque
 	v.EndObject()
 
 	// check for any errors
-	err := v.Error()
-	if err != nil {
-		return nil, err
+	if v.HasError() {
+		return nil, v.validationError("invalid tile request")
 	}
 	return req, nil
 }
@@ -73,13 +89,19 @@ func (v *validator) validateMetaRequest(args map[string]interface{}) (*MetaReque
 	v.EndObject()
 
 	// check for any errors
-	err := v.Error()
-	if err != nil {
-		return nil, err
+	if v.HasError() {
+		return nil, v.validationError("invalid meta request")
 	}
 	return req, nil
 }
 
+func (v *validator) validationError(msg string) *ValidationError {
+	return &ValidationError{
+		Issues: v.Issues(),
+		msg:    fmt.Sprintf("%s:\n%s", msg, v.String()),
+	}
+}
+
 // Parses the tile request JSON for the provided URI.
 //
 // Ex:
@@ -87,21 +109,27 @@ func (v *validator) validateMetaRequest(args map[string]interface{}) (*MetaReque
 //         "uri": "example-uri-value0"
 //     }
 //
-func (v *validator) parseURI(args map[string]interface{}) (string, error) {
+func (v *validator) parseURI(args map[string]interface{}) (interface{}, string, error) {
 	val, ok := args["uri"]
 	if !ok {
-		return missing, fmt.Errorf("`uri` not found")
+		return missing, "", &json.CodedError{
+			Code:    json.CodeMissing,
+			Message: "`uri` not found",
+		}
 	}
 	uri, ok := val.(string)
 	if !ok {
-		return fmt.Sprintf("%v", val), fmt.Errorf("`uri` not of type `string`")
+		return val, "", &json.CodedError{
+			Code:    json.CodeType,
+			Message: "`uri` not of type `string`",
+		}
 	}
-	return uri, nil
+	return uri, uri, nil
 }
 
 func (v *validator) validateURI(args map[string]interface{}) string {
-	uri, err := v.parseURI(args)
-	v.BufferKeyValue("uri", uri, err)
+	val, uri, err := v.parseURI(args)
+	v.BufferKeyValue("uri", val, err)
 	return uri
 }
 
@@ -119,35 +147,68 @@ func (v *validator) validateURI(args map[string]interface{}) string {
 func (v *validator) parseCoord(args map[string]interface{}) (interface{}, *binning.TileCoord, error) {
 	c, ok := args["coord"]
 	if !ok {
-		return nil, nil, fmt.Errorf("`coord` not found")
+		return nil, nil, &json.CodedError{
+			Code:    json.CodeMissing,
+			Message: "`coord` not found",
+		}
 	}
 	coord, ok := c.(map[string]interface{})
 	if !ok {
-		return c, nil, fmt.Errorf("`coord` is not of correct type")
+		return c, nil, &json.CodedError{
+			Code:    json.CodeType,
+			Message: "`coord` is not of correct type",
+		}
 	}
 	ix, ok := coord["x"]
 	if !ok {
-		return coord, nil, fmt.Errorf("`coord.x` not found")
+		return coord, nil, &json.CodedError{
+			Code:    json.CodeMissing,
+			Message: "`coord.x` not found",
+			Path:    []string{"x"},
+		}
 	}
 	x, ok := ix.(float64)
 	if !ok {
-		return coord, nil, fmt.Errorf("`coord.x` is not of type `number`")
+		return coord, nil, &json.CodedError{
+			Code:    json.CodeType,
+			Message: "`coord.x` is not of type `number`",
+			Path:    []string{"x"},
+			Value:   ix,
+		}
 	}
 	iy, ok := coord["y"]
 	if !ok {
-		return coord, nil, fmt.Errorf("`coord.y` not found")
+		return coord, nil, &json.CodedError{
+			Code:    json.CodeMissing,
+			Message: "`coord.y` not found",
+			Path:    []string{"y"},
+		}
 	}
 	y, ok := iy.(float64)
 	if !ok {
-		return coord, nil, fmt.Errorf("`coord.y` is not of type `number`")
+		return coord, nil, &json.CodedError{
+			Code:    json.CodeType,
+			Message: "`coord.y` is not of type `number`",
+			Path:    []string{"y"},
+			Value:   iy,
+		}
 	}
 	iz, ok := coord["z"]
 	if !ok {
-		return coord, nil, fmt.Errorf("`coord.z` not found")
+		return coord, nil, &json.CodedError{
+			Code:    json.CodeMissing,
+			Message: "`coord.z` not found",
+			Path:    []string{"z"},
+		}
 	}
 	z, ok := iz.(float64)
 	if !ok {
-		return coord, nil, fmt.Errorf("`coord.z` is not of type `number`")
+		return coord, nil, &json.CodedError{
+			Code:    json.CodeType,
+			Message: "`coord.z` is not of type `number`",
+			Path:    []string{"z"},
+			Value:   iz,
+		}
 	}
 	return coord, &binning.TileCoord{
 		X: uint32(x),
@@ -156,8 +217,11 @@ func (v *validator) parseCoord(args map[string]interface{}) (interface{}, *binni
 	}, nil
 }
 
-func (v *validator) validateCoord(args map[string]interface{}) *binning.TileCoord {
+func (v *validator) validateCoord(args map[string]interface{}, tile Tile) *binning.TileCoord {
 	params, coord, err := v.parseCoord(args)
+	if err == nil {
+		err = checkGrid(tile, coord)
+	}
 	if params != nil {
 		v.BufferKeyValue("coord", params, err)
 	} else {
@@ -166,6 +230,17 @@ func (v *validator) validateCoord(args map[string]interface{}) *binning.TileCoor
 	return coord
 }
 
+// checkGrid returns an error if the coord is outside of the grid of the
+// projection of the requested tile. Tiles which do not parse are nil, and are
+// reported when the tile is validated.
+func checkGrid(tile Tile, coord *binning.TileCoord) error {
+	projected, ok := tile.(binning.Projected)
+	if !ok {
+		return nil
+	}
+	return binning.ValidateTileCoord(projected.GetProjection(), coord)
+}
+
 // Parses the tile request JSON for the provided tile type and parameters.
 //
 // Ex:
@@ -186,7 +261,10 @@ func (v *validator) validateCoord(args map[string]interface{}) *binning.TileCoor
 func (v *validator) parseTile(args map[string]interface{}) (string, interface{}, Tile, error) {
 	id, params, ok := json.GetRandomChild(args)
 	if !ok {
-		return id, params, nil, fmt.Errorf("no tile type found")
+		return id, params, nil, &json.CodedError{
+			Code:    json.CodeMissing,
+			Message: "no tile type found",
+		}
 	}
 	tile, err := v.pipeline.GetTile(id, params)
 	if err != nil {
@@ -195,31 +273,64 @@ func (v *validator) parseTile(args map[string]interface{}) (string, interface{},
 	return id, params, tile, nil
 }
 
-func (v *validator) validateTile(args map[string]interface{}) Tile {
+// tileArg represents the parsed `tile` value of a tile request. The value is
+// set in place of the id and params if it is missing or not an object.
+type tileArg struct {
+	val    interface{}
+	id     string
+	params interface{}
+	tile   Tile
+	err    error
+}
+
+func (v *validator) parseTileArg(args map[string]interface{}) *tileArg {
 	// check if the tile key exists
 	arg, ok := args["tile"]
 	if !ok {
-		v.BufferKeyValue("tile", missing, fmt.Errorf("`tile` not found"))
-		return nil
+		return &tileArg{
+			val: missing,
+			err: &json.CodedError{
+				Code:    json.CodeMissing,
+				Message: "`tile` not found",
+			},
+		}
 	}
 
 	// check if the tile value is an object
 	val, ok := arg.(map[string]interface{})
 	if !ok {
-		v.BufferKeyValue("tile", arg, fmt.Errorf("`tile` is not of correct type"))
-		return nil
+		return &tileArg{
+			val: arg,
+			err: &json.CodedError{
+				Code:    json.CodeType,
+				Message: "`tile` is not of correct type",
+			},
+		}
 	}
 
 	// check if tile is correct
-	v.StartSubObject("tile")
 	id, params, tile, err := v.parseTile(val)
 	if id == "" {
 		id = missing
 		params = missing
 	}
-	v.BufferKeyValue(id, params, err)
+	return &tileArg{
+		id:     id,
+		params: params,
+		tile:   tile,
+		err:    err,
+	}
+}
+
+func (v *validator) validateTile(arg *tileArg) Tile {
+	if arg.id == "" {
+		v.BufferKeyValue("tile", arg.val, arg.err)
+		return nil
+	}
+	v.StartSubObject("tile")
+	v.BufferKeyValue(arg.id, arg.params, arg.err)
 	v.EndObject()
-	return tile
+	return arg.tile
 }
 
 // Parses the meta request JSON for the provided meta type and parameters.
@@ -234,7 +345,10 @@ func (v *validator) validateTile(args map[string]interface{}) Tile {
 func (v *validator) parseMeta(args map[string]interface{}) (string, interface{}, Meta, error) {
 	id, params, ok := json.GetRandomChild(args)
 	if !ok {
-		return id, params, nil, fmt.Errorf("no meta type found")
+		return id, params, nil, &json.CodedError{
+			Code:    json.CodeMissing,
+			Message: "no meta type found",
+		}
 	}
 	tile, err := v.pipeline.GetMeta(id, params)
 	if err != nil {
@@ -247,14 +361,20 @@ func (v *validator) validateMeta(args map[string]interface{}) Meta {
 	// check if the meta key exists
 	arg, ok := args["meta"]
 	if !ok {
-		v.BufferKeyValue("meta", missing, fmt.Errorf("`meta` not found"))
+		v.BufferKeyValue("meta", missing, &json.CodedError{
+			Code:    json.CodeMissing,
+			Message: "`meta` not found",
+		})
 		return nil
 	}
 
 	// check if the meta value is an object
 	val, ok := arg.(map[string]interface{})
 	if !ok {
-		v.BufferKeyValue("meta", arg, fmt.Errorf("`meta` is not of correct type"))
+		v.BufferKeyValue("meta", arg, &json.CodedError{
+			Code:    json.CodeType,
+			Message: "`meta` is not of correct type",
+		})
 		return nil
 	}
 
@@ -304,7 +424,10 @@ func (v *validator) validateQuery(args map[string]interface{}) Query {
 func (v *validator) parseQuery(args map[string]interface{}) (string, interface{}, Query, error) {
 	id, params, ok := json.GetRandomChild(args)
 	if !ok {
-		return id, params, nil, fmt.Errorf("no query type found")
+		return id, params, nil, &json.CodedError{
+			Code:    json.CodeMissing,
+			Message: "no query type found",
+		}
 	}
 	query, err := v.pipeline.GetQuery(id, params)
 	if err != nil {
@@ -343,7 +466,10 @@ func isValidBoolOperator(op string) bool {
 
 func (v *validator) validateOperatorToken(op string) interface{} {
 	if !isValidBoolOperator(op) {
-		v.BufferValue(op, fmt.Errorf("invalid operator"))
+		v.BufferValue(op, &json.CodedError{
+			Code:    json.CodeUnrecognized,
+			Message: "invalid operator",
+		})
 		return nil
 	}
 	v.BufferValue(op, nil)
@@ -363,7 +489,7 @@ func (v *validator) validateExpressionToken(exp []interface{}, first bool) inter
 	for i, current := range exp {
 		// next line
 		if !isTokenValid(last, current) {
-			v.StartError("unexpected token")
+			v.StartCodedError(json.CodeUnexpectedToken, "unexpected token", current)
 			v.validateToken(current, false)
 			v.EndError()
 			last = current
@@ -395,9 +521,15 @@ func (v *validator) validateToken(arg interface{}, first bool) interface{} {
 	}
 	// err
 	if first {
-		v.BufferKeyValue("query", fmt.Sprintf("%v", arg), fmt.Errorf("`query` is not of correct type"))
+		v.BufferKeyValue("query", fmt.Sprintf("%v", arg), &json.CodedError{
+			Code:    json.CodeType,
+			Message: "`query` is not of correct type",
+		})
 	} else {
-		v.BufferValue(arg, fmt.Errorf("unrecognized symbol"))
+		v.BufferValue(arg, &json.CodedError{
+			Code:    json.CodeUnrecognized,
+			Message: "unrecognized symbol",
+		})
 	}
 	return arg
 }
@@ -0,0 +1,222 @@
+package veldt_test
+
+import (
+	"github.com/unchartedsoftware/veldt"
+	"github.com/unchartedsoftware/veldt/util/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/unchartedsoftware/veldt/util/test"
+)
+
+var _ = Describe("validator", func() {
+
+	var pipeline *veldt.Pipeline
+
+	BeforeEach(func() {
+		pipeline = newTestPipeline()
+		pipeline.Binary(func() (veldt.Query, error) {
+			return &veldt.BinaryExpression{}, nil
+		})
+	})
+
+	Describe("NewTileRequest", func() {
+		It("should return a *ValidationError listing every issue", func() {
+			_, err := pipeline.NewTileRequest(JSON(
+				`{
+					"uri": 5,
+					"coord": {
+						"x": 0,
+						"y": "1"
+					},
+					"tile": {
+						"heatmap": {
+							"yField": "y",
+							"left": 0,
+							"right": 256,
+							"bottom": 0,
+							"top": 256
+						}
+					},
+					"query": [
+						{
+							"exists": {
+								"field": "a"
+							}
+						},
+						"AND",
+						{
+							"unknown": {}
+						}
+					]
+				}`))
+			Expect(err).NotTo(BeNil())
+			verr, ok := err.(*veldt.ValidationError)
+			Expect(ok).To(BeTrue())
+			Expect(verr.Error()).To(HavePrefix("invalid tile request:\n"))
+			Expect(verr.Issues).To(Equal([]json.Issue{
+				{Path: "/uri", Code: json.CodeType, Message: "`uri` not of type `string`", Value: 5.0},
+				{Path: "/coord/y", Code: json.CodeType, Message: "`coord.y` is not of type `number`", Value: "1"},
+				{Path: "/tile/heatmap/xField", Code: json.CodeMissing, Message: "`xField` parameter missing"},
+				{Path: "/query/2/unknown", Code: json.CodeUnrecognized, Message: "unrecognized query type `unknown`", Value: map[string]interface{}{}},
+			}))
+		})
+		It("should list every invalid parameter of a type", func() {
+			_, err := pipeline.NewTileRequest(JSON(
+				`{
+					"uri": "test",
+					"coord": {
+						"x": 0,
+						"y": 0,
+						"z": 0
+					},
+					"tile": {
+						"heatmap": {
+							"yField": 5,
+							"resolution": 2.5,
+							"left": 0,
+							"right": 256,
+							"bottom": 0,
+							"top": 256
+						}
+					}
+				}`))
+			Expect(err).NotTo(BeNil())
+			Expect(err.(*veldt.ValidationError).Issues).To(ConsistOf(
+				json.Issue{Path: "/tile/heatmap/xField", Code: json.CodeMissing, Message: "`xField` parameter missing"},
+				json.Issue{Path: "/tile/heatmap/yField", Code: json.CodeType, Message: "`yField` parameter is not of type `string`", Value: 5.0},
+				json.Issue{Path: "/tile/heatmap/resolution", Code: json.CodeType, Message: "`resolution` parameter is not of type `integer`", Value: 2.5},
+			))
+		})
+		It("should record unexpected tokens at their index", func() {
+			_, err := pipeline.NewTileRequest(JSON(
+				`{
+					"uri": "test",
+					"coord": {
+						"x": 0,
+						"y": 0,
+						"z": 0
+					},
+					"tile": {
+						"heatmap": {
+							"xField": "x",
+							"yField": "y",
+							"left": 0,
+							"right": 256,
+							"bottom": 0,
+							"top": 256
+						}
+					},
+					"query": [
+						{
+							"exists": {
+								"field": "a"
+							}
+						},
+						{
+							"exists": {
+								"field": "b"
+							}
+						}
+					]
+				}`))
+			Expect(err).NotTo(BeNil())
+			issues := err.(*veldt.ValidationError).Issues
+			Expect(len(issues)).To(Equal(1))
+			Expect(issues[0].Path).To(Equal("/query/1"))
+			Expect(issues[0].Code).To(Equal(json.CodeUnexpectedToken))
+		})
+		It("should return an error if the coord is outside of the tile grid", func() {
+			_, err := pipeline.NewTileRequest(JSON(
+				`{
+					"uri": "test",
+					"coord": {
+						"x": 2,
+						"y": 0,
+						"z": 1
+					},
+					"tile": {
+						"heatmap": {
+							"xField": "x",
+							"yField": "y",
+							"left": 0,
+							"right": 256,
+							"bottom": 0,
+							"top": 256
+						}
+					}
+				}`))
+			Expect(err).NotTo(BeNil())
+			issues := err.(*veldt.ValidationError).Issues
+			Expect(len(issues)).To(Equal(1))
+			Expect(issues[0].Path).To(Equal("/coord"))
+			Expect(issues[0].Code).To(Equal(json.CodeInvalid))
+		})
+		It("should parse the tile once to check the coord against its grid", func() {
+			parsed := 0
+			pipeline.Tile("heatmap", func() (veldt.Tile, error) {
+				parsed++
+				return &testTile{}, nil
+			})
+			_, err := pipeline.NewTileRequest(JSON(
+				`{
+					"uri": "test",
+					"coord": {
+						"x": 0,
+						"y": 0,
+						"z": 1
+					},
+					"tile": {
+						"heatmap": {
+							"xField": "x",
+							"yField": "y",
+							"left": 0,
+							"right": 256,
+							"bottom": 0,
+							"top": 256
+						}
+					}
+				}`))
+			Expect(err).To(BeNil())
+			Expect(parsed).To(Equal(1))
+		})
+		It("should return the request if it is valid", func() {
+			req, err := pipeline.NewTileRequest(JSON(
+				`{
+					"uri": "test",
+					"coord": {
+						"x": 0,
+						"y": 0,
+						"z": 0
+					},
+					"tile": {
+						"heatmap": {
+							"xField": "x",
+							"yField": "y",
+							"left": 0,
+							"right": 256,
+							"bottom": 0,
+							"top": 256
+						}
+					}
+				}`))
+			Expect(err).To(BeNil())
+			Expect(req.URI).To(Equal("test"))
+		})
+	})
+
+	Describe("NewMetaRequest", func() {
+		It("should return a *ValidationError if the meta is missing", func() {
+			_, err := pipeline.NewMetaRequest(JSON(
+				`{
+					"uri": "test"
+				}`))
+			Expect(err).NotTo(BeNil())
+			verr := err.(*veldt.ValidationError)
+			Expect(verr.Error()).To(HavePrefix("invalid meta request:\n"))
+			Expect(verr.Issues).To(Equal([]json.Issue{
+				{Path: "/meta", Code: json.CodeMissing, Message: "`meta` not found"},
+			}))
+		})
+	})
+})